@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/kroma-network/kroma/kroma-chain-ops/crossdomain"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+var (
+	L1RPCFlag = &cli.StringFlag{
+		Name:     "l1-rpc-url",
+		Usage:    "L1 RPC URL",
+		Required: true,
+		EnvVars:  []string{"L1_RPC_URL"},
+	}
+	L2RPCFlag = &cli.StringFlag{
+		Name:     "l2-rpc-url",
+		Usage:    "L2 RPC URL",
+		Required: true,
+		EnvVars:  []string{"L2_RPC_URL"},
+	}
+	DeploymentsFlag = &cli.StringFlag{
+		Name:     "deployments",
+		Usage:    "Path to the L1 deployments JSON file",
+		Required: true,
+	}
+	TxFlag = &cli.StringFlag{
+		Name:     "tx",
+		Usage:    "Hash of the L1 or L2 transaction that sent the messages",
+		Required: true,
+	}
+)
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	app := &cli.App{
+		Name:  "crossdomain",
+		Usage: "Follow cross domain messages of the Kroma bridges, and replay failed ones",
+		Commands: []*cli.Command{
+			{
+				Name:   "status",
+				Usage:  "Prints the messages sent by a transaction and their status",
+				Flags:  []cli.Flag{L1RPCFlag, L2RPCFlag, DeploymentsFlag, TxFlag},
+				Action: status,
+			},
+			{
+				Name:  "replay",
+				Usage: "Replays a failed message sent by a transaction with a higher gas limit",
+				Flags: []cli.Flag{
+					L1RPCFlag,
+					L2RPCFlag,
+					DeploymentsFlag,
+					TxFlag,
+					&cli.IntFlag{
+						Name:  "index",
+						Usage: "Index of the message among the messages sent by the transaction",
+					},
+					&cli.Uint64Flag{
+						Name:     "gas-limit",
+						Usage:    "Gas limit of the replay transaction",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "private-key",
+						Usage:    "Private key of the sender of the replay transaction",
+						Required: true,
+						EnvVars:  []string{"PRIVATE_KEY"},
+					},
+				},
+				Action: replay,
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error tracking cross domain messages", "err", err)
+	}
+}
+
+type clients struct {
+	l1, l2  *ethclient.Client
+	tracker *crossdomain.Tracker
+}
+
+func (c *clients) Close() {
+	c.l1.Close()
+	c.l2.Close()
+}
+
+func dial(ctx *cli.Context) (*clients, error) {
+	deployments, err := genesis.NewL1Deployments(ctx.String(DeploymentsFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	l1, err := ethclient.DialContext(ctx.Context, ctx.String(L1RPCFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1: %w", err)
+	}
+	l2, err := ethclient.DialContext(ctx.Context, ctx.String(L2RPCFlag.Name))
+	if err != nil {
+		l1.Close()
+		return nil, fmt.Errorf("failed to dial L2: %w", err)
+	}
+	tracker, err := crossdomain.NewTracker(crossdomain.TrackerConfig{
+		L1CrossDomainMessenger: deployments.L1CrossDomainMessengerProxy,
+		KromaPortal:            deployments.KromaPortalProxy,
+		L2OutputOracle:         deployments.L2OutputOracleProxy,
+	}, l1, l2)
+	if err != nil {
+		l1.Close()
+		l2.Close()
+		return nil, err
+	}
+	return &clients{l1: l1, l2: l2, tracker: tracker}, nil
+}
+
+func status(ctx *cli.Context) error {
+	c, err := dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	msgs, err := c.tracker.Track(ctx.Context, common.HexToHash(ctx.String(TxFlag.Name)))
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		log.Warn("Transaction did not send any cross domain message")
+	}
+	return jsonutil.WriteJSON("-", msgs, 0o644)
+}
+
+func replay(ctx *cli.Context) error {
+	c, err := dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	msgs, err := c.tracker.Track(ctx.Context, common.HexToHash(ctx.String(TxFlag.Name)))
+	if err != nil {
+		return err
+	}
+	index := ctx.Int("index")
+	if index < 0 || index >= len(msgs) {
+		return fmt.Errorf("transaction sent %d messages, no message at index %d", len(msgs), index)
+	}
+	msg := msgs[index]
+
+	// the message is replayed on the chain it is sent to
+	client := c.l2
+	if msg.Direction == crossdomain.L2ToL1 {
+		client = c.l1
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.String("private-key"), "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	chainID, err := client.ChainID(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx.Context
+	opts.GasLimit = ctx.Uint64("gas-limit")
+
+	tx, err := c.tracker.Replay(opts, msg)
+	if err != nil {
+		return err
+	}
+	log.Info("Sent replay tx", "hash", tx.Hash(), "message", msg.Hash, "direction", msg.Direction)
+	receipt, err := bind.WaitMined(ctx.Context, client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for tx %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("tx %s failed", tx.Hash())
+	}
+	log.Info("Replay tx confirmed", "hash", tx.Hash(), "block", receipt.BlockNumber)
+
+	// a replay that runs out of gas again does not revert, so check the message itself
+	msgs, err = c.tracker.Track(ctx.Context, msg.TxHash)
+	if err != nil {
+		return err
+	}
+	if msgs[index].Status != crossdomain.StatusRelayed {
+		return fmt.Errorf("message %s is still %s after the replay", msg.Hash, msgs[index].Status)
+	}
+	log.Info("Message relayed", "message", msg.Hash)
+	return nil
+}
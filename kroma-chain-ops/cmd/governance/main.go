@@ -0,0 +1,446 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+	"github.com/kroma-network/kroma/kroma-chain-ops/governance"
+)
+
+var (
+	DeploymentsFlag = &cli.StringFlag{
+		Name:     "deployments",
+		Usage:    "Path to the L1 deployments JSON file",
+		Required: true,
+	}
+	ProposalFlag = &cli.StringFlag{
+		Name:     "proposal",
+		Usage:    "Path to the proposal JSON file",
+		Required: true,
+	}
+	L1RPCFlag = &cli.StringFlag{
+		Name:     "l1-rpc-url",
+		Usage:    "L1 RPC URL",
+		Required: true,
+		EnvVars:  []string{"L1_RPC_URL"},
+	}
+	PrivateKeyFlag = &cli.StringFlag{
+		Name:     "private-key",
+		Usage:    "Private key of the sender, a council member",
+		Required: true,
+		EnvVars:  []string{"PRIVATE_KEY"},
+	}
+	SecurityCouncilFlag = &cli.BoolFlag{
+		Name:  "security-council",
+		Usage: "Submit the governor call as a SecurityCouncil multisig transaction, instead of calling the governor directly",
+	}
+)
+
+var buildFlags = []cli.Flag{
+	DeploymentsFlag,
+	&cli.StringFlag{
+		Name:     "out",
+		Usage:    "Path to write the proposal JSON file to",
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     "description",
+		Usage:    "Description of the proposal",
+		Required: true,
+	},
+	&cli.StringSliceFlag{
+		Name:  "upgrade",
+		Usage: "Upgrade the proxy of a contract to an implementation, as <contract>=<implementation>, e.g. KromaPortal=0x..",
+	},
+	&cli.StringSliceFlag{
+		Name:  "upgrade-call",
+		Usage: "Calldata to call the new implementation with after the upgrade, as <contract>=<calldata>",
+	},
+	&cli.Uint64Flag{
+		Name:  "gas-limit",
+		Usage: "Set the L2 gas limit in the SystemConfig",
+	},
+	&cli.StringFlag{
+		Name:  "batcher",
+		Usage: "Set the batcher address in the SystemConfig",
+	},
+	&cli.StringFlag{
+		Name:  "unsafe-block-signer",
+		Usage: "Set the unsafe block signer address in the SystemConfig",
+	},
+	&cli.StringFlag{
+		Name:  "gas-config",
+		Usage: "Set the L1 fee overhead and scalar in the SystemConfig, as <overhead>,<scalar>",
+	},
+	&cli.StringFlag{
+		Name:  "validator-reward-scalar",
+		Usage: "Set the validator reward scalar in the SystemConfig",
+	},
+}
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	txFlags := []cli.Flag{DeploymentsFlag, ProposalFlag, L1RPCFlag, PrivateKeyFlag, SecurityCouncilFlag}
+	app := &cli.App{
+		Name:  "governance",
+		Usage: "Build, propose, queue and execute UpgradeGovernor proposals",
+		Commands: []*cli.Command{
+			{
+				Name:   "build",
+				Usage:  "Builds a proposal of proxy upgrades and SystemConfig changes",
+				Flags:  buildFlags,
+				Action: build,
+			},
+			{
+				Name:   "decode",
+				Usage:  "Shows the ID and the decoded calls of a proposal",
+				Flags:  []cli.Flag{DeploymentsFlag, ProposalFlag},
+				Action: decode,
+			},
+			{
+				Name:   "status",
+				Usage:  "Shows the on-chain state of a proposal",
+				Flags:  []cli.Flag{DeploymentsFlag, ProposalFlag, L1RPCFlag},
+				Action: status,
+			},
+			{
+				Name:   "propose",
+				Usage:  "Proposes a proposal to the UpgradeGovernor",
+				Flags:  txFlags,
+				Action: sendAction("propose", proposeCheck, (*governance.Governor).ProposeCalldata),
+			},
+			{
+				Name:   "queue",
+				Usage:  "Queues a succeeded proposal in the TimeLock",
+				Flags:  txFlags,
+				Action: sendAction("queue", queueCheck, (*governance.Governor).QueueCalldata),
+			},
+			{
+				Name:   "execute",
+				Usage:  "Executes a queued proposal after the TimeLock delay",
+				Flags:  txFlags,
+				Action: sendAction("execute", executeCheck, (*governance.Governor).ExecuteCalldata),
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("Governance command failed", "err", err)
+	}
+}
+
+func build(ctx *cli.Context) error {
+	deployments, err := genesis.NewL1Deployments(ctx.String(DeploymentsFlag.Name))
+	if err != nil {
+		return err
+	}
+	upgradeCalls := make(map[string][]byte)
+	for _, arg := range ctx.StringSlice("upgrade-call") {
+		name, data, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid upgrade call %q", arg)
+		}
+		if upgradeCalls[name], err = hexutil.Decode(data); err != nil {
+			return fmt.Errorf("invalid calldata of %s: %w", name, err)
+		}
+	}
+
+	p := &governance.Proposal{Description: ctx.String("description")}
+	for _, arg := range ctx.StringSlice("upgrade") {
+		name, impl, ok := strings.Cut(arg, "=")
+		if !ok || !common.IsHexAddress(impl) {
+			return fmt.Errorf("invalid upgrade %q", arg)
+		}
+		call, err := governance.UpgradeCall(deployments, name, common.HexToAddress(impl), upgradeCalls[name])
+		if err != nil {
+			return err
+		}
+		delete(upgradeCalls, name)
+		p.Calls = append(p.Calls, call)
+	}
+	for name := range upgradeCalls {
+		return fmt.Errorf("upgrade call of %s without upgrade", name)
+	}
+
+	var sysCfgCalls []func() (governance.Call, error)
+	if ctx.IsSet("gas-limit") {
+		sysCfgCalls = append(sysCfgCalls, func() (governance.Call, error) {
+			return governance.SystemConfigCall(deployments, "setGasLimit", ctx.Uint64("gas-limit"))
+		})
+	}
+	if ctx.IsSet("batcher") {
+		batcher := common.HexToAddress(ctx.String("batcher"))
+		sysCfgCalls = append(sysCfgCalls, func() (governance.Call, error) {
+			return governance.SystemConfigCall(deployments, "setBatcherHash", common.BytesToHash(batcher.Bytes()))
+		})
+	}
+	if ctx.IsSet("unsafe-block-signer") {
+		sysCfgCalls = append(sysCfgCalls, func() (governance.Call, error) {
+			return governance.SystemConfigCall(deployments, "setUnsafeBlockSigner", common.HexToAddress(ctx.String("unsafe-block-signer")))
+		})
+	}
+	if ctx.IsSet("gas-config") {
+		overheadStr, scalarStr, _ := strings.Cut(ctx.String("gas-config"), ",")
+		overhead, ok1 := new(big.Int).SetString(overheadStr, 10)
+		scalar, ok2 := new(big.Int).SetString(scalarStr, 10)
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid gas config %q", ctx.String("gas-config"))
+		}
+		sysCfgCalls = append(sysCfgCalls, func() (governance.Call, error) {
+			return governance.SystemConfigCall(deployments, "setGasConfig", overhead, scalar)
+		})
+	}
+	if ctx.IsSet("validator-reward-scalar") {
+		scalar, ok := new(big.Int).SetString(ctx.String("validator-reward-scalar"), 10)
+		if !ok {
+			return fmt.Errorf("invalid validator reward scalar %q", ctx.String("validator-reward-scalar"))
+		}
+		sysCfgCalls = append(sysCfgCalls, func() (governance.Call, error) {
+			return governance.SystemConfigCall(deployments, "setValidatorRewardScalar", scalar)
+		})
+	}
+	for _, fn := range sysCfgCalls {
+		call, err := fn()
+		if err != nil {
+			return err
+		}
+		p.Calls = append(p.Calls, call)
+	}
+	if len(p.Calls) == 0 {
+		return errors.New("the proposal has no calls")
+	}
+
+	if err := jsonutil.WriteJSON(ctx.String("out"), p, 0o644); err != nil {
+		return err
+	}
+	return printProposal(deployments, p)
+}
+
+func decode(ctx *cli.Context) error {
+	deployments, p, err := loadProposal(ctx)
+	if err != nil {
+		return err
+	}
+	return printProposal(deployments, p)
+}
+
+func status(ctx *cli.Context) error {
+	deployments, p, err := loadProposal(ctx)
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx.Context, ctx.String(L1RPCFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	gov, err := newGovernor(deployments, client)
+	if err != nil {
+		return err
+	}
+	if err := printProposal(deployments, p); err != nil {
+		return err
+	}
+	st, err := gov.Status(ctx.Context, p)
+	if err != nil {
+		return err
+	}
+	printStatus(st)
+	return nil
+}
+
+type stateCheck func(st *governance.Status) error
+
+func proposeCheck(st *governance.Status) error {
+	if st.Proposed {
+		return fmt.Errorf("the proposal was already proposed, it is %s", st.State)
+	}
+	return nil
+}
+
+func queueCheck(st *governance.Status) error {
+	if !st.Proposed || st.State != governance.StateSucceeded {
+		return fmt.Errorf("only succeeded proposals can be queued, the proposal is %s", stateOf(st))
+	}
+	return nil
+}
+
+func executeCheck(st *governance.Status) error {
+	if !st.Proposed || st.State != governance.StateQueued {
+		return fmt.Errorf("only queued proposals can be executed, the proposal is %s", stateOf(st))
+	}
+	if !st.Ready {
+		return fmt.Errorf("the timelock delay did not pass yet, the proposal can be executed at %s", st.Eta)
+	}
+	return nil
+}
+
+func stateOf(st *governance.Status) string {
+	if !st.Proposed {
+		return "not proposed"
+	}
+	return st.State.String()
+}
+
+// sendAction sends the governor call of the proposal, after checking the proposal is in the right state for it.
+func sendAction(name string, check stateCheck, calldata func(*governance.Governor, *governance.Proposal) ([]byte, error)) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		deployments, p, err := loadProposal(ctx)
+		if err != nil {
+			return err
+		}
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.String(PrivateKeyFlag.Name), "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+		client, err := ethclient.DialContext(ctx.Context, ctx.String(L1RPCFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		gov, err := newGovernor(deployments, client)
+		if err != nil {
+			return err
+		}
+		if err := printProposal(deployments, p); err != nil {
+			return err
+		}
+		st, err := gov.Status(ctx.Context, p)
+		if err != nil {
+			return err
+		}
+		printStatus(st)
+		if err := check(st); err != nil {
+			return err
+		}
+
+		to := gov.Address()
+		data, err := calldata(gov, p)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		if ctx.Bool(SecurityCouncilFlag.Name) {
+			if deployments.SecurityCouncilProxy == (common.Address{}) {
+				return errors.New("SecurityCouncilProxy is not deployed")
+			}
+			if data, err = governance.SecurityCouncilCalldata(to, data); err != nil {
+				return fmt.Errorf("failed to encode security council transaction: %w", err)
+			}
+			to = deployments.SecurityCouncilProxy
+		}
+		decoder, err := governance.NewDecoder(deployments)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Sending %s:\n%s", name, decoder.DecodeCall(to, nil, data))
+		if err := sendTx(ctx.Context, client, key, to, data); err != nil {
+			return err
+		}
+
+		if st, err = gov.Status(ctx.Context, p); err != nil {
+			return err
+		}
+		printStatus(st)
+		return nil
+	}
+}
+
+func sendTx(ctx context.Context, client *ethclient.Client, key *ecdsa.PrivateKey, to common.Address, data []byte) error {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+	tx, err := bind.NewBoundContract(to, abi.ABI{}, client, client, client).RawTransact(opts, data)
+	if err != nil {
+		return fmt.Errorf("failed to send tx: %w", err)
+	}
+	log.Info("Sent tx", "hash", tx.Hash(), "from", opts.From, "to", to)
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for tx %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("tx %s failed", tx.Hash())
+	}
+	log.Info("Tx confirmed", "hash", tx.Hash(), "block", receipt.BlockNumber)
+	return nil
+}
+
+func loadProposal(ctx *cli.Context) (*genesis.L1Deployments, *governance.Proposal, error) {
+	deployments, err := genesis.NewL1Deployments(ctx.String(DeploymentsFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := jsonutil.LoadJSON[governance.Proposal](ctx.String(ProposalFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployments, p, nil
+}
+
+func newGovernor(deployments *genesis.L1Deployments, client *ethclient.Client) (*governance.Governor, error) {
+	if deployments.UpgradeGovernorProxy == (common.Address{}) || deployments.TimeLockProxy == (common.Address{}) {
+		return nil, errors.New("UpgradeGovernorProxy and TimeLockProxy must be deployed")
+	}
+	return governance.NewGovernor(deployments.UpgradeGovernorProxy, deployments.TimeLockProxy, client)
+}
+
+func printProposal(deployments *genesis.L1Deployments, p *governance.Proposal) error {
+	id, err := p.ID()
+	if err != nil {
+		return err
+	}
+	decoder, err := governance.NewDecoder(deployments)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Proposal %s\n", id)
+	fmt.Printf("Description: %s\n", p.Description)
+	for i, call := range p.Calls {
+		fmt.Printf("Call %d:\n%s", i, decoder.DecodeCall(call.Target, call.Value, call.Data))
+	}
+	return nil
+}
+
+func printStatus(st *governance.Status) {
+	if !st.Proposed {
+		fmt.Println("State: not proposed")
+		return
+	}
+	fmt.Printf("State: %s\n", st.State)
+	fmt.Printf("Voting: from %s to %s\n", st.Snapshot, st.Deadline)
+	fmt.Printf("Votes: for %s, against %s, abstain %s\n", st.ForVotes, st.AgainstVotes, st.AbstainVotes)
+	if st.Quorum != nil {
+		fmt.Printf("Quorum: %s\n", st.Quorum)
+	}
+	if st.Eta.Sign() != 0 {
+		fmt.Printf("Executable at: %s (ready: %s)\n", st.Eta, strconv.FormatBool(st.Ready))
+	}
+}
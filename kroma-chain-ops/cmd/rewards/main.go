@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+	"github.com/kroma-network/kroma/kroma-chain-ops/rewards"
+)
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	app := &cli.App{
+		Name:  "rewards",
+		Usage: "Report the validator rewards and governance token mints of a range of outputs",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "l1-rpc-url",
+				Required: true,
+				Usage:    "L1 RPC URL",
+				EnvVars:  []string{"L1_RPC_URL"},
+			},
+			&cli.StringFlag{
+				Name:     "l2-rpc-url",
+				Required: true,
+				Usage:    "L2 RPC URL",
+				EnvVars:  []string{"L2_RPC_URL"},
+			},
+			&cli.StringFlag{
+				Name:     "deployments",
+				Required: true,
+				Usage:    "File system path to the L1 deployments JSON file",
+			},
+			&cli.Uint64Flag{
+				Name:     "from-l2-block",
+				Required: true,
+				Usage:    "First L2 block of the outputs to report",
+			},
+			&cli.Uint64Flag{
+				Name:     "to-l2-block",
+				Required: true,
+				Usage:    "Last L2 block of the outputs to report",
+			},
+			&cli.Uint64Flag{
+				Name:     "l1-start-block",
+				Required: true,
+				Usage:    "L1 block from which the validator reward scalar updates are scanned, it must be before the outputs",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "csv",
+				Usage: "Output format, csv or json",
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Value: "-",
+				Usage: "File system path to write the report to, - for stdout",
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error reporting rewards", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if ctx.Uint64("from-l2-block") > ctx.Uint64("to-l2-block") {
+		return errors.New("from-l2-block is after to-l2-block")
+	}
+
+	deployments, err := genesis.NewL1Deployments(ctx.String("deployments"))
+	if err != nil {
+		return err
+	}
+	l1Client, err := ethclient.DialContext(ctx.Context, ctx.String("l1-rpc-url"))
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+	l2Client, err := ethclient.DialContext(ctx.Context, ctx.String("l2-rpc-url"))
+	if err != nil {
+		return err
+	}
+	defer l2Client.Close()
+
+	fetcher, err := rewards.NewFetcher(rewards.Config{
+		L2OutputOracle: deployments.L2OutputOracleProxy,
+		SystemConfig:   deployments.SystemConfigProxy,
+		L1StartBlock:   ctx.Uint64("l1-start-block"),
+	}, l1Client, l2Client)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Reporting rewards", "from", ctx.Uint64("from-l2-block"), "to", ctx.Uint64("to-l2-block"))
+	report, err := fetcher.Report(ctx.Context, ctx.Uint64("from-l2-block"), ctx.Uint64("to-l2-block"))
+	if err != nil {
+		return err
+	}
+	for _, s := range report.Validators {
+		log.Info("Validator rewards", "validator", s.Validator, "submitted", s.Submitted, "rewarded", s.Rewarded, "total", s.Total)
+	}
+
+	out := ctx.String("out")
+	if format == "json" {
+		return jsonutil.WriteJSON(out, report, 0o644)
+	}
+	if out == "-" {
+		return report.WriteCSV(os.Stdout)
+	}
+	f, err := ioutil.NewAtomicWriterCompressed(out, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer f.Close()
+	if err := report.WriteCSV(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+	"github.com/kroma-network/kroma/kroma-chain-ops/upgrade"
+)
+
+var (
+	FromConfigFlag = &cli.StringFlag{
+		Name:  "from-config",
+		Usage: "Path to the deploy config of the current chain",
+	}
+	FromGenesisFlag = &cli.StringFlag{
+		Name:  "from-genesis",
+		Usage: "Path to the L2 genesis of the current chain, e.g. built with older contract artifacts",
+	}
+	ToConfigFlag = &cli.StringFlag{
+		Name:  "to-config",
+		Usage: "Path to the deploy config after the upgrade",
+	}
+	ToGenesisFlag = &cli.StringFlag{
+		Name:  "to-genesis",
+		Usage: "Path to the L2 genesis after the upgrade",
+	}
+	UpgradeNameFlag = &cli.StringFlag{
+		Name:  "upgrade-name",
+		Usage: "Name of the upgrade, used as prefix of the intents the deposit source hashes are derived from",
+		Value: "Upgrade",
+	}
+	OutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "Path to write the upgrade deposit transactions to as JSON",
+	}
+	JSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the diff as JSON instead of text",
+	}
+)
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	app := &cli.App{
+		Name:  "upgrade-diff",
+		Usage: "Diff the L2 predeploys of two deploy configs or genesis files, and build the deposit txs to upgrade a live chain",
+		Flags: []cli.Flag{
+			FromConfigFlag,
+			FromGenesisFlag,
+			ToConfigFlag,
+			ToGenesisFlag,
+			UpgradeNameFlag,
+			OutFlag,
+			JSONFlag,
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error diffing predeploys", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	from, err := loadSide(ctx, FromConfigFlag, FromGenesisFlag)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := loadSide(ctx, ToConfigFlag, ToGenesisFlag)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+
+	diffs, err := upgrade.Diff(from, to)
+	if err != nil {
+		return err
+	}
+	txs, err := upgrade.Transactions(ctx.String(UpgradeNameFlag.Name), from, to)
+	if err != nil {
+		return err
+	}
+	left, err := upgrade.Unmigratable(ctx.String(UpgradeNameFlag.Name), from, to)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(JSONFlag.Name) {
+		if err := jsonutil.WriteJSON("-", map[string]any{
+			"diff":         diffs,
+			"transactions": txs,
+			"unmigratable": left,
+		}, 0o644); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("%d predeploys differ\n", len(diffs))
+		printDiffs(diffs)
+		fmt.Printf("\n%d upgrade transactions\n", len(txs))
+		signer := types.LatestSignerForChainID(to.Genesis.Config.ChainID)
+		for _, tx := range txs {
+			if err := printTx(signer, tx); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("\n%d predeploys still differ after the upgrade transactions\n", len(left))
+		printDiffs(left)
+	}
+
+	return jsonutil.WriteJSON(ctx.String(OutFlag.Name), txs, 0o644)
+}
+
+func loadSide(ctx *cli.Context, configFlag, genesisFlag *cli.StringFlag) (*upgrade.Side, error) {
+	switch {
+	case ctx.IsSet(configFlag.Name) && ctx.IsSet(genesisFlag.Name):
+		return nil, fmt.Errorf("only one of --%s and --%s can be set", configFlag.Name, genesisFlag.Name)
+	case ctx.IsSet(configFlag.Name):
+		config, err := genesis.NewDeployConfig(ctx.String(configFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		// The L1 start block only fills the L1Block storage, so a stub at the L2OO starting timestamp is enough.
+		l1StartBlock := types.NewBlock(&types.Header{
+			Number:  common.Big0,
+			Time:    uint64(config.L2OutputOracleStartingTimestamp),
+			BaseFee: big.NewInt(1),
+		}, nil, nil, nil, trie.NewStackTrie(nil))
+		return upgrade.FromConfig(config, l1StartBlock)
+	case ctx.IsSet(genesisFlag.Name):
+		gen, err := jsonutil.LoadJSON[core.Genesis](ctx.String(genesisFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		return upgrade.FromGenesis(gen), nil
+	default:
+		return nil, errors.New("either a deploy config or a genesis must be set")
+	}
+}
+
+func printDiffs(diffs []*upgrade.PredeployDiff) {
+	for _, d := range diffs {
+		fmt.Printf("\n%s (%s)\n", d.Name, d.Address)
+		if d.Code != nil {
+			fmt.Printf("  code hash: %s -> %s\n", d.Code.From, d.Code.To)
+		}
+		if d.ImplementationCode != nil {
+			fmt.Printf("  implementation code hash: %s -> %s\n", d.ImplementationCode.From, d.ImplementationCode.To)
+		}
+		fields := make([]string, 0, len(d.Immutables))
+		for field := range d.Immutables {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fmt.Printf("  immutable %s: %s -> %s\n", field, d.Immutables[field].From, d.Immutables[field].To)
+		}
+		printSlots("storage", d.Storage)
+		printSlots("implementation storage", d.ImplementationStorage)
+	}
+}
+
+func printSlots(kind string, slots []upgrade.SlotDiff) {
+	for _, slot := range slots {
+		fmt.Printf("  %s %s: %s -> %s\n", kind, slot.Slot, slot.From, slot.To)
+		for _, field := range slot.Fields {
+			fmt.Printf("    %s (%s): %s -> %s\n", field.Label, field.Type, field.From, field.To)
+		}
+	}
+}
+
+func printTx(signer types.Signer, tx upgrade.Transaction) error {
+	var decoded types.Transaction
+	if err := decoded.UnmarshalBinary(tx.Tx); err != nil {
+		return fmt.Errorf("failed to decode %q: %w", tx.Intent, err)
+	}
+	from, err := signer.Sender(&decoded)
+	if err != nil {
+		return fmt.Errorf("failed to get the sender of %q: %w", tx.Intent, err)
+	}
+	to := "(contract creation)"
+	if decoded.To() != nil {
+		to = decoded.To().Hex()
+	}
+	fmt.Printf("\n%s\n", tx.Intent)
+	fmt.Printf("  source hash: %s\n", decoded.SourceHash())
+	fmt.Printf("  from: %s\n  to: %s\n  gas: %d\n  data: %d bytes\n", from, to, decoded.Gas(), len(decoded.Data()))
+	fmt.Printf("  tx: %s\n", tx.Tx)
+	return nil
+}
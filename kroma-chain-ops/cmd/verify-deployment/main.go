@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+	"github.com/kroma-network/kroma/kroma-chain-ops/verify"
+)
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	app := &cli.App{
+		Name:  "verify-deployment",
+		Usage: "Check that the deployed L1 contracts match a deploy config",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "l1-rpc-url",
+				Required: true,
+				Usage:    "L1 RPC URL",
+				EnvVars:  []string{"L1_RPC_URL"},
+			},
+			&cli.StringFlag{
+				Name:     "deploy-config",
+				Required: true,
+				Usage:    "File system path to the deploy config",
+			},
+			&cli.StringFlag{
+				Name:     "deployments",
+				Required: true,
+				Usage:    "File system path to the L1 deployments JSON file",
+			},
+			&cli.StringFlag{
+				Name:     "proxy-admin-owner",
+				Required: true,
+				Usage:    "Owner of the L1 ProxyAdmin, i.e. the account that deployed the L1 contracts",
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error verifying deployment", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	config, err := genesis.NewDeployConfig(ctx.String("deploy-config"))
+	if err != nil {
+		return err
+	}
+	deployments, err := genesis.NewL1Deployments(ctx.String("deployments"))
+	if err != nil {
+		return err
+	}
+	owner := ctx.String("proxy-admin-owner")
+	if !common.IsHexAddress(owner) {
+		return fmt.Errorf("invalid proxy admin owner %q", owner)
+	}
+	client, err := ethclient.DialContext(ctx.Context, ctx.String("l1-rpc-url"))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	log.Info("Verifying deployment", "deploy-config", ctx.String("deploy-config"), "deployments", ctx.String("deployments"))
+	differences, err := verify.NewVerifier(client, config, deployments, common.HexToAddress(owner)).Verify(ctx.Context)
+	if err != nil {
+		return err
+	}
+	for _, d := range differences {
+		log.Error("Deployment differs from deploy config", "contract", d.Contract, "field", d.Field, "expected", d.Expected, "actual", d.Actual)
+	}
+	if len(differences) > 0 {
+		return fmt.Errorf("found %d differences", len(differences))
+	}
+
+	log.Info("Deployment matches the deploy config")
+	return nil
+}
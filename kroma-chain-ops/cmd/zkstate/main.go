@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+	"github.com/kroma-network/kroma/kroma-chain-ops/zkstate"
+)
+
+var (
+	GenesisFlag = &cli.StringFlag{
+		Name:  "genesis",
+		Usage: "Path to an L2 genesis JSON file, to build the state of its allocs",
+	}
+	DumpFlag = &cli.StringFlag{
+		Name:  "dump",
+		Usage: "Path to a state dump JSON file, to build the state of its accounts",
+	}
+)
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	app := &cli.App{
+		Name:  "zkstate",
+		Usage: "Compute the zkTrie state root and proofs of a genesis or a state dump, without a running node",
+		Commands: []*cli.Command{
+			{
+				Name:   "root",
+				Usage:  "Prints the state root and the L2ToL1MessagePasser storage root",
+				Flags:  []cli.Flag{GenesisFlag, DumpFlag},
+				Action: root,
+			},
+			{
+				Name:  "proof",
+				Usage: "Prints the account proof and the storage proofs, as returned by eth_getProof",
+				Flags: []cli.Flag{
+					GenesisFlag,
+					DumpFlag,
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "Address of the account to prove",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:  "slot",
+						Usage: "Storage slot of the account to prove, can be repeated",
+					},
+				},
+				Action: proof,
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error computing zkTrie state", "err", err)
+	}
+}
+
+func loadState(ctx *cli.Context) (*zkstate.State, error) {
+	switch {
+	case ctx.IsSet(GenesisFlag.Name) && ctx.IsSet(DumpFlag.Name):
+		return nil, errors.New("only one of --genesis and --dump can be set")
+	case ctx.IsSet(GenesisFlag.Name):
+		gen, err := jsonutil.LoadJSON[core.Genesis](ctx.String(GenesisFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		return zkstate.FromAlloc(gen.Alloc)
+	case ctx.IsSet(DumpFlag.Name):
+		dump, err := genesis.NewStateDump(ctx.String(DumpFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		return zkstate.FromDump(dump)
+	default:
+		return nil, errors.New("one of --genesis and --dump must be set")
+	}
+}
+
+func root(ctx *cli.Context) error {
+	s, err := loadState(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("State root: %s\n", s.Root())
+	fmt.Printf("L2ToL1MessagePasser storage root: %s\n", s.StorageRoot(predeploys.L2ToL1MessagePasserAddr))
+	return nil
+}
+
+func proof(ctx *cli.Context) error {
+	s, err := loadState(ctx)
+	if err != nil {
+		return err
+	}
+	addr := ctx.String("address")
+	if !common.IsHexAddress(addr) {
+		return fmt.Errorf("invalid address %q", addr)
+	}
+	var slots []common.Hash
+	for _, slot := range ctx.StringSlice("slot") {
+		slots = append(slots, common.HexToHash(slot))
+	}
+	res, err := s.Proof(common.HexToAddress(addr), slots...)
+	if err != nil {
+		return err
+	}
+	// the proof of an absent account proves its absence, which Verify does not check
+	if s.Account(res.Address) == nil {
+		log.Warn("Account is not in the state", "address", res.Address)
+	} else if err := res.Verify(s.Root()); err != nil {
+		return fmt.Errorf("generated proof does not verify: %w", err)
+	}
+	return jsonutil.WriteJSON("-", res, 0o644)
+}
@@ -0,0 +1,354 @@
+package crossdomain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+)
+
+// Direction is the direction a cross domain message is sent in.
+type Direction string
+
+const (
+	L1ToL2 Direction = "L1->L2"
+	L2ToL1 Direction = "L2->L1"
+)
+
+// MessageStatus is the state of a cross domain message on its way to the other chain.
+type MessageStatus string
+
+const (
+	// StatusPending means that the deposit of an L1 to L2 message is not executed on L2 yet.
+	StatusPending MessageStatus = "pending"
+	// StatusWaitingForOutput means that no output covering the L2 block of the withdrawal is submitted yet.
+	StatusWaitingForOutput MessageStatus = "waiting for output"
+	// StatusReadyToProve means that the withdrawal can be proven on L1.
+	StatusReadyToProve MessageStatus = "ready to prove"
+	// StatusProven means that the withdrawal is proven, but the output it is proven against is not finalized yet.
+	StatusProven MessageStatus = "proven"
+	// StatusReadyToFinalize means that the withdrawal can be finalized on L1.
+	StatusReadyToFinalize MessageStatus = "ready to finalize"
+	// StatusRelayed means that the message was successfully relayed to its target.
+	StatusRelayed MessageStatus = "relayed"
+	// StatusFailed means that the call to the target failed, the message can be replayed.
+	StatusFailed MessageStatus = "failed"
+)
+
+// TrackedMessage is a cross domain message sent by a transaction, with its status on the other chain.
+type TrackedMessage struct {
+	Direction Direction           `json:"direction"`
+	Message   *CrossDomainMessage `json:"message"`
+	Hash      common.Hash         `json:"hash"`
+	TxHash    common.Hash         `json:"txHash"`
+	// BlockNumber is the block of the transaction, on the chain the message is sent from.
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// Withdrawal is only set for L2 to L1 messages.
+	Withdrawal     *Withdrawal  `json:"withdrawal,omitempty"`
+	WithdrawalHash *common.Hash `json:"withdrawalHash,omitempty"`
+	// L2OutputIndex is the output a withdrawal is proven against.
+	L2OutputIndex *big.Int `json:"l2OutputIndex,omitempty"`
+
+	Status MessageStatus `json:"status"`
+}
+
+// Client is the part of an ethclient.Client that the Tracker uses.
+type Client interface {
+	bind.ContractBackend
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// TrackerConfig holds the addresses of the L1 contracts of the bridge.
+type TrackerConfig struct {
+	L1CrossDomainMessenger common.Address
+	KromaPortal            common.Address
+	L2OutputOracle         common.Address
+}
+
+// Tracker follows cross domain messages from the transaction that sends them to their relay on the other chain.
+type Tracker struct {
+	cfg TrackerConfig
+	l1  Client
+	l2  Client
+
+	l1Messenger   *bindings.L1CrossDomainMessenger
+	l2Messenger   *bindings.L2CrossDomainMessenger
+	messagePasser *bindings.L2ToL1MessagePasser
+	portal        *bindings.KromaPortal
+	l2oo          *bindings.L2OutputOracle
+}
+
+func NewTracker(cfg TrackerConfig, l1, l2 Client) (*Tracker, error) {
+	l1Messenger, err := bindings.NewL1CrossDomainMessenger(cfg.L1CrossDomainMessenger, l1)
+	if err != nil {
+		return nil, err
+	}
+	l2Messenger, err := bindings.NewL2CrossDomainMessenger(predeploys.L2CrossDomainMessengerAddr, l2)
+	if err != nil {
+		return nil, err
+	}
+	messagePasser, err := bindings.NewL2ToL1MessagePasser(predeploys.L2ToL1MessagePasserAddr, l2)
+	if err != nil {
+		return nil, err
+	}
+	portal, err := bindings.NewKromaPortal(cfg.KromaPortal, l1)
+	if err != nil {
+		return nil, err
+	}
+	l2oo, err := bindings.NewL2OutputOracle(cfg.L2OutputOracle, l1)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{
+		cfg:           cfg,
+		l1:            l1,
+		l2:            l2,
+		l1Messenger:   l1Messenger,
+		l2Messenger:   l2Messenger,
+		messagePasser: messagePasser,
+		portal:        portal,
+		l2oo:          l2oo,
+	}, nil
+}
+
+// Track returns the messages sent by the transaction, which is looked up on L1 first and then on L2.
+func (t *Tracker) Track(ctx context.Context, txHash common.Hash) ([]*TrackedMessage, error) {
+	receipt, err := t.l1.TransactionReceipt(ctx, txHash)
+	if err == nil {
+		return t.trackL1ToL2(ctx, receipt)
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("failed to get L1 receipt: %w", err)
+	}
+	receipt, err = t.l2.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 receipt: %w", err)
+	}
+	return t.trackL2ToL1(ctx, receipt)
+}
+
+func (t *Tracker) trackL1ToL2(ctx context.Context, receipt *types.Receipt) ([]*TrackedMessage, error) {
+	msgs, err := SentMessages(receipt, t.cfg.L1CrossDomainMessenger)
+	if err != nil {
+		return nil, err
+	}
+	tracked := make([]*TrackedMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		m, err := newTrackedMessage(L1ToL2, msg, receipt)
+		if err != nil {
+			return nil, err
+		}
+		if m.Status, err = relayStatus(ctx, &t.l2Messenger.L2CrossDomainMessengerCaller, m.Hash); err != nil {
+			return nil, err
+		}
+		if m.Status == "" {
+			m.Status = StatusPending
+		}
+		tracked = append(tracked, m)
+	}
+	return tracked, nil
+}
+
+func (t *Tracker) trackL2ToL1(ctx context.Context, receipt *types.Receipt) ([]*TrackedMessage, error) {
+	msgs, err := SentMessages(receipt, predeploys.L2CrossDomainMessengerAddr)
+	if err != nil {
+		return nil, err
+	}
+	withdrawals, err := MessagesPassed(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make([]*TrackedMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		m, err := newTrackedMessage(L2ToL1, msg, receipt)
+		if err != nil {
+			return nil, err
+		}
+		if m.Withdrawal, err = findWithdrawal(msg, withdrawals); err != nil {
+			return nil, err
+		}
+		withdrawalHash, err := m.Withdrawal.Hash()
+		if err != nil {
+			return nil, err
+		}
+		m.WithdrawalHash = &withdrawalHash
+		if err := t.withdrawalStatus(ctx, m); err != nil {
+			return nil, err
+		}
+		tracked = append(tracked, m)
+	}
+	return tracked, nil
+}
+
+func (t *Tracker) withdrawalStatus(ctx context.Context, m *TrackedMessage) error {
+	opts := &bind.CallOpts{Context: ctx}
+	status, err := relayStatus(ctx, &t.l1Messenger.L1CrossDomainMessengerCaller, m.Hash)
+	if err != nil || status != "" {
+		m.Status = status
+		return err
+	}
+
+	proven, err := t.portal.ProvenWithdrawals(opts, *m.WithdrawalHash)
+	if err != nil {
+		return fmt.Errorf("failed to get proven withdrawal %s: %w", m.WithdrawalHash, err)
+	}
+	if proven.Timestamp.Sign() != 0 {
+		m.L2OutputIndex = proven.L2OutputIndex
+		finalized, err := t.portal.IsOutputFinalized(opts, proven.L2OutputIndex)
+		if err != nil {
+			return fmt.Errorf("failed to check if output %d is finalized: %w", proven.L2OutputIndex, err)
+		}
+		m.Status = StatusProven
+		if finalized {
+			m.Status = StatusReadyToFinalize
+		}
+		return nil
+	}
+
+	latest, err := t.l2oo.LatestBlockNumber(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get latest output block number: %w", err)
+	}
+	m.Status = StatusWaitingForOutput
+	if latest.Uint64() >= m.BlockNumber {
+		m.Status = StatusReadyToProve
+	}
+	return nil
+}
+
+// messengerCaller is implemented by the callers of both messengers.
+type messengerCaller interface {
+	SuccessfulMessages(opts *bind.CallOpts, arg0 [32]byte) (bool, error)
+	FailedMessages(opts *bind.CallOpts, arg0 [32]byte) (bool, error)
+}
+
+// relayStatus returns the status of the message on the messenger it is relayed by, or an empty status
+// if the messenger has not seen the message yet.
+func relayStatus(ctx context.Context, messenger messengerCaller, msgHash common.Hash) (MessageStatus, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	success, err := messenger.SuccessfulMessages(opts, msgHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if message %s is relayed: %w", msgHash, err)
+	}
+	if success {
+		return StatusRelayed, nil
+	}
+	failed, err := messenger.FailedMessages(opts, msgHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if message %s failed: %w", msgHash, err)
+	}
+	if failed {
+		return StatusFailed, nil
+	}
+	return "", nil
+}
+
+// Replay relays a failed message again, so that the target is called with the gas limit of the transact opts.
+// The value of the message is held by the messenger since the first relay, so no value is sent.
+func (t *Tracker) Replay(opts *bind.TransactOpts, m *TrackedMessage) (*types.Transaction, error) {
+	if m.Status != StatusFailed {
+		return nil, fmt.Errorf("message %s is %s, only failed messages can be replayed", m.Hash, m.Status)
+	}
+	replayOpts := *opts
+	replayOpts.Value = nil
+
+	msg := m.Message
+	switch m.Direction {
+	case L1ToL2:
+		return t.l2Messenger.RelayMessage(&replayOpts, msg.Nonce, msg.Sender, msg.Target, msg.Value, msg.GasLimit, msg.Data)
+	case L2ToL1:
+		return t.l1Messenger.RelayMessage(&replayOpts, msg.Nonce, msg.Sender, msg.Target, msg.Value, msg.GasLimit, msg.Data)
+	default:
+		return nil, fmt.Errorf("unknown direction %q", m.Direction)
+	}
+}
+
+func newTrackedMessage(direction Direction, msg *CrossDomainMessage, receipt *types.Receipt) (*TrackedMessage, error) {
+	hash, err := msg.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash message with nonce %d: %w", msg.Nonce, err)
+	}
+	return &TrackedMessage{
+		Direction:   direction,
+		Message:     msg,
+		Hash:        hash,
+		TxHash:      receipt.TxHash,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+	}, nil
+}
+
+// SentMessages returns the messages of the SentMessage events the messenger emitted in the receipt.
+// Both messengers emit the same event, so the L1 binding parses the events of both.
+func SentMessages(receipt *types.Receipt, messenger common.Address) ([]*CrossDomainMessage, error) {
+	messengerABI, err := bindings.L1CrossDomainMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := bindings.NewL1CrossDomainMessengerFilterer(messenger, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*CrossDomainMessage, 0)
+	for _, log := range receipt.Logs {
+		if log.Address != messenger || len(log.Topics) == 0 || log.Topics[0] != messengerABI.Events["SentMessage"].ID {
+			continue
+		}
+		ev, err := filterer.ParseSentMessage(*log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SentMessage log %d: %w", log.Index, err)
+		}
+		msgs = append(msgs, NewCrossDomainMessage(ev.MessageNonce, ev.Sender, ev.Target, ev.Value, ev.GasLimit, ev.Message))
+	}
+	return msgs, nil
+}
+
+// MessagesPassed returns the withdrawals of the MessagePassed events of the L2ToL1MessagePasser in the receipt.
+func MessagesPassed(receipt *types.Receipt) ([]*Withdrawal, error) {
+	passerABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := bindings.NewL2ToL1MessagePasserFilterer(predeploys.L2ToL1MessagePasserAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals := make([]*Withdrawal, 0)
+	for _, log := range receipt.Logs {
+		if log.Address != predeploys.L2ToL1MessagePasserAddr || len(log.Topics) == 0 || log.Topics[0] != passerABI.Events["MessagePassed"].ID {
+			continue
+		}
+		ev, err := filterer.ParseMessagePassed(*log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MessagePassed log %d: %w", log.Index, err)
+		}
+		withdrawals = append(withdrawals, NewWithdrawal(ev.Nonce, &ev.Sender, &ev.Target, ev.Value, ev.GasLimit, ev.Data))
+	}
+	return withdrawals, nil
+}
+
+// findWithdrawal returns the withdrawal the L2CrossDomainMessenger initiated to relay the message on L1.
+func findWithdrawal(msg *CrossDomainMessage, withdrawals []*Withdrawal) (*Withdrawal, error) {
+	encoded, err := msg.Encode()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range withdrawals {
+		if *w.Sender == predeploys.L2CrossDomainMessengerAddr && bytes.Equal(w.Data, encoded) {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("no withdrawal found for message with nonce %d", msg.Nonce)
+}
@@ -0,0 +1,236 @@
+package crossdomain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+)
+
+var trackerCfg = TrackerConfig{
+	L1CrossDomainMessenger: common.HexToAddress("0x1001"),
+	KromaPortal:            common.HexToAddress("0x1002"),
+	L2OutputOracle:         common.HexToAddress("0x1003"),
+}
+
+type method func(args []any) []any
+
+// fakeChain answers calls from the methods set by contract address and method name,
+// and returns the receipts it knows about.
+type fakeChain struct {
+	bind.ContractBackend
+	abis     map[common.Address]*abi.ABI
+	methods  map[common.Address]map[string]method
+	receipts map[common.Hash]*types.Receipt
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		abis:     make(map[common.Address]*abi.ABI),
+		methods:  make(map[common.Address]map[string]method),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+func (f *fakeChain) set(addr common.Address, md *bind.MetaData, name string, fn method) {
+	parsed, err := md.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	f.abis[addr] = parsed
+	if f.methods[addr] == nil {
+		f.methods[addr] = make(map[string]method)
+	}
+	f.methods[addr][name] = fn
+}
+
+func (f *fakeChain) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeChain) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	contract, ok := f.abis[*call.To]
+	if !ok {
+		return nil, fmt.Errorf("no contract at %s", call.To)
+	}
+	m, err := contract.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := f.methods[*call.To][m.Name]
+	if !ok {
+		return nil, fmt.Errorf("unexpected call of %s at %s", m.Name, call.To)
+	}
+	args, err := m.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return m.Outputs.Pack(fn(args)...)
+}
+
+func (f *fakeChain) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, ok := f.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func returns(values ...any) method {
+	return func([]any) []any { return values }
+}
+
+// eventLog packs the event the same way the EVM emits it.
+func eventLog(t *testing.T, addr common.Address, md *bind.MetaData, name string, indexed []common.Hash, values ...any) *types.Log {
+	parsed, err := md.GetAbi()
+	require.NoError(t, err)
+	ev := parsed.Events[name]
+	data, err := ev.Inputs.NonIndexed().Pack(values...)
+	require.NoError(t, err)
+	return &types.Log{Address: addr, Topics: append([]common.Hash{ev.ID}, indexed...), Data: data}
+}
+
+func testMessage(sender, target common.Address) *CrossDomainMessage {
+	return NewCrossDomainMessage(
+		EncodeVersionedNonce(big.NewInt(7), common.Big1),
+		sender,
+		target,
+		big.NewInt(100),
+		big.NewInt(200_000),
+		[]byte{0xde, 0xad},
+	)
+}
+
+func sentMessageLog(t *testing.T, messenger common.Address, msg *CrossDomainMessage) *types.Log {
+	return eventLog(t, messenger, bindings.L1CrossDomainMessengerMetaData, "SentMessage",
+		[]common.Hash{common.BytesToHash(msg.Target.Bytes()), common.BytesToHash(msg.Sender.Bytes())},
+		msg.Value, msg.Data, msg.Nonce, msg.GasLimit)
+}
+
+func TestTrackL1ToL2(t *testing.T) {
+	l1, l2 := newFakeChain(), newFakeChain()
+	msg := testMessage(common.HexToAddress("0xaa"), common.HexToAddress("0xbb"))
+	hash, err := msg.Hash()
+	require.NoError(t, err)
+
+	txHash := common.HexToHash("0x01")
+	l1.receipts[txHash] = &types.Receipt{
+		TxHash:      txHash,
+		BlockNumber: big.NewInt(10),
+		Logs: []*types.Log{
+			// the same event from another contract is ignored
+			sentMessageLog(t, common.HexToAddress("0xdead"), msg),
+			sentMessageLog(t, trackerCfg.L1CrossDomainMessenger, msg),
+		},
+	}
+	failed := false
+	l2.set(predeploys.L2CrossDomainMessengerAddr, bindings.L2CrossDomainMessengerMetaData, "successfulMessages", returns(false))
+	l2.set(predeploys.L2CrossDomainMessengerAddr, bindings.L2CrossDomainMessengerMetaData, "failedMessages", func(args []any) []any {
+		require.Equal(t, [32]byte(hash), args[0])
+		return []any{failed}
+	})
+
+	tracker, err := NewTracker(trackerCfg, l1, l2)
+	require.NoError(t, err)
+
+	msgs, err := tracker.Track(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	require.Equal(t, L1ToL2, msgs[0].Direction)
+	require.Equal(t, msg, msgs[0].Message)
+	require.Equal(t, hash, msgs[0].Hash)
+	require.Equal(t, uint64(10), msgs[0].BlockNumber)
+	require.Equal(t, StatusPending, msgs[0].Status)
+
+	_, err = tracker.Replay(&bind.TransactOpts{}, msgs[0])
+	require.ErrorContains(t, err, "only failed messages can be replayed")
+
+	failed = true
+	msgs, err = tracker.Track(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Equal(t, StatusFailed, msgs[0].Status)
+}
+
+func TestTrackL2ToL1(t *testing.T) {
+	l1, l2 := newFakeChain(), newFakeChain()
+	msg := testMessage(common.HexToAddress("0xaa"), common.HexToAddress("0xbb"))
+	encoded, err := msg.Encode()
+	require.NoError(t, err)
+	withdrawal := NewWithdrawal(big.NewInt(3), &predeploys.L2CrossDomainMessengerAddr, &trackerCfg.L1CrossDomainMessenger,
+		msg.Value, big.NewInt(300_000), encoded)
+	withdrawalHash, err := withdrawal.Hash()
+	require.NoError(t, err)
+
+	txHash := common.HexToHash("0x02")
+	l2.receipts[txHash] = &types.Receipt{
+		TxHash:      txHash,
+		BlockNumber: big.NewInt(50),
+		Logs: []*types.Log{
+			eventLog(t, predeploys.L2ToL1MessagePasserAddr, bindings.L2ToL1MessagePasserMetaData, "MessagePassed",
+				[]common.Hash{
+					common.BigToHash(withdrawal.Nonce),
+					common.BytesToHash(withdrawal.Sender.Bytes()),
+					common.BytesToHash(withdrawal.Target.Bytes()),
+				},
+				withdrawal.Value, withdrawal.GasLimit, []byte(withdrawal.Data), withdrawalHash),
+			sentMessageLog(t, predeploys.L2CrossDomainMessengerAddr, msg),
+		},
+	}
+
+	l1.set(trackerCfg.L1CrossDomainMessenger, bindings.L1CrossDomainMessengerMetaData, "successfulMessages", returns(false))
+	l1.set(trackerCfg.L1CrossDomainMessenger, bindings.L1CrossDomainMessengerMetaData, "failedMessages", returns(false))
+	latest := uint64(40)
+	l1.set(trackerCfg.L2OutputOracle, bindings.L2OutputOracleMetaData, "latestBlockNumber", func([]any) []any {
+		return []any{new(big.Int).SetUint64(latest)}
+	})
+	var provenAt int64
+	l1.set(trackerCfg.KromaPortal, bindings.KromaPortalMetaData, "provenWithdrawals", func(args []any) []any {
+		require.Equal(t, [32]byte(withdrawalHash), args[0])
+		return []any{[32]byte{}, big.NewInt(provenAt), big.NewInt(2)}
+	})
+	finalized := false
+	l1.set(trackerCfg.KromaPortal, bindings.KromaPortalMetaData, "isOutputFinalized", func(args []any) []any {
+		require.Equal(t, big.NewInt(2), args[0])
+		return []any{finalized}
+	})
+
+	tracker, err := NewTracker(trackerCfg, l1, l2)
+	require.NoError(t, err)
+
+	track := func() *TrackedMessage {
+		msgs, err := tracker.Track(context.Background(), txHash)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		return msgs[0]
+	}
+
+	m := track()
+	require.Equal(t, L2ToL1, m.Direction)
+	require.Equal(t, withdrawal, m.Withdrawal)
+	require.Equal(t, withdrawalHash, *m.WithdrawalHash)
+	require.Equal(t, StatusWaitingForOutput, m.Status)
+
+	latest = 60
+	require.Equal(t, StatusReadyToProve, track().Status)
+
+	provenAt = 1000
+	m = track()
+	require.Equal(t, StatusProven, m.Status)
+	require.Equal(t, big.NewInt(2), m.L2OutputIndex)
+
+	finalized = true
+	require.Equal(t, StatusReadyToFinalize, track().Status)
+
+	l1.set(trackerCfg.L1CrossDomainMessenger, bindings.L1CrossDomainMessengerMetaData, "successfulMessages", returns(true))
+	require.Equal(t, StatusRelayed, track().Status)
+}
@@ -110,7 +110,9 @@ func newHexBig(in uint64) *hexutil.Big {
 }
 
 // [Kroma: START]
-func parseSegsLengthsConfig(in string) []*big.Int {
+
+// ParseSegsLengthsConfig parses the comma separated Colosseum segments lengths of the deploy config.
+func ParseSegsLengthsConfig(in string) []*big.Int {
 	sliced := strings.Split(in, ",")
 	arr := make([]*big.Int, len(sliced))
 	for i := range arr {
@@ -0,0 +1,153 @@
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+// contractABIs are the ABIs of the L1 contracts governance can call, by contract name in the L1Deployments.
+var contractABIs = map[string]*bind.MetaData{
+	"ProxyAdmin":                bindings.ProxyAdminMetaData,
+	"SystemConfig":              bindings.SystemConfigMetaData,
+	"UpgradeGovernor":           bindings.UpgradeGovernorMetaData,
+	"TimeLock":                  bindings.TimeLockMetaData,
+	"SecurityCouncil":           bindings.SecurityCouncilMetaData,
+	"SecurityCouncilToken":      bindings.SecurityCouncilTokenMetaData,
+	"L1GovernanceToken":         bindings.GovernanceTokenMetaData,
+	"L2OutputOracle":            bindings.L2OutputOracleMetaData,
+	"KromaPortal":               bindings.KromaPortalMetaData,
+	"Colosseum":                 bindings.ColosseumMetaData,
+	"ValidatorPool":             bindings.ValidatorPoolMetaData,
+	"L1CrossDomainMessenger":    bindings.L1CrossDomainMessengerMetaData,
+	"L1StandardBridge":          bindings.L1StandardBridgeMetaData,
+	"L1ERC721Bridge":            bindings.L1ERC721BridgeMetaData,
+	"KromaMintableERC20Factory": bindings.KromaMintableERC20FactoryMetaData,
+	"ZKVerifier":                bindings.ZKVerifierMetaData,
+}
+
+// Decoder decodes the calls of proposals into readable method calls,
+// and names the addresses of the L1 deployments.
+type Decoder struct {
+	deployments *genesis.L1Deployments
+	abis        map[string]*abi.ABI
+	// names are the contract names of abis, sorted to decode deterministically
+	names []string
+}
+
+func NewDecoder(deployments *genesis.L1Deployments) (*Decoder, error) {
+	d := &Decoder{deployments: deployments, abis: make(map[string]*abi.ABI, len(contractABIs))}
+	for name, md := range contractABIs {
+		parsed, err := md.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s ABI: %w", name, err)
+		}
+		d.abis[name] = parsed
+		d.names = append(d.names, name)
+	}
+	sort.Strings(d.names)
+	return d, nil
+}
+
+// Name returns the address, followed by its contract name if it is one of the L1 deployments.
+func (d *Decoder) Name(addr common.Address) string {
+	if name := d.contractName(addr); name != "" {
+		return fmt.Sprintf("%s (%s)", addr, name)
+	}
+	return addr.String()
+}
+
+func (d *Decoder) contractName(addr common.Address) string {
+	if d.deployments == nil || addr == (common.Address{}) {
+		return ""
+	}
+	return d.deployments.GetName(addr)
+}
+
+// method finds the method of the calldata, preferring the ABI of the target contract.
+func (d *Decoder) method(target common.Address, data []byte) (string, *abi.Method) {
+	if len(data) < 4 {
+		return "", nil
+	}
+	name := strings.TrimSuffix(d.contractName(target), "Proxy")
+	if contractABI, ok := d.abis[name]; ok {
+		if m, err := contractABI.MethodById(data[:4]); err == nil {
+			return name, m
+		}
+	}
+	for _, name := range d.names {
+		if m, err := d.abis[name].MethodById(data[:4]); err == nil {
+			return name, m
+		}
+	}
+	return "", nil
+}
+
+// DecodeCall decodes a call, e.g. ProxyAdmin.upgrade(_proxy: 0x.. (KromaPortalProxy), _implementation: 0x..).
+// The calls made by ProxyAdmin.upgradeAndCall and SecurityCouncil transactions are decoded too.
+func (d *Decoder) DecodeCall(target common.Address, value *big.Int, data []byte) string {
+	var sb strings.Builder
+	d.writeCall(&sb, target, value, data, 0)
+	return sb.String()
+}
+
+func (d *Decoder) writeCall(sb *strings.Builder, target common.Address, value *big.Int, data []byte, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(sb, "%scall %s", indent, d.Name(target))
+	if value != nil && value.Sign() != 0 {
+		fmt.Fprintf(sb, " with value %s", value)
+	}
+	sb.WriteString("\n")
+	contract, m := d.method(target, data)
+	if m == nil {
+		if len(data) > 0 {
+			fmt.Fprintf(sb, "%s  unknown calldata %s\n", indent, hexutil.Bytes(data))
+		}
+		return
+	}
+	args, err := m.Inputs.Unpack(data[4:])
+	if err != nil {
+		fmt.Fprintf(sb, "%s  %s.%s: failed to decode arguments: %v\n", indent, contract, m.Name, err)
+		return
+	}
+	fmt.Fprintf(sb, "%s  %s.%s\n", indent, contract, m.Name)
+	for i, arg := range args {
+		fmt.Fprintf(sb, "%s    %s: %s\n", indent, m.Inputs[i].Name, d.formatArg(arg))
+	}
+
+	// decode the call that is made by the called method
+	switch {
+	case contract == "ProxyAdmin" && m.Name == "upgradeAndCall":
+		d.writeCall(sb, args[0].(common.Address), nil, args[2].([]byte), depth+1)
+	case contract == "SecurityCouncil" && m.Name == "submitTransaction":
+		d.writeCall(sb, args[0].(common.Address), args[1].(*big.Int), args[2].([]byte), depth+1)
+	}
+}
+
+func (d *Decoder) formatArg(arg any) string {
+	switch v := arg.(type) {
+	case common.Address:
+		return d.Name(v)
+	case []common.Address:
+		names := make([]string, len(v))
+		for i, addr := range v {
+			names[i] = d.Name(addr)
+		}
+		return "[" + strings.Join(names, ", ") + "]"
+	case []byte:
+		return hexutil.Bytes(v).String()
+	case [32]byte:
+		return common.Hash(v).String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
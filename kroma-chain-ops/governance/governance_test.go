@@ -0,0 +1,130 @@
+package governance
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+func testDeployments() *genesis.L1Deployments {
+	return &genesis.L1Deployments{
+		ProxyAdmin:           common.HexToAddress("0x1000"),
+		SystemConfigProxy:    common.HexToAddress("0x2000"),
+		KromaPortalProxy:     common.HexToAddress("0x3000"),
+		UpgradeGovernorProxy: common.HexToAddress("0x4000"),
+		TimeLockProxy:        common.HexToAddress("0x5000"),
+		SecurityCouncilProxy: common.HexToAddress("0x6000"),
+	}
+}
+
+func TestProposalID(t *testing.T) {
+	deployments := testDeployments()
+	call, err := SystemConfigCall(deployments, "setGasLimit", uint64(30_000_000))
+	require.NoError(t, err)
+
+	p := &Proposal{Description: "raise the gas limit", Calls: []Call{call}}
+	id, err := p.ID()
+	require.NoError(t, err)
+	again, err := (&Proposal{Description: p.Description, Calls: []Call{call}}).ID()
+	require.NoError(t, err)
+	require.Equal(t, id, again)
+
+	other, err := (&Proposal{Description: "another description", Calls: []Call{call}}).ID()
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+
+	// a nil value is encoded as zero
+	call.Value = nil
+	nilValue, err := (&Proposal{Description: p.Description, Calls: []Call{call}}).ID()
+	require.NoError(t, err)
+	require.Equal(t, id, nilValue)
+}
+
+func TestTimelockSalt(t *testing.T) {
+	p := &Proposal{Description: "upgrade"}
+	require.Equal(t, crypto.Keccak256Hash([]byte("upgrade")), p.TimelockSalt())
+}
+
+func TestUpgradeCall(t *testing.T) {
+	deployments := testDeployments()
+	impl := common.HexToAddress("0xabcd")
+
+	_, err := UpgradeCall(deployments, "Unknown", impl, nil)
+	require.ErrorContains(t, err, "unknown proxied contract")
+	_, err = UpgradeCall(deployments, "L2OutputOracle", impl, nil)
+	require.ErrorContains(t, err, "not deployed")
+
+	decoder, err := NewDecoder(deployments)
+	require.NoError(t, err)
+
+	call, err := UpgradeCall(deployments, "KromaPortal", impl, nil)
+	require.NoError(t, err)
+	require.Equal(t, deployments.ProxyAdmin, call.Target)
+	decoded := decoder.DecodeCall(call.Target, call.Value, call.Data)
+	require.Contains(t, decoded, "ProxyAdmin.upgrade\n")
+	require.Contains(t, decoded, deployments.KromaPortalProxy.String()+" (KromaPortalProxy)")
+	require.Contains(t, decoded, impl.String())
+
+	portal, err := bindings.KromaPortalMetaData.GetAbi()
+	require.NoError(t, err)
+	initData, err := portal.Pack("paused")
+	require.NoError(t, err)
+	call, err = UpgradeCall(deployments, "KromaPortal", impl, initData)
+	require.NoError(t, err)
+	decoded = decoder.DecodeCall(call.Target, call.Value, call.Data)
+	require.Contains(t, decoded, "ProxyAdmin.upgradeAndCall\n")
+	// the call made by upgradeAndCall is decoded with the ABI of the proxied contract
+	require.Contains(t, decoded, "\n    KromaPortal.paused\n")
+}
+
+func TestSystemConfigCall(t *testing.T) {
+	deployments := testDeployments()
+	decoder, err := NewDecoder(deployments)
+	require.NoError(t, err)
+
+	call, err := SystemConfigCall(deployments, "setGasConfig", big.NewInt(188), big.NewInt(684000))
+	require.NoError(t, err)
+	require.Equal(t, deployments.SystemConfigProxy, call.Target)
+	decoded := decoder.DecodeCall(call.Target, call.Value, call.Data)
+	require.True(t, strings.HasPrefix(decoded, "call "+deployments.SystemConfigProxy.String()+" (SystemConfigProxy)\n"))
+	require.Contains(t, decoded, "SystemConfig.setGasConfig\n")
+	require.Contains(t, decoded, ": 684000\n")
+
+	_, err = SystemConfigCall(deployments, "setGasLimit", "not a number")
+	require.Error(t, err)
+}
+
+func TestSecurityCouncilCalldata(t *testing.T) {
+	deployments := testDeployments()
+	decoder, err := NewDecoder(deployments)
+	require.NoError(t, err)
+
+	call, err := SystemConfigCall(deployments, "setGasLimit", uint64(30_000_000))
+	require.NoError(t, err)
+	p := &Proposal{Description: "raise the gas limit", Calls: []Call{call}}
+	gov, err := NewGovernor(deployments.UpgradeGovernorProxy, deployments.TimeLockProxy, nil)
+	require.NoError(t, err)
+	propose, err := gov.ProposeCalldata(p)
+	require.NoError(t, err)
+	data, err := SecurityCouncilCalldata(gov.Address(), propose)
+	require.NoError(t, err)
+
+	decoded := decoder.DecodeCall(deployments.SecurityCouncilProxy, nil, data)
+	require.Contains(t, decoded, "SecurityCouncil.submitTransaction\n")
+	require.Contains(t, decoded, "\n  call "+deployments.UpgradeGovernorProxy.String()+" (UpgradeGovernorProxy)\n")
+	require.Contains(t, decoded, "UpgradeGovernor.propose\n")
+}
+
+func TestProposalStateString(t *testing.T) {
+	require.Equal(t, "pending", StatePending.String())
+	require.Equal(t, "succeeded", StateSucceeded.String())
+	require.Equal(t, "executed", StateExecuted.String())
+	require.Equal(t, "unknown(99)", ProposalState(99).String())
+}
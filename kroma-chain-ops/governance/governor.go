@@ -0,0 +1,177 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+// ProposalState is the state of a proposal, as the Governor.ProposalState enum.
+type ProposalState uint8
+
+const (
+	StatePending ProposalState = iota
+	StateActive
+	StateCanceled
+	StateDefeated
+	StateSucceeded
+	StateQueued
+	StateExpired
+	StateExecuted
+)
+
+func (s ProposalState) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateActive:
+		return "active"
+	case StateCanceled:
+		return "canceled"
+	case StateDefeated:
+		return "defeated"
+	case StateSucceeded:
+		return "succeeded"
+	case StateQueued:
+		return "queued"
+	case StateExpired:
+		return "expired"
+	case StateExecuted:
+		return "executed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// Status is the on-chain status of a proposal.
+type Status struct {
+	ID *big.Int `json:"id"`
+	// Proposed is false if the proposal was not proposed yet, the other fields are not set then.
+	Proposed bool          `json:"proposed"`
+	State    ProposalState `json:"state"`
+	// Snapshot and Deadline are the start and end of the voting, in the clock of the governor.
+	Snapshot     *big.Int `json:"snapshot"`
+	Deadline     *big.Int `json:"deadline"`
+	ForVotes     *big.Int `json:"forVotes"`
+	AgainstVotes *big.Int `json:"againstVotes"`
+	AbstainVotes *big.Int `json:"abstainVotes"`
+	Quorum       *big.Int `json:"quorum"`
+	// Eta is the timestamp the queued proposal can be executed at, zero if it is not queued.
+	Eta *big.Int `json:"eta"`
+	// TimelockOperation is the ID of the TimeLock operation of the proposal.
+	TimelockOperation common.Hash `json:"timelockOperation"`
+	// Ready is true if the TimeLock delay of the queued proposal passed.
+	Ready bool `json:"ready"`
+}
+
+// Governor reads the state of proposals from the UpgradeGovernor and its TimeLock,
+// and encodes the calls to propose, queue and execute them.
+type Governor struct {
+	addr     common.Address
+	governor *bindings.UpgradeGovernorCaller
+	timelock *bindings.TimeLockCaller
+	abi      *abi.ABI
+}
+
+func NewGovernor(governorAddr common.Address, timelockAddr common.Address, caller bind.ContractCaller) (*Governor, error) {
+	governor, err := bindings.NewUpgradeGovernorCaller(governorAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+	timelock, err := bindings.NewTimeLockCaller(timelockAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+	governorABI, err := bindings.UpgradeGovernorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Governor{addr: governorAddr, governor: governor, timelock: timelock, abi: governorABI}, nil
+}
+
+func (g *Governor) Address() common.Address {
+	return g.addr
+}
+
+// Status returns the on-chain status of the proposal.
+func (g *Governor) Status(ctx context.Context, p *Proposal) (*Status, error) {
+	id, err := p.ID()
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	status := &Status{ID: id}
+	status.Snapshot, err = g.governor.ProposalSnapshot(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal snapshot: %w", err)
+	}
+	// the state reverts for unknown proposals
+	if status.Snapshot.Sign() == 0 {
+		return status, nil
+	}
+	status.Proposed = true
+	state, err := g.governor.State(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal state: %w", err)
+	}
+	status.State = ProposalState(state)
+	if status.Deadline, err = g.governor.ProposalDeadline(opts, id); err != nil {
+		return nil, fmt.Errorf("failed to get proposal deadline: %w", err)
+	}
+	votes, err := g.governor.ProposalVotes(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal votes: %w", err)
+	}
+	status.ForVotes, status.AgainstVotes, status.AbstainVotes = votes.ForVotes, votes.AgainstVotes, votes.AbstainVotes
+	if status.State != StatePending {
+		if status.Quorum, err = g.governor.Quorum(opts, status.Snapshot); err != nil {
+			return nil, fmt.Errorf("failed to get quorum: %w", err)
+		}
+	}
+	if status.Eta, err = g.governor.ProposalEta(opts, id); err != nil {
+		return nil, fmt.Errorf("failed to get proposal eta: %w", err)
+	}
+	targets, values, calldatas := p.args()
+	status.TimelockOperation, err = g.timelock.HashOperationBatch(opts, targets, values, calldatas, [32]byte{}, p.TimelockSalt())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timelock operation: %w", err)
+	}
+	if status.Ready, err = g.timelock.IsOperationReady(opts, status.TimelockOperation); err != nil {
+		return nil, fmt.Errorf("failed to get timelock operation state: %w", err)
+	}
+	return status, nil
+}
+
+// ProposeCalldata encodes the UpgradeGovernor.propose call of the proposal.
+func (g *Governor) ProposeCalldata(p *Proposal) ([]byte, error) {
+	targets, values, calldatas := p.args()
+	return g.abi.Pack("propose", targets, values, calldatas, p.Description)
+}
+
+// QueueCalldata encodes the UpgradeGovernor.queue call of the proposal.
+func (g *Governor) QueueCalldata(p *Proposal) ([]byte, error) {
+	targets, values, calldatas := p.args()
+	return g.abi.Pack("queue", targets, values, calldatas, p.DescriptionHash())
+}
+
+// ExecuteCalldata encodes the UpgradeGovernor.execute call of the proposal.
+func (g *Governor) ExecuteCalldata(p *Proposal) ([]byte, error) {
+	targets, values, calldatas := p.args()
+	return g.abi.Pack("execute", targets, values, calldatas, p.DescriptionHash())
+}
+
+// SecurityCouncilCalldata encodes the SecurityCouncil.submitTransaction call, to make a call through the
+// SecurityCouncil multisig. The transaction is made once enough council members confirmed it.
+func SecurityCouncilCalldata(target common.Address, data []byte) ([]byte, error) {
+	council, err := bindings.SecurityCouncilMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return council.Pack("submitTransaction", target, new(big.Int), data)
+}
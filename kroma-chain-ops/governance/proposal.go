@@ -0,0 +1,127 @@
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+// Call is a call the TimeLock executes when the proposal is executed.
+type Call struct {
+	Target common.Address `json:"target"`
+	Value  *big.Int       `json:"value"`
+	Data   hexutil.Bytes  `json:"data"`
+}
+
+// Proposal is an UpgradeGovernor proposal. The proposal is identified by its calls and description,
+// so the same proposal file is used to propose, queue and execute it.
+type Proposal struct {
+	Description string `json:"description"`
+	Calls       []Call `json:"calls"`
+}
+
+func (p *Proposal) args() (targets []common.Address, values []*big.Int, calldatas [][]byte) {
+	for _, c := range p.Calls {
+		targets = append(targets, c.Target)
+		value := c.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		values = append(values, value)
+		calldatas = append(calldatas, c.Data)
+	}
+	return
+}
+
+// DescriptionHash is the hash of the description, that identifies the proposal when it is queued and executed.
+func (p *Proposal) DescriptionHash() common.Hash {
+	return crypto.Keccak256Hash([]byte(p.Description))
+}
+
+var (
+	addressesType, _ = abi.NewType("address[]", "", nil)
+	uintsType, _     = abi.NewType("uint256[]", "", nil)
+	bytesListType, _ = abi.NewType("bytes[]", "", nil)
+	bytes32Type, _   = abi.NewType("bytes32", "", nil)
+
+	proposalIDArgs = abi.Arguments{{Type: addressesType}, {Type: uintsType}, {Type: bytesListType}, {Type: bytes32Type}}
+)
+
+// ID is the proposal ID, as computed by Governor.hashProposal.
+func (p *Proposal) ID() (*big.Int, error) {
+	targets, values, calldatas := p.args()
+	enc, err := proposalIDArgs.Pack(targets, values, calldatas, p.DescriptionHash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode proposal: %w", err)
+	}
+	return new(big.Int).SetBytes(crypto.Keccak256(enc)), nil
+}
+
+// TimelockSalt is the salt of the TimeLock operation of the proposal, as computed by GovernorTimelockControl
+// of OpenZeppelin 4.9, which the governor is deployed with: the description hash.
+func (p *Proposal) TimelockSalt() common.Hash {
+	return p.DescriptionHash()
+}
+
+// proxyOf returns the proxy of the named contract in the deployments, e.g. KromaPortalProxy for KromaPortal.
+func proxyOf(deployments *genesis.L1Deployments, name string) (common.Address, error) {
+	field := reflect.ValueOf(deployments).Elem().FieldByName(name + "Proxy")
+	if !field.IsValid() {
+		return common.Address{}, fmt.Errorf("unknown proxied contract %q", name)
+	}
+	proxy := field.Interface().(common.Address)
+	if proxy == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%sProxy is not deployed", name)
+	}
+	return proxy, nil
+}
+
+// UpgradeCall builds the ProxyAdmin call that upgrades the proxy of the named contract to the implementation.
+// If data is not empty, the proxy calls the new implementation with it after the upgrade.
+func UpgradeCall(deployments *genesis.L1Deployments, name string, implementation common.Address, data []byte) (Call, error) {
+	if deployments.ProxyAdmin == (common.Address{}) {
+		return Call{}, fmt.Errorf("ProxyAdmin is not deployed")
+	}
+	proxy, err := proxyOf(deployments, name)
+	if err != nil {
+		return Call{}, err
+	}
+	proxyAdmin, err := bindings.ProxyAdminMetaData.GetAbi()
+	if err != nil {
+		return Call{}, err
+	}
+	var calldata []byte
+	if len(data) == 0 {
+		calldata, err = proxyAdmin.Pack("upgrade", proxy, implementation)
+	} else {
+		calldata, err = proxyAdmin.Pack("upgradeAndCall", proxy, implementation, data)
+	}
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to encode upgrade of %s: %w", name, err)
+	}
+	return Call{Target: deployments.ProxyAdmin, Value: new(big.Int), Data: calldata}, nil
+}
+
+// SystemConfigCall builds a call of the SystemConfig setter method with the given arguments.
+func SystemConfigCall(deployments *genesis.L1Deployments, method string, args ...any) (Call, error) {
+	if deployments.SystemConfigProxy == (common.Address{}) {
+		return Call{}, fmt.Errorf("SystemConfigProxy is not deployed")
+	}
+	systemConfig, err := bindings.SystemConfigMetaData.GetAbi()
+	if err != nil {
+		return Call{}, err
+	}
+	calldata, err := systemConfig.Pack(method, args...)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to encode SystemConfig.%s: %w", method, err)
+	}
+	return Call{Target: deployments.SystemConfigProxy, Value: new(big.Int), Data: calldata}, nil
+}
@@ -0,0 +1,321 @@
+package rewards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+)
+
+// maxTopicsPerFilter bounds the number of L2 block numbers that are filtered in a single log query.
+const maxTopicsPerFilter = 100
+
+// Config locates the contracts the rewards are read from.
+type Config struct {
+	L2OutputOracle common.Address
+	SystemConfig   common.Address
+	// L1StartBlock is the L1 block from which the scalar updates of the SystemConfig are scanned.
+	// The scalar at this block is the one in effect before the first update.
+	L1StartBlock uint64
+}
+
+// Fetcher reads the outputs from L1 and the rewards and mints from L2.
+type Fetcher struct {
+	cfg Config
+	l1  bind.ContractBackend
+	l2  bind.ContractBackend
+
+	l2oo         *bindings.L2OutputOracle
+	systemConfig *bindings.SystemConfig
+	rewardVault  *bindings.ValidatorRewardVault
+	mintManager  *bindings.MintManager
+}
+
+func NewFetcher(cfg Config, l1, l2 bind.ContractBackend) (*Fetcher, error) {
+	l2oo, err := bindings.NewL2OutputOracle(cfg.L2OutputOracle, l1)
+	if err != nil {
+		return nil, err
+	}
+	systemConfig, err := bindings.NewSystemConfig(cfg.SystemConfig, l1)
+	if err != nil {
+		return nil, err
+	}
+	rewardVault, err := bindings.NewValidatorRewardVault(predeploys.ValidatorRewardVaultAddr, l2)
+	if err != nil {
+		return nil, err
+	}
+	mintManager, err := bindings.NewMintManager(predeploys.MintManagerAddr, l2)
+	if err != nil {
+		return nil, err
+	}
+	return &Fetcher{
+		cfg:          cfg,
+		l1:           l1,
+		l2:           l2,
+		l2oo:         l2oo,
+		systemConfig: systemConfig,
+		rewardVault:  rewardVault,
+		mintManager:  mintManager,
+	}, nil
+}
+
+// Report returns the reward accounting of the outputs of the L2 blocks from fromL2Block to toL2Block included.
+func (f *Fetcher) Report(ctx context.Context, fromL2Block, toL2Block uint64) (*Report, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	timing, err := f.timing(opts)
+	if err != nil {
+		return nil, err
+	}
+	outputs, prevL2Block, err := f.outputs(opts, fromL2Block, toL2Block)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) == 0 {
+		return Attribute(outputs, nil, nil, timing, prevL2Block), nil
+	}
+	scalars, err := f.scalarChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := f.rewards(ctx, outputs)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.setMinted(ctx, outputs, prevL2Block); err != nil {
+		return nil, err
+	}
+	return Attribute(outputs, rewards, scalars, timing, prevL2Block), nil
+}
+
+func (f *Fetcher) timing(opts *bind.CallOpts) (L2Timing, error) {
+	startingBlockNumber, err := f.l2oo.StartingBlockNumber(opts)
+	if err != nil {
+		return L2Timing{}, fmt.Errorf("failed to get starting block number: %w", err)
+	}
+	startingTimestamp, err := f.l2oo.StartingTimestamp(opts)
+	if err != nil {
+		return L2Timing{}, fmt.Errorf("failed to get starting timestamp: %w", err)
+	}
+	blockTime, err := f.l2oo.L2BLOCKTIME(opts)
+	if err != nil {
+		return L2Timing{}, fmt.Errorf("failed to get L2 block time: %w", err)
+	}
+	return L2Timing{
+		StartingBlockNumber: startingBlockNumber.Uint64(),
+		StartingTimestamp:   startingTimestamp.Uint64(),
+		BlockTime:           blockTime.Uint64(),
+	}, nil
+}
+
+// outputs returns the submitted outputs in the range, and the L2 block of the output before the first one.
+func (f *Fetcher) outputs(opts *bind.CallOpts, fromL2Block, toL2Block uint64) ([]*Output, uint64, error) {
+	latestBlock, err := f.l2oo.LatestBlockNumber(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get latest output block number: %w", err)
+	}
+	latestIndex, err := f.l2oo.LatestOutputIndex(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get latest output index: %w", err)
+	}
+	if latestBlock.Uint64() < fromL2Block {
+		return nil, 0, nil
+	}
+	first, err := f.l2oo.GetL2OutputIndexAfter(opts, new(big.Int).SetUint64(fromL2Block))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get output index after L2 block %d: %w", fromL2Block, err)
+	}
+
+	var prevL2Block uint64
+	if first.Sign() > 0 {
+		prev, err := f.l2oo.GetL2Output(opts, new(big.Int).Sub(first, common.Big1))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get output %d: %w", first.Uint64()-1, err)
+		}
+		prevL2Block = prev.L2BlockNumber.Uint64()
+	} else {
+		startingBlockNumber, err := f.l2oo.StartingBlockNumber(opts)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get starting block number: %w", err)
+		}
+		prevL2Block = startingBlockNumber.Uint64()
+	}
+
+	outputs := make([]*Output, 0)
+	for i := first.Uint64(); i <= latestIndex.Uint64(); i++ {
+		output, err := f.l2oo.GetL2Output(opts, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get output %d: %w", i, err)
+		}
+		if output.L2BlockNumber.Uint64() > toL2Block {
+			break
+		}
+		outputs = append(outputs, &Output{
+			Index:         i,
+			OutputRoot:    output.OutputRoot,
+			Submitter:     output.Submitter,
+			L2BlockNumber: output.L2BlockNumber.Uint64(),
+			L1Timestamp:   output.Timestamp.Uint64(),
+		})
+	}
+	return outputs, prevL2Block, nil
+}
+
+// scalarChanges returns the scalar at the L1 start block followed by the updates after it.
+func (f *Fetcher) scalarChanges(ctx context.Context) ([]ScalarChange, error) {
+	start := new(big.Int).SetUint64(f.cfg.L1StartBlock)
+	initial, err := f.systemConfig.ValidatorRewardScalar(&bind.CallOpts{Context: ctx, BlockNumber: start})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator reward scalar at L1 block %d: %w", f.cfg.L1StartBlock, err)
+	}
+	header, err := f.l1.HeaderByNumber(ctx, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 block %d: %w", f.cfg.L1StartBlock, err)
+	}
+	changes := []ScalarChange{{L1BlockNumber: f.cfg.L1StartBlock, L1Timestamp: header.Time, Scalar: initial.Uint64()}}
+
+	updateType := uint8(new(big.Int).SetBytes(derive.SystemConfigUpdateValidatorRewardScalar[:]).Uint64())
+	it, err := f.systemConfig.FilterConfigUpdate(&bind.FilterOpts{Context: ctx, Start: f.cfg.L1StartBlock + 1}, nil, []uint8{updateType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter config updates: %w", err)
+	}
+	defer it.Close()
+
+	rollupCfg := &rollup.Config{L1SystemConfigAddress: f.cfg.SystemConfig}
+	for it.Next() {
+		ev := it.Event.Raw
+		header, err := f.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(ev.BlockNumber))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get L1 block %d: %w", ev.BlockNumber, err)
+		}
+		var sysCfg eth.SystemConfig
+		if err := derive.ProcessSystemConfigUpdateLogEvent(&sysCfg, &ev, rollupCfg, header.Time); err != nil {
+			return nil, fmt.Errorf("failed to decode config update in L1 tx %s: %w", ev.TxHash, err)
+		}
+		changes = append(changes, ScalarChange{
+			L1BlockNumber: ev.BlockNumber,
+			L1Timestamp:   header.Time,
+			Scalar:        new(big.Int).SetBytes(sysCfg.ValidatorRewardScalar[:]).Uint64(),
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate config updates: %w", err)
+	}
+	return changes, nil
+}
+
+// rewards returns the Rewarded events of the outputs. Rewards are paid once the outputs are finalized,
+// so the L2 blocks are scanned from the block of the first output on.
+func (f *Fetcher) rewards(ctx context.Context, outputs []*Output) ([]*Reward, error) {
+	rewards := make([]*Reward, 0, len(outputs))
+	for start := 0; start < len(outputs); start += maxTopicsPerFilter {
+		end := min(start+maxTopicsPerFilter, len(outputs))
+		blockNumbers := make([]*big.Int, 0, end-start)
+		for _, o := range outputs[start:end] {
+			blockNumbers = append(blockNumbers, new(big.Int).SetUint64(o.L2BlockNumber))
+		}
+
+		opts := &bind.FilterOpts{Context: ctx, Start: outputs[start].L2BlockNumber}
+		it, err := f.rewardVault.FilterRewarded(opts, nil, blockNumbers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter rewards: %w", err)
+		}
+		for it.Next() {
+			rewards = append(rewards, &Reward{
+				Validator:     it.Event.Validator,
+				L2BlockNumber: it.Event.L2BlockNumber.Uint64(),
+				Amount:        it.Event.Amount,
+				TxBlockNumber: it.Event.Raw.BlockNumber,
+				TxHash:        it.Event.Raw.TxHash,
+			})
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate rewards: %w", err)
+		}
+	}
+	return rewards, nil
+}
+
+// setMinted sets the governance tokens minted in the L2 blocks of each output, if the MintManager is deployed.
+func (f *Fetcher) setMinted(ctx context.Context, outputs []*Output, prevL2Block uint64) error {
+	code, err := f.l2.CodeAt(ctx, predeploys.MintManagerAddr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get MintManager code: %w", err)
+	}
+	if len(code) == 0 {
+		return nil
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	activated, err := f.mintManager.MINTACTIVATEDBLOCK(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get mint activated block: %w", err)
+	}
+	window, err := f.mintManager.SLIDINGWINDOWBLOCKS(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get sliding window blocks: %w", err)
+	}
+	schedule := &MintSchedule{
+		ActivatedBlock: activated.Uint64(),
+		WindowBlocks:   window.Uint64(),
+		AmountAt: func(block uint64) (*big.Int, error) {
+			return f.mintManager.MintAmountPerBlock(opts, new(big.Int).SetUint64(block))
+		},
+	}
+	for _, o := range outputs {
+		if o.Minted, err = schedule.Minted(prevL2Block+1, o.L2BlockNumber); err != nil {
+			return fmt.Errorf("failed to compute minted amount of output %d: %w", o.Index, err)
+		}
+		prevL2Block = o.L2BlockNumber
+	}
+	return nil
+}
+
+// MintSchedule computes the governance tokens the MintManager mints per block. The amount per block only changes
+// once per sliding window, so it is queried once per window. The catch-up mint of the first minted block is not included.
+type MintSchedule struct {
+	ActivatedBlock uint64
+	WindowBlocks   uint64
+	AmountAt       func(block uint64) (*big.Int, error)
+
+	cache map[uint64]*big.Int
+}
+
+// Minted returns the amount minted in the blocks from start to end included.
+func (s *MintSchedule) Minted(start, end uint64) (*big.Int, error) {
+	if s.WindowBlocks == 0 {
+		return nil, errors.New("sliding window blocks is zero")
+	}
+	if s.cache == nil {
+		s.cache = make(map[uint64]*big.Int)
+	}
+	start = max(start, s.ActivatedBlock, 1)
+
+	total := new(big.Int)
+	for block := start; block <= end; {
+		epoch := (block - 1) / s.WindowBlocks
+		epochEnd := min((epoch+1)*s.WindowBlocks, end)
+		amount, ok := s.cache[epoch]
+		if !ok {
+			var err error
+			if amount, err = s.AmountAt(block); err != nil {
+				return nil, err
+			}
+			s.cache[epoch] = amount
+		}
+		total.Add(total, new(big.Int).Mul(amount, new(big.Int).SetUint64(epochEnd-block+1)))
+		block = epochEnd + 1
+	}
+	return total, nil
+}
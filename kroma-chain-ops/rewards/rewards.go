@@ -0,0 +1,197 @@
+package rewards
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Output is an output submitted to the L2OutputOracle, with the reward it was given on L2.
+type Output struct {
+	Index         uint64         `json:"index"`
+	OutputRoot    common.Hash    `json:"outputRoot"`
+	Submitter     common.Address `json:"submitter"`
+	L2BlockNumber uint64         `json:"l2BlockNumber"`
+	// L1Timestamp is the timestamp of the L1 block the output was submitted in.
+	L1Timestamp uint64 `json:"l1Timestamp"`
+
+	// Scalars are the validator reward scalars that were in effect for the L2 blocks of the output.
+	Scalars []ScalarSpan `json:"scalars"`
+	// Minted is the amount of governance tokens minted in the L2 blocks of the output, nil if not known.
+	Minted *big.Int `json:"minted,omitempty"`
+
+	// Reward is nil until the ValidatorRewardVault rewards the output.
+	Reward *Reward `json:"reward,omitempty"`
+}
+
+// Reward is a Rewarded event of the ValidatorRewardVault.
+type Reward struct {
+	Validator     common.Address `json:"validator"`
+	L2BlockNumber uint64         `json:"l2BlockNumber"`
+	Amount        *big.Int       `json:"amount"`
+	// TxBlockNumber and TxHash locate the L2 transaction that paid the reward.
+	TxBlockNumber uint64      `json:"txBlockNumber"`
+	TxHash        common.Hash `json:"txHash"`
+}
+
+// ScalarChange is a validator reward scalar update of the SystemConfig.
+type ScalarChange struct {
+	L1BlockNumber uint64 `json:"l1BlockNumber"`
+	L1Timestamp   uint64 `json:"l1Timestamp"`
+	Scalar        uint64 `json:"scalar"`
+}
+
+// ScalarSpan is a validator reward scalar that is in effect from an L2 block on.
+type ScalarSpan struct {
+	FromL2Block uint64 `json:"fromL2Block"`
+	Scalar      uint64 `json:"scalar"`
+}
+
+// ValidatorSummary sums up the outputs and rewards of a validator.
+type ValidatorSummary struct {
+	Validator common.Address `json:"validator"`
+	Submitted uint64         `json:"submitted"`
+	Rewarded  uint64         `json:"rewarded"`
+	Total     *big.Int       `json:"total"`
+}
+
+// Report is the reward accounting of a range of outputs.
+type Report struct {
+	Outputs    []*Output           `json:"outputs"`
+	Validators []*ValidatorSummary `json:"validators"`
+}
+
+// L2Timing converts L2 block numbers to timestamps, the same way as L2OutputOracle.computeL2Timestamp.
+type L2Timing struct {
+	StartingBlockNumber uint64
+	StartingTimestamp   uint64
+	BlockTime           uint64
+}
+
+func (t L2Timing) Timestamp(l2BlockNumber uint64) uint64 {
+	return t.StartingTimestamp + (l2BlockNumber-t.StartingBlockNumber)*t.BlockTime
+}
+
+// Attribute matches the rewards with the outputs, attributes them to the validators and sets
+// the scalars of each output. An output covers the L2 blocks after the previous output up to its own block.
+// A scalar change is attributed to the L2 blocks from the first one that is not older than the L1 block of the
+// change; the L2 chain actually applies it once an L1 origin includes it, which is at most the sequencer drift later.
+// The outputs and the scalar changes must be sorted, the first change being the scalar in effect before the range.
+func Attribute(outputs []*Output, rewards []*Reward, scalars []ScalarChange, timing L2Timing, prevL2Block uint64) *Report {
+	rewardOf := make(map[uint64]*Reward, len(rewards))
+	for _, r := range rewards {
+		rewardOf[r.L2BlockNumber] = r
+	}
+
+	validators := make(map[common.Address]*ValidatorSummary)
+	summary := func(addr common.Address) *ValidatorSummary {
+		s, ok := validators[addr]
+		if !ok {
+			s = &ValidatorSummary{Validator: addr, Total: new(big.Int)}
+			validators[addr] = s
+		}
+		return s
+	}
+
+	for _, o := range outputs {
+		o.Scalars = scalarSpans(scalars, timing, prevL2Block+1, o.L2BlockNumber)
+		prevL2Block = o.L2BlockNumber
+
+		summary(o.Submitter).Submitted++
+		if r, ok := rewardOf[o.L2BlockNumber]; ok {
+			o.Reward = r
+			s := summary(r.Validator)
+			s.Rewarded++
+			s.Total.Add(s.Total, r.Amount)
+		}
+	}
+
+	report := &Report{Outputs: outputs, Validators: make([]*ValidatorSummary, 0, len(validators))}
+	for _, s := range validators {
+		report.Validators = append(report.Validators, s)
+	}
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return report.Validators[i].Validator.Cmp(report.Validators[j].Validator) < 0
+	})
+	return report
+}
+
+// scalarSpans returns the scalars in effect for the L2 blocks from start to end included.
+func scalarSpans(scalars []ScalarChange, timing L2Timing, start, end uint64) []ScalarSpan {
+	spans := make([]ScalarSpan, 0, 1)
+	for i, change := range scalars {
+		from := start
+		if i > 0 {
+			from = firstL2BlockAt(timing, change.L1Timestamp)
+		}
+		if from > end {
+			break
+		}
+		if from < start {
+			from = start
+		}
+		if len(spans) > 0 && spans[len(spans)-1].FromL2Block == from {
+			spans = spans[:len(spans)-1]
+		}
+		spans = append(spans, ScalarSpan{FromL2Block: from, Scalar: change.Scalar})
+	}
+	return spans
+}
+
+// firstL2BlockAt returns the first L2 block with a timestamp not older than the given time.
+func firstL2BlockAt(timing L2Timing, timestamp uint64) uint64 {
+	if timestamp <= timing.StartingTimestamp || timing.BlockTime == 0 {
+		return timing.StartingBlockNumber
+	}
+	return timing.StartingBlockNumber + (timestamp-timing.StartingTimestamp+timing.BlockTime-1)/timing.BlockTime
+}
+
+var csvHeader = []string{
+	"output_index", "l2_block_number", "output_root", "submitter", "l1_timestamp", "scalars", "minted",
+	"rewarded_validator", "reward", "reward_tx_block", "reward_tx_hash",
+}
+
+// WriteCSV writes a row per output.
+func (r *Report) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, o := range r.Outputs {
+		scalars := make([]string, len(o.Scalars))
+		for i, s := range o.Scalars {
+			scalars[i] = fmt.Sprintf("%d@%d", s.Scalar, s.FromL2Block)
+		}
+		minted := ""
+		if o.Minted != nil {
+			minted = o.Minted.String()
+		}
+		row := []string{
+			strconv.FormatUint(o.Index, 10),
+			strconv.FormatUint(o.L2BlockNumber, 10),
+			o.OutputRoot.Hex(),
+			o.Submitter.Hex(),
+			strconv.FormatUint(o.L1Timestamp, 10),
+			strings.Join(scalars, ";"),
+			minted,
+			"", "", "", "",
+		}
+		if o.Reward != nil {
+			row[7] = o.Reward.Validator.Hex()
+			row[8] = o.Reward.Amount.String()
+			row[9] = strconv.FormatUint(o.Reward.TxBlockNumber, 10)
+			row[10] = o.Reward.TxHash.Hex()
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
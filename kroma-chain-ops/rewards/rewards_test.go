@@ -0,0 +1,98 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	validatorA = common.HexToAddress("0xaaaa")
+	validatorB = common.HexToAddress("0xbbbb")
+	timing     = L2Timing{StartingBlockNumber: 0, StartingTimestamp: 1000, BlockTime: 2}
+)
+
+func TestAttribute(t *testing.T) {
+	outputs := []*Output{
+		{Index: 1, Submitter: validatorA, L2BlockNumber: 20},
+		{Index: 2, Submitter: validatorB, L2BlockNumber: 30},
+		{Index: 3, Submitter: validatorA, L2BlockNumber: 40},
+	}
+	rewards := []*Reward{
+		{Validator: validatorA, L2BlockNumber: 20, Amount: big.NewInt(100)},
+		// the output was replaced, so the reward goes to the challenger
+		{Validator: validatorA, L2BlockNumber: 30, Amount: big.NewInt(50)},
+		// not an output of the range
+		{Validator: validatorB, L2BlockNumber: 50, Amount: big.NewInt(7)},
+	}
+	scalars := []ScalarChange{
+		{L1Timestamp: 900, Scalar: 5000},
+		// applies from L2 block 25, at timestamp 1050
+		{L1Timestamp: 1049, Scalar: 6000},
+		// both apply from L2 block 31, only the latest counts
+		{L1Timestamp: 1061, Scalar: 7000},
+		{L1Timestamp: 1062, Scalar: 8000},
+	}
+
+	report := Attribute(outputs, rewards, scalars, timing, 10)
+
+	require.Equal(t, []ScalarSpan{{FromL2Block: 11, Scalar: 5000}}, outputs[0].Scalars)
+	require.Equal(t, []ScalarSpan{{FromL2Block: 21, Scalar: 5000}, {FromL2Block: 25, Scalar: 6000}}, outputs[1].Scalars)
+	require.Equal(t, []ScalarSpan{{FromL2Block: 31, Scalar: 8000}}, outputs[2].Scalars)
+
+	require.Equal(t, rewards[0], outputs[0].Reward)
+	require.Equal(t, rewards[1], outputs[1].Reward)
+	require.Nil(t, outputs[2].Reward)
+
+	require.Equal(t, []*ValidatorSummary{
+		{Validator: validatorA, Submitted: 2, Rewarded: 2, Total: big.NewInt(150)},
+		{Validator: validatorB, Submitted: 1, Rewarded: 0, Total: new(big.Int)},
+	}, report.Validators)
+}
+
+func TestMintSchedule(t *testing.T) {
+	queried := make([]uint64, 0)
+	schedule := &MintSchedule{
+		ActivatedBlock: 5,
+		WindowBlocks:   10,
+		AmountAt: func(block uint64) (*big.Int, error) {
+			queried = append(queried, block)
+			// halves every window
+			return big.NewInt(int64(1000 >> ((block - 1) / 10))), nil
+		},
+	}
+
+	minted, err := schedule.Minted(1, 25)
+	require.NoError(t, err)
+	// blocks 5-10, 11-20, 21-25
+	require.Equal(t, big.NewInt(6*1000+10*500+5*250), minted)
+	require.Equal(t, []uint64{5, 11, 21}, queried)
+
+	minted, err = schedule.Minted(26, 30)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5*250), minted)
+	require.Len(t, queried, 3, "amount of a window is cached")
+}
+
+func TestWriteCSV(t *testing.T) {
+	report := Attribute([]*Output{
+		{Index: 1, Submitter: validatorA, L2BlockNumber: 20, Minted: big.NewInt(3)},
+		{Index: 2, Submitter: validatorB, L2BlockNumber: 30},
+	}, []*Reward{
+		{Validator: validatorA, L2BlockNumber: 20, Amount: big.NewInt(100), TxBlockNumber: 99},
+	}, []ScalarChange{{Scalar: 5000}}, timing, 10)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteCSV(&buf))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, csvHeader, rows[0])
+	require.Equal(t, []string{"1", "20", common.Hash{}.Hex(), validatorA.Hex(), "0", "5000@11", "3",
+		validatorA.Hex(), "100", "99", common.Hash{}.Hex()}, rows[1])
+	require.Equal(t, []string{"2", "30", common.Hash{}.Hex(), validatorB.Hex(), "0", "5000@21", "", "", "", "", ""}, rows[2])
+}
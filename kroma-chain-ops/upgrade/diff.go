@@ -0,0 +1,313 @@
+package upgrade
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	opbindings "github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+	kromabindings "github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+	"github.com/kroma-network/kroma/kroma-chain-ops/immutables"
+)
+
+// Side is one of the two L2 genesis states that are compared.
+type Side struct {
+	Genesis *core.Genesis
+	// Immutables is only known when the side is built from a deploy config.
+	Immutables *immutables.PredeploysImmutableConfig
+}
+
+// FromConfig builds the L2 genesis of the deploy config on top of the given L1 start block.
+func FromConfig(config *genesis.DeployConfig, l1StartBlock *types.Block) (*Side, error) {
+	gen, err := genesis.BuildL2Genesis(config, l1StartBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build L2 genesis: %w", err)
+	}
+	immutableConfig, err := genesis.NewL2ImmutableConfig(config, l1StartBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build immutables config: %w", err)
+	}
+	return &Side{Genesis: gen, Immutables: immutableConfig}, nil
+}
+
+// FromGenesis uses an already built L2 genesis, e.g. the one of a live chain built with older artifacts.
+func FromGenesis(gen *core.Genesis) *Side {
+	return &Side{Genesis: gen}
+}
+
+// Change is a value that differs between the two sides.
+type Change struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Field is a storage layout variable of a slot that differs between the two sides.
+type Field struct {
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Change
+}
+
+// SlotDiff is a storage slot that differs between the two sides.
+type SlotDiff struct {
+	Slot common.Hash `json:"slot"`
+	From common.Hash `json:"from"`
+	To   common.Hash `json:"to"`
+	// Fields are the variables of the storage layout that are packed in the slot.
+	// It is empty when the slot is not a fixed slot of the layout, e.g. a mapping entry.
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// PredeployDiff lists everything that differs for a single predeploy.
+type PredeployDiff struct {
+	Name    string         `json:"name"`
+	Address common.Address `json:"address"`
+	Proxied bool           `json:"proxied"`
+	// Code is the code hash of the predeploy account. For a proxied predeploy, this is the code of the proxy.
+	Code *Change `json:"code,omitempty"`
+	// ImplementationCode is the code hash of the implementation the proxy points to.
+	ImplementationCode *Change `json:"implementationCode,omitempty"`
+	// Storage are the slots of the predeploy account.
+	Storage []SlotDiff `json:"storage,omitempty"`
+	// ImplementationStorage are the slots of the implementation account.
+	ImplementationStorage []SlotDiff `json:"implementationStorage,omitempty"`
+	// Immutables are only compared when both sides are built from a deploy config.
+	Immutables map[string]Change `json:"immutables,omitempty"`
+}
+
+// Empty returns true if nothing differs.
+func (d *PredeployDiff) Empty() bool {
+	return d.Code == nil && d.ImplementationCode == nil && len(d.Storage) == 0 &&
+		len(d.ImplementationStorage) == 0 && len(d.Immutables) == 0
+}
+
+// Diff compares every predeploy of the two sides and returns the ones that differ, sorted by name.
+// The implementation slot of a proxy is not reported when the code behind both implementations is the same,
+// since the implementation address itself is not relevant.
+func Diff(from, to *Side) ([]*PredeployDiff, error) {
+	var immutableDiffs map[string]map[string]Change
+	if from.Immutables != nil && to.Immutables != nil {
+		var err error
+		if immutableDiffs, err = diffImmutables(from.Immutables, to.Immutables); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0, len(predeploys.Predeploys))
+	for name := range predeploys.Predeploys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	diffs := make([]*PredeployDiff, 0)
+	for _, name := range names {
+		d := diffPredeploy(name, predeploys.Predeploys[name].Address, from.Genesis.Alloc, to.Genesis.Alloc)
+		d.Immutables = immutableDiffs[name]
+		if !d.Empty() {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs, nil
+}
+
+func diffPredeploy(name string, addr common.Address, from, to core.GenesisAlloc) *PredeployDiff {
+	layout := storageLayout(name)
+
+	fromImpl, fromProxied := implementation(from, addr)
+	toImpl, toProxied := implementation(to, addr)
+	d := &PredeployDiff{
+		Name:    name,
+		Address: addr,
+		Proxied: toProxied,
+		Code:    diffCode(from[addr].Code, to[addr].Code),
+	}
+	if fromProxied || toProxied {
+		d.ImplementationCode = diffCode(from[fromImpl].Code, to[toImpl].Code)
+		d.ImplementationStorage = diffStorage(layout, from[fromImpl].Storage, to[toImpl].Storage)
+	}
+	d.Storage = diffStorage(layout, from[addr].Storage, to[addr].Storage)
+	if d.ImplementationCode == nil {
+		d.Storage = filterSlot(d.Storage, genesis.ImplementationSlot)
+	}
+	return d
+}
+
+// implementation returns the implementation the proxy at the address points to.
+func implementation(alloc core.GenesisAlloc, addr common.Address) (common.Address, bool) {
+	account, ok := alloc[addr]
+	if !ok {
+		return common.Address{}, false
+	}
+	slot, ok := account.Storage[genesis.ImplementationSlot]
+	if !ok {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(slot.Bytes()), true
+}
+
+func diffCode(from, to []byte) *Change {
+	fromHash, toHash := codeHash(from), codeHash(to)
+	if fromHash == toHash {
+		return nil
+	}
+	return &Change{From: fromHash.Hex(), To: toHash.Hex()}
+}
+
+func codeHash(code []byte) common.Hash {
+	if len(code) == 0 {
+		return types.EmptyCodeHash
+	}
+	return crypto.Keccak256Hash(code)
+}
+
+func diffStorage(layout *solc.StorageLayout, from, to map[common.Hash]common.Hash) []SlotDiff {
+	slots := make(map[common.Hash]struct{})
+	for slot := range from {
+		slots[slot] = struct{}{}
+	}
+	for slot := range to {
+		slots[slot] = struct{}{}
+	}
+
+	diffs := make([]SlotDiff, 0)
+	for slot := range slots {
+		fromVal, toVal := from[slot], to[slot]
+		if fromVal == toVal {
+			continue
+		}
+		diffs = append(diffs, SlotDiff{
+			Slot:   slot,
+			From:   fromVal,
+			To:     toVal,
+			Fields: decodeSlot(layout, slot, fromVal, toVal),
+		})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return new(big.Int).SetBytes(diffs[i].Slot[:]).Cmp(new(big.Int).SetBytes(diffs[j].Slot[:])) < 0
+	})
+	return diffs
+}
+
+func filterSlot(diffs []SlotDiff, slot common.Hash) []SlotDiff {
+	filtered := diffs[:0]
+	for _, d := range diffs {
+		if d.Slot != slot {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// decodeSlot decodes the changed variables of the slot with the storage layout.
+func decodeSlot(layout *solc.StorageLayout, slot, from, to common.Hash) []Field {
+	switch slot {
+	case genesis.ImplementationSlot:
+		return []Field{addressField("_implementation (EIP-1967)", from, to)}
+	case genesis.AdminSlot:
+		return []Field{addressField("_admin (EIP-1967)", from, to)}
+	}
+	if layout == nil {
+		return nil
+	}
+
+	fields := make([]Field, 0)
+	for _, entry := range layout.Storage {
+		if common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot))) != slot {
+			continue
+		}
+		typ, ok := layout.Types[entry.Type]
+		if !ok || typ.NumberOfBytes == 0 || entry.Offset+typ.NumberOfBytes > common.HashLength {
+			continue
+		}
+		start := common.HashLength - entry.Offset - typ.NumberOfBytes
+		end := common.HashLength - entry.Offset
+		fromVal := formatValue(typ.Label, from[start:end])
+		toVal := formatValue(typ.Label, to[start:end])
+		if fromVal == toVal {
+			continue
+		}
+		fields = append(fields, Field{
+			Label:  entry.Label,
+			Type:   typ.Label,
+			Change: Change{From: fromVal, To: toVal},
+		})
+	}
+	return fields
+}
+
+func addressField(label string, from, to common.Hash) Field {
+	return Field{
+		Label: label,
+		Type:  "address",
+		Change: Change{
+			From: common.BytesToAddress(from.Bytes()).Hex(),
+			To:   common.BytesToAddress(to.Bytes()).Hex(),
+		},
+	}
+}
+
+func formatValue(typ string, val []byte) string {
+	switch {
+	case typ == "address" || typ == "address payable" || strings.HasPrefix(typ, "contract "):
+		return common.BytesToAddress(val).Hex()
+	case typ == "bool":
+		return fmt.Sprintf("%t", new(big.Int).SetBytes(val).Sign() != 0)
+	case strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "enum "):
+		return new(big.Int).SetBytes(val).String()
+	default:
+		return fmt.Sprintf("0x%x", val)
+	}
+}
+
+// storageLayout returns the storage layout of the predeploy, or nil if there is none.
+// Kroma bindings are searched first, then OP bindings, the same way the genesis is built.
+func storageLayout(name string) *solc.StorageLayout {
+	if layout, err := kromabindings.GetStorageLayout(name); err == nil {
+		return layout
+	}
+	if layout, err := opbindings.GetStorageLayout(name); err == nil {
+		return layout
+	}
+	return nil
+}
+
+func diffImmutables(from, to *immutables.PredeploysImmutableConfig) (map[string]map[string]Change, error) {
+	toValues := make(map[string]reflect.Value)
+	if err := to.ForEach(func(name string, values any) error {
+		toValues[name] = reflect.ValueOf(values)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	diffs := make(map[string]map[string]Change)
+	err := from.ForEach(func(name string, values any) error {
+		fromVal, toVal := reflect.ValueOf(values), toValues[name]
+		for i := 0; i < fromVal.NumField(); i++ {
+			fromField := fmt.Sprintf("%v", fromVal.Field(i).Interface())
+			toField := fmt.Sprintf("%v", toVal.Field(i).Interface())
+			if fromField == toField {
+				continue
+			}
+			if diffs[name] == nil {
+				diffs[name] = make(map[string]Change)
+			}
+			diffs[name][fromVal.Type().Field(i).Name] = Change{From: fromField, To: toField}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
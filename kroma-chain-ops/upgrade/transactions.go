@@ -0,0 +1,171 @@
+package upgrade
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/squash"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+)
+
+// proxyUpdateGas is the gas limit of an upgradeTo call, the same as the Ecotone proxy updates.
+const proxyUpdateGas = 50_000
+
+// Transaction is a deposit transaction of a network upgrade, with the intent its source hash is derived from.
+type Transaction struct {
+	Intent    string        `json:"intent"`
+	Predeploy string        `json:"predeploy"`
+	Tx        hexutil.Bytes `json:"tx"`
+}
+
+// Transactions returns the deposit transactions of the network upgrade that move the predeploy implementations
+// of a chain at the "from" genesis to the ones of the "to" genesis, in the same way as the Ecotone upgrade:
+// all the implementations are deployed first by fresh deployer accounts, then the proxies are updated.
+// Only the implementations of proxied predeploys can be moved this way, see Unmigratable for the rest.
+func Transactions(upgradeName string, from, to *Side) ([]Transaction, error) {
+	names := make([]string, 0)
+	for name, predeploy := range predeploys.Predeploys {
+		fromImpl, fromProxied := implementation(from.Genesis.Alloc, predeploy.Address)
+		toImpl, toProxied := implementation(to.Genesis.Alloc, predeploy.Address)
+		if !fromProxied || !toProxied {
+			continue
+		}
+		if diffCode(from.Genesis.Alloc[fromImpl].Code, to.Genesis.Alloc[toImpl].Code) != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deployments := make([]Transaction, 0, len(names))
+	updates := make([]Transaction, 0, len(names))
+	for _, name := range names {
+		addr := predeploys.Predeploys[name].Address
+		toImpl, _ := implementation(to.Genesis.Alloc, addr)
+		code := to.Genesis.Alloc[toImpl].Code
+
+		deployIntent := fmt.Sprintf("%s: %s Deployment", upgradeName, name)
+		deployer := DeployerAddress(deployIntent)
+		initCode := deploymentCode(code)
+		deployment, err := types.NewTx(&types.DepositTx{
+			SourceHash: (&derive.UpgradeDepositSource{Intent: deployIntent}).SourceHash(),
+			From:       deployer,
+			To:         nil,
+			Mint:       big.NewInt(0),
+			Value:      big.NewInt(0),
+			Gas:        deploymentGas(initCode),
+			Data:       initCode,
+		}).MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s deployment: %w", name, err)
+		}
+		deployments = append(deployments, Transaction{Intent: deployIntent, Predeploy: name, Tx: deployment})
+
+		updateIntent := fmt.Sprintf("%s: %s Proxy Update", upgradeName, name)
+		update, err := types.NewTx(&types.DepositTx{
+			SourceHash: (&derive.UpgradeDepositSource{Intent: updateIntent}).SourceHash(),
+			From:       common.Address{},
+			To:         &addr,
+			Mint:       big.NewInt(0),
+			Value:      big.NewInt(0),
+			Gas:        proxyUpdateGas,
+			Data:       upgradeToCalldata(crypto.CreateAddress(deployer, 0)),
+		}).MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s proxy update: %w", name, err)
+		}
+		updates = append(updates, Transaction{Intent: updateIntent, Predeploy: name, Tx: update})
+	}
+	return append(deployments, updates...), nil
+}
+
+// Encoded returns the encoded transactions, as returned by derive.EcotoneNetworkUpgradeTransactions.
+func Encoded(txs []Transaction) []hexutil.Bytes {
+	encoded := make([]hexutil.Bytes, len(txs))
+	for i, tx := range txs {
+		encoded[i] = tx.Tx
+	}
+	return encoded
+}
+
+// DeployerAddress returns the account that deploys an implementation. It is derived from the intent
+// of the deployment, so it is an account without any transaction on a live chain.
+func DeployerAddress(intent string) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte(intent)))
+}
+
+// deploymentCode returns the init code that returns the given runtime code, so that
+// the implementation is deployed with the exact code of the genesis, immutables included.
+func deploymentCode(code []byte) []byte {
+	prefix := []byte{
+		0x61, byte(len(code) >> 8), byte(len(code)), // PUSH2 len(code)
+		0x80,       // DUP1
+		0x60, 0x0c, // PUSH1 len(prefix)
+		0x60, 0x00, // PUSH1 0
+		0x39,       // CODECOPY
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	}
+	return append(prefix, code...)
+}
+
+// deploymentGas covers the contract creation, the init code calldata and the code deposit with some margin.
+func deploymentGas(initCode []byte) uint64 {
+	return 100_000 + 300*uint64(len(initCode))
+}
+
+func upgradeToCalldata(addr common.Address) []byte {
+	return append(common.CopyBytes(derive.UpgradeToFuncBytes4), common.LeftPadBytes(addr.Bytes(), 32)...)
+}
+
+// Simulate applies the transactions on top of the "from" genesis and returns the resulting state,
+// which can be diffed against the "to" genesis to check the upgrade.
+func Simulate(from *Side, txs []Transaction) (*Side, error) {
+	db := state.NewMemoryStateDB(copyGenesis(from.Genesis))
+	sim := squash.NewSimulator(db)
+	if err := sim.AddUpgradeTxs(Encoded(txs)); err != nil {
+		return nil, err
+	}
+	return FromGenesis(db.Genesis()), nil
+}
+
+// Unmigratable returns the differences that are left after the upgrade transactions are applied,
+// i.e. the ones that a network upgrade has to take care of in another way.
+func Unmigratable(upgradeName string, from, to *Side) ([]*PredeployDiff, error) {
+	txs, err := Transactions(upgradeName, from, to)
+	if err != nil {
+		return nil, err
+	}
+	upgraded, err := Simulate(from, txs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate upgrade txs: %w", err)
+	}
+	return Diff(FromGenesis(upgraded.Genesis), FromGenesis(to.Genesis))
+}
+
+func copyGenesis(gen *core.Genesis) *core.Genesis {
+	cpy := *gen
+	cpy.Alloc = make(core.GenesisAlloc, len(gen.Alloc))
+	for addr, account := range gen.Alloc {
+		accountCpy := account
+		if account.Balance != nil {
+			accountCpy.Balance = new(big.Int).Set(account.Balance)
+		}
+		if account.Storage != nil {
+			accountCpy.Storage = make(map[common.Hash]common.Hash, len(account.Storage))
+			for k, v := range account.Storage {
+				accountCpy.Storage[k] = v
+			}
+		}
+		cpy.Alloc[addr] = accountCpy
+	}
+	return &cpy
+}
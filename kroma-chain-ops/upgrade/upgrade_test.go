@@ -0,0 +1,95 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/predeploys"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+func buildSides(t *testing.T) (*Side, *Side) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	l1Block := types.NewBlock(&types.Header{Number: common.Big0, Time: 1700000000, BaseFee: common.Big1}, nil, nil, nil, trie.NewStackTrie(nil))
+
+	from, err := FromConfig(config, l1Block)
+	require.NoError(t, err)
+
+	upgraded := config.Copy()
+	upgraded.L1FeeVaultRecipient = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	upgraded.ProxyAdminOwner = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	to, err := FromConfig(upgraded, l1Block)
+	require.NoError(t, err)
+	return from, to
+}
+
+func TestDiff(t *testing.T) {
+	from, to := buildSides(t)
+
+	none, err := Diff(from, from)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	diffs, err := Diff(from, to)
+	require.NoError(t, err)
+	require.Len(t, diffs, 2)
+
+	feeVault := diffs[0]
+	require.Equal(t, "L1FeeVault", feeVault.Name)
+	require.True(t, feeVault.Proxied)
+	require.Nil(t, feeVault.Code)
+	require.NotNil(t, feeVault.ImplementationCode)
+	require.Empty(t, feeVault.Storage)
+	require.Equal(t, "0x1111111111111111111111111111111111111111", feeVault.Immutables["Recipient"].To)
+
+	proxyAdmin := diffs[1]
+	require.Equal(t, "ProxyAdmin", proxyAdmin.Name)
+	require.Nil(t, proxyAdmin.ImplementationCode)
+	require.Len(t, proxyAdmin.Storage, 1)
+	require.Equal(t, []Field{{
+		Label: "_owner",
+		Type:  "address",
+		Change: Change{
+			From: common.BytesToAddress(proxyAdmin.Storage[0].From.Bytes()).Hex(),
+			To:   "0x2222222222222222222222222222222222222222",
+		},
+	}}, proxyAdmin.Storage[0].Fields)
+}
+
+func TestTransactions(t *testing.T) {
+	from, to := buildSides(t)
+
+	txs, err := Transactions("Test", from, to)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, "Test: L1FeeVault Deployment", txs[0].Intent)
+	require.Equal(t, "Test: L1FeeVault Proxy Update", txs[1].Intent)
+
+	var deployment types.Transaction
+	require.NoError(t, deployment.UnmarshalBinary(txs[0].Tx))
+	require.True(t, deployment.IsDepositTx())
+	require.Nil(t, deployment.To())
+
+	upgraded, err := Simulate(from, txs)
+	require.NoError(t, err)
+	implAddr := crypto.CreateAddress(DeployerAddress(txs[0].Intent), 0)
+	impl, ok := implementation(upgraded.Genesis.Alloc, predeploys.L1FeeVaultAddr)
+	require.True(t, ok)
+	require.Equal(t, implAddr, impl)
+
+	// the simulation does not modify the "from" genesis
+	_, ok = from.Genesis.Alloc[implAddr]
+	require.False(t, ok)
+
+	// only the ProxyAdmin owner is left to be migrated
+	left, err := Unmigratable("Test", from, to)
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	require.Equal(t, "ProxyAdmin", left[0].Name)
+}
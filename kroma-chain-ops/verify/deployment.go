@@ -0,0 +1,310 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+// Difference is a value of a deployed contract that does not match the deploy config or the deployments.
+type Difference struct {
+	Contract string
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s.%s: expected %s, got %s", d.Contract, d.Field, d.Expected, d.Actual)
+}
+
+// Verifier checks the state of the deployed L1 contracts against the deploy config they were deployed with.
+type Verifier struct {
+	caller      bind.ContractCaller
+	config      *genesis.DeployConfig
+	deployments *genesis.L1Deployments
+	// proxyAdminOwner is the owner of the L1 ProxyAdmin: the deployer of the L1 contracts,
+	// unlike the ProxyAdminOwner of the deploy config, which owns the L2 ProxyAdmin predeploy.
+	proxyAdminOwner common.Address
+
+	opts        *bind.CallOpts
+	differences []Difference
+}
+
+func NewVerifier(caller bind.ContractCaller, config *genesis.DeployConfig, deployments *genesis.L1Deployments, proxyAdminOwner common.Address) *Verifier {
+	return &Verifier{caller: caller, config: config, deployments: deployments, proxyAdminOwner: proxyAdminOwner}
+}
+
+// Verify reads the deployed contracts and returns every difference to the deploy config and the deployments.
+// An error is returned only if the contracts cannot be read.
+func (v *Verifier) Verify(ctx context.Context) ([]Difference, error) {
+	v.opts = &bind.CallOpts{Context: ctx}
+	v.differences = nil
+
+	checks := []struct {
+		name string
+		fn   func() error
+	}{
+		{"ProxyAdmin", v.checkProxyAdmin},
+		{"SystemConfig", v.checkSystemConfig},
+		{"L2OutputOracle", v.checkL2OutputOracle},
+		{"ValidatorPool", v.checkValidatorPool},
+		{"Colosseum", v.checkColosseum},
+		{"KromaPortal", v.checkKromaPortal},
+		{"SecurityCouncil", v.checkSecurityCouncil},
+	}
+	for _, c := range checks {
+		if err := c.fn(); err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", c.name, err)
+		}
+	}
+	return v.differences, nil
+}
+
+func (v *Verifier) report(contract, field string, expected, actual any) {
+	exp, act := format(expected), format(actual)
+	if exp != act {
+		v.differences = append(v.differences, Difference{Contract: contract, Field: field, Expected: exp, Actual: act})
+	}
+}
+
+// check reads a value of a contract and reports it if it differs from the expected value.
+func check[T any](v *Verifier, contract, field string, expected T, read func(*bind.CallOpts) (T, error)) error {
+	actual, err := read(v.opts)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", field, err)
+	}
+	v.report(contract, field, expected, actual)
+	return nil
+}
+
+func format(value any) string {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return "0"
+		}
+		return v.String()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case common.Hash:
+		return v.Hex()
+	case common.Address:
+		return v.Hex()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func u64(v uint64) *big.Int {
+	return new(big.Int).SetUint64(v)
+}
+
+type proxy struct {
+	name           string
+	address        common.Address
+	implementation common.Address
+}
+
+// proxies returns the deployed proxies, with the implementations they are expected to point to.
+func (v *Verifier) proxies() []proxy {
+	val := reflect.ValueOf(v.deployments).Elem()
+	var proxies []proxy
+	for i := 0; i < val.NumField(); i++ {
+		name := val.Type().Field(i).Name
+		if !strings.HasSuffix(name, "Proxy") {
+			continue
+		}
+		impl := val.FieldByName(strings.TrimSuffix(name, "Proxy"))
+		addr := val.Field(i).Interface().(common.Address)
+		if !impl.IsValid() || addr == (common.Address{}) {
+			continue
+		}
+		proxies = append(proxies, proxy{name: name, address: addr, implementation: impl.Interface().(common.Address)})
+	}
+	return proxies
+}
+
+func (v *Verifier) checkProxyAdmin() error {
+	proxyAdmin, err := bindings.NewProxyAdminCaller(v.deployments.ProxyAdmin, v.caller)
+	if err != nil {
+		return err
+	}
+	if err := check(v, "ProxyAdmin", "owner", v.proxyAdminOwner, proxyAdmin.Owner); err != nil {
+		return err
+	}
+
+	for _, p := range v.proxies() {
+		getImpl := func(opts *bind.CallOpts) (common.Address, error) {
+			return proxyAdmin.GetProxyImplementation(opts, p.address)
+		}
+		if err := check(v, p.name, "implementation", p.implementation, getImpl); err != nil {
+			return err
+		}
+		getAdmin := func(opts *bind.CallOpts) (common.Address, error) {
+			return proxyAdmin.GetProxyAdmin(opts, p.address)
+		}
+		if err := check(v, p.name, "admin", v.deployments.ProxyAdmin, getAdmin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) checkSystemConfig() error {
+	sysCfg, err := bindings.NewSystemConfigCaller(v.deployments.SystemConfigProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	const name = "SystemConfig"
+	batcherHash := common.BytesToHash(v.config.BatchSenderAddress.Bytes())
+	return firstErr(
+		check(v, name, "owner", v.deployments.TimeLockProxy, sysCfg.Owner),
+		check(v, name, "overhead", u64(v.config.GasPriceOracleOverhead), sysCfg.Overhead),
+		check(v, name, "scalar", u64(v.config.GasPriceOracleScalar), sysCfg.Scalar),
+		check(v, name, "batcherHash", [32]byte(batcherHash), sysCfg.BatcherHash),
+		check(v, name, "gasLimit", uint64(v.config.L2GenesisBlockGasLimit), sysCfg.GasLimit),
+		check(v, name, "unsafeBlockSigner", v.config.P2PSequencerAddress, sysCfg.UnsafeBlockSigner),
+		check(v, name, "validatorRewardScalar", u64(v.config.ValidatorRewardScalar), sysCfg.ValidatorRewardScalar),
+	)
+}
+
+func (v *Verifier) checkL2OutputOracle() error {
+	l2oo, err := bindings.NewL2OutputOracleCaller(v.deployments.L2OutputOracleProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	const name = "L2OutputOracle"
+	err = firstErr(
+		check(v, name, "SUBMISSION_INTERVAL", u64(v.config.L2OutputOracleSubmissionInterval), l2oo.SUBMISSIONINTERVAL),
+		check(v, name, "L2_BLOCK_TIME", u64(v.config.L2BlockTime), l2oo.L2BLOCKTIME),
+		check(v, name, "FINALIZATION_PERIOD_SECONDS", u64(v.config.FinalizationPeriodSeconds), l2oo.FINALIZATIONPERIODSECONDS),
+		check(v, name, "VALIDATOR_POOL", v.deployments.ValidatorPoolProxy, l2oo.VALIDATORPOOL),
+		check(v, name, "COLOSSEUM", v.deployments.ColosseumProxy, l2oo.COLOSSEUM),
+	)
+	if err != nil {
+		return err
+	}
+	// the starting timestamp is taken from the L1 starting block if it is not configured
+	if v.config.L2OutputOracleStartingTimestamp > 0 {
+		return check(v, name, "startingTimestamp", big.NewInt(int64(v.config.L2OutputOracleStartingTimestamp)), l2oo.StartingTimestamp)
+	}
+	return nil
+}
+
+func (v *Verifier) checkValidatorPool() error {
+	pool, err := bindings.NewValidatorPoolCaller(v.deployments.ValidatorPoolProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	const name = "ValidatorPool"
+	return firstErr(
+		check(v, name, "REQUIRED_BOND_AMOUNT", v.config.ValidatorPoolRequiredBondAmount.ToInt(), pool.REQUIREDBONDAMOUNT),
+		check(v, name, "MAX_UNBOND", u64(v.config.ValidatorPoolMaxUnbond), pool.MAXUNBOND),
+		check(v, name, "ROUND_DURATION", u64(v.config.ValidatorPoolRoundDuration), pool.ROUNDDURATION),
+		check(v, name, "TRUSTED_VALIDATOR", v.config.ValidatorPoolTrustedValidator, pool.TRUSTEDVALIDATOR),
+		check(v, name, "L2_ORACLE", v.deployments.L2OutputOracleProxy, pool.L2ORACLE),
+		check(v, name, "PORTAL", v.deployments.KromaPortalProxy, pool.PORTAL),
+		check(v, name, "SECURITY_COUNCIL", v.deployments.SecurityCouncilProxy, pool.SECURITYCOUNCIL),
+	)
+}
+
+func (v *Verifier) checkColosseum() error {
+	colosseum, err := bindings.NewColosseumCaller(v.deployments.ColosseumProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	const name = "Colosseum"
+	err = firstErr(
+		check(v, name, "L2_ORACLE_SUBMISSION_INTERVAL", u64(v.config.L2OutputOracleSubmissionInterval), colosseum.L2ORACLESUBMISSIONINTERVAL),
+		check(v, name, "CREATION_PERIOD_SECONDS", u64(v.config.ColosseumCreationPeriodSeconds), colosseum.CREATIONPERIODSECONDS),
+		check(v, name, "BISECTION_TIMEOUT", u64(v.config.ColosseumBisectionTimeout), colosseum.BISECTIONTIMEOUT),
+		check(v, name, "PROVING_TIMEOUT", u64(v.config.ColosseumProvingTimeout), colosseum.PROVINGTIMEOUT),
+		check(v, name, "DUMMY_HASH", [32]byte(v.config.ColosseumDummyHash), colosseum.DUMMYHASH),
+		check(v, name, "MAX_TXS", u64(v.config.ColosseumMaxTxs), colosseum.MAXTXS),
+		check(v, name, "L2_ORACLE", v.deployments.L2OutputOracleProxy, colosseum.L2ORACLE),
+		check(v, name, "ZK_VERIFIER", v.deployments.ZKVerifierProxy, colosseum.ZKVERIFIER),
+		check(v, name, "ZK_MERKLE_TRIE", v.deployments.ZKMerkleTrie, colosseum.ZKMERKLETRIE),
+		check(v, name, "SECURITY_COUNCIL", v.deployments.SecurityCouncilProxy, colosseum.SECURITYCOUNCIL),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the segments lengths are stored by turn, starting from turn 1, and the turn after the last one is unset
+	lengths := genesis.ParseSegsLengthsConfig(v.config.ColosseumSegmentsLengths)
+	lengths = append(lengths, new(big.Int))
+	for i, length := range lengths {
+		turn := uint8(i + 1)
+		read := func(opts *bind.CallOpts) (*big.Int, error) {
+			return colosseum.GetSegmentsLength(opts, turn)
+		}
+		if err := check(v, name, fmt.Sprintf("segmentsLength(%d)", turn), length, read); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) checkKromaPortal() error {
+	portal, err := bindings.NewKromaPortalCaller(v.deployments.KromaPortalProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	const name = "KromaPortal"
+	return firstErr(
+		check(v, name, "L2_ORACLE", v.deployments.L2OutputOracleProxy, portal.L2ORACLE),
+		check(v, name, "VALIDATOR_POOL", v.deployments.ValidatorPoolProxy, portal.VALIDATORPOOL),
+		check(v, name, "GUARDIAN", v.deployments.SecurityCouncilProxy, portal.GUARDIAN),
+		check(v, name, "SYSTEM_CONFIG", v.deployments.SystemConfigProxy, portal.SYSTEMCONFIG),
+		check(v, name, "ZK_MERKLE_TRIE", v.deployments.ZKMerkleTrie, portal.ZKMERKLETRIE),
+	)
+}
+
+func (v *Verifier) checkSecurityCouncil() error {
+	council, err := bindings.NewSecurityCouncilCaller(v.deployments.SecurityCouncilProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	const name = "SecurityCouncil"
+	err = firstErr(
+		check(v, name, "COLOSSEUM", v.deployments.ColosseumProxy, council.COLOSSEUM),
+		check(v, name, "GOVERNOR", v.deployments.UpgradeGovernorProxy, council.GOVERNOR),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the owners of the council are the holders of the council token
+	token, err := bindings.NewSecurityCouncilTokenCaller(v.deployments.SecurityCouncilTokenProxy, v.caller)
+	if err != nil {
+		return err
+	}
+	for _, owner := range v.config.SecurityCouncilOwners {
+		balance, err := token.BalanceOf(v.opts, owner)
+		if err != nil {
+			return fmt.Errorf("failed to read token balance of %s: %w", owner, err)
+		}
+		if balance.Sign() == 0 {
+			v.report(name, fmt.Sprintf("owner(%s)", owner), "a council token", "none")
+		}
+	}
+	return check(v, "SecurityCouncilToken", "totalSupply", u64(uint64(len(v.config.SecurityCouncilOwners))), token.TotalSupply)
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
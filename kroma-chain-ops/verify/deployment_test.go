@@ -0,0 +1,216 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+type method func(args []any) []any
+
+// fakeContracts answers calls from the methods set by contract address and method name.
+type fakeContracts struct {
+	abis    map[common.Address]*abi.ABI
+	methods map[common.Address]map[string]method
+}
+
+var _ bind.ContractCaller = (*fakeContracts)(nil)
+
+func (f *fakeContracts) set(addr common.Address, md *bind.MetaData, name string, fn method) {
+	parsed, err := md.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	f.abis[addr] = parsed
+	if f.methods[addr] == nil {
+		f.methods[addr] = make(map[string]method)
+	}
+	f.methods[addr][name] = fn
+}
+
+func (f *fakeContracts) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeContracts) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	contract, ok := f.abis[*call.To]
+	if !ok {
+		return nil, fmt.Errorf("no contract at %s", call.To)
+	}
+	m, err := contract.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := f.methods[*call.To][m.Name]
+	if !ok {
+		return nil, fmt.Errorf("unexpected call of %s at %s", m.Name, call.To)
+	}
+	args, err := m.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return m.Outputs.Pack(fn(args)...)
+}
+
+func returns(values ...any) method {
+	return func([]any) []any { return values }
+}
+
+// testDeployer is the deployer of the L1 contracts, which owns the L1 ProxyAdmin.
+var testDeployer = common.HexToAddress("0xde91")
+
+// deployedContracts returns the contracts as deployed from the config.
+func deployedContracts(cfg *genesis.DeployConfig, d *genesis.L1Deployments) *fakeContracts {
+	f := &fakeContracts{abis: make(map[common.Address]*abi.ABI), methods: make(map[common.Address]map[string]method)}
+	u64 := func(v uint64) *big.Int { return new(big.Int).SetUint64(v) }
+
+	implementations := map[common.Address]common.Address{}
+	for _, p := range (&Verifier{deployments: d}).proxies() {
+		implementations[p.address] = p.implementation
+	}
+	f.set(d.ProxyAdmin, bindings.ProxyAdminMetaData, "owner", returns(testDeployer))
+	f.set(d.ProxyAdmin, bindings.ProxyAdminMetaData, "getProxyImplementation", func(args []any) []any {
+		return []any{implementations[args[0].(common.Address)]}
+	})
+	f.set(d.ProxyAdmin, bindings.ProxyAdminMetaData, "getProxyAdmin", returns(d.ProxyAdmin))
+
+	sysCfg := bindings.SystemConfigMetaData
+	f.set(d.SystemConfigProxy, sysCfg, "owner", returns(d.TimeLockProxy))
+	f.set(d.SystemConfigProxy, sysCfg, "overhead", returns(u64(cfg.GasPriceOracleOverhead)))
+	f.set(d.SystemConfigProxy, sysCfg, "scalar", returns(u64(cfg.GasPriceOracleScalar)))
+	f.set(d.SystemConfigProxy, sysCfg, "batcherHash", returns([32]byte(common.BytesToHash(cfg.BatchSenderAddress.Bytes()))))
+	f.set(d.SystemConfigProxy, sysCfg, "gasLimit", returns(uint64(cfg.L2GenesisBlockGasLimit)))
+	f.set(d.SystemConfigProxy, sysCfg, "unsafeBlockSigner", returns(cfg.P2PSequencerAddress))
+	f.set(d.SystemConfigProxy, sysCfg, "validatorRewardScalar", returns(u64(cfg.ValidatorRewardScalar)))
+
+	l2oo := bindings.L2OutputOracleMetaData
+	f.set(d.L2OutputOracleProxy, l2oo, "SUBMISSION_INTERVAL", returns(u64(cfg.L2OutputOracleSubmissionInterval)))
+	f.set(d.L2OutputOracleProxy, l2oo, "L2_BLOCK_TIME", returns(u64(cfg.L2BlockTime)))
+	f.set(d.L2OutputOracleProxy, l2oo, "FINALIZATION_PERIOD_SECONDS", returns(u64(cfg.FinalizationPeriodSeconds)))
+	f.set(d.L2OutputOracleProxy, l2oo, "VALIDATOR_POOL", returns(d.ValidatorPoolProxy))
+	f.set(d.L2OutputOracleProxy, l2oo, "COLOSSEUM", returns(d.ColosseumProxy))
+
+	pool := bindings.ValidatorPoolMetaData
+	f.set(d.ValidatorPoolProxy, pool, "REQUIRED_BOND_AMOUNT", returns(cfg.ValidatorPoolRequiredBondAmount.ToInt()))
+	f.set(d.ValidatorPoolProxy, pool, "MAX_UNBOND", returns(u64(cfg.ValidatorPoolMaxUnbond)))
+	f.set(d.ValidatorPoolProxy, pool, "ROUND_DURATION", returns(u64(cfg.ValidatorPoolRoundDuration)))
+	f.set(d.ValidatorPoolProxy, pool, "TRUSTED_VALIDATOR", returns(cfg.ValidatorPoolTrustedValidator))
+	f.set(d.ValidatorPoolProxy, pool, "L2_ORACLE", returns(d.L2OutputOracleProxy))
+	f.set(d.ValidatorPoolProxy, pool, "PORTAL", returns(d.KromaPortalProxy))
+	f.set(d.ValidatorPoolProxy, pool, "SECURITY_COUNCIL", returns(d.SecurityCouncilProxy))
+
+	colosseum := bindings.ColosseumMetaData
+	f.set(d.ColosseumProxy, colosseum, "L2_ORACLE_SUBMISSION_INTERVAL", returns(u64(cfg.L2OutputOracleSubmissionInterval)))
+	f.set(d.ColosseumProxy, colosseum, "CREATION_PERIOD_SECONDS", returns(u64(cfg.ColosseumCreationPeriodSeconds)))
+	f.set(d.ColosseumProxy, colosseum, "BISECTION_TIMEOUT", returns(u64(cfg.ColosseumBisectionTimeout)))
+	f.set(d.ColosseumProxy, colosseum, "PROVING_TIMEOUT", returns(u64(cfg.ColosseumProvingTimeout)))
+	f.set(d.ColosseumProxy, colosseum, "DUMMY_HASH", returns([32]byte(cfg.ColosseumDummyHash)))
+	f.set(d.ColosseumProxy, colosseum, "MAX_TXS", returns(u64(cfg.ColosseumMaxTxs)))
+	f.set(d.ColosseumProxy, colosseum, "L2_ORACLE", returns(d.L2OutputOracleProxy))
+	f.set(d.ColosseumProxy, colosseum, "ZK_VERIFIER", returns(d.ZKVerifierProxy))
+	f.set(d.ColosseumProxy, colosseum, "ZK_MERKLE_TRIE", returns(d.ZKMerkleTrie))
+	f.set(d.ColosseumProxy, colosseum, "SECURITY_COUNCIL", returns(d.SecurityCouncilProxy))
+	segments := genesis.ParseSegsLengthsConfig(cfg.ColosseumSegmentsLengths)
+	f.set(d.ColosseumProxy, colosseum, "getSegmentsLength", func(args []any) []any {
+		turn := int(args[0].(uint8))
+		if turn > len(segments) {
+			return []any{new(big.Int)}
+		}
+		return []any{segments[turn-1]}
+	})
+
+	portal := bindings.KromaPortalMetaData
+	f.set(d.KromaPortalProxy, portal, "L2_ORACLE", returns(d.L2OutputOracleProxy))
+	f.set(d.KromaPortalProxy, portal, "VALIDATOR_POOL", returns(d.ValidatorPoolProxy))
+	f.set(d.KromaPortalProxy, portal, "GUARDIAN", returns(d.SecurityCouncilProxy))
+	f.set(d.KromaPortalProxy, portal, "SYSTEM_CONFIG", returns(d.SystemConfigProxy))
+	f.set(d.KromaPortalProxy, portal, "ZK_MERKLE_TRIE", returns(d.ZKMerkleTrie))
+
+	f.set(d.SecurityCouncilProxy, bindings.SecurityCouncilMetaData, "COLOSSEUM", returns(d.ColosseumProxy))
+	f.set(d.SecurityCouncilProxy, bindings.SecurityCouncilMetaData, "GOVERNOR", returns(d.UpgradeGovernorProxy))
+	owners := make(map[common.Address]bool)
+	for _, owner := range cfg.SecurityCouncilOwners {
+		owners[owner] = true
+	}
+	token := bindings.SecurityCouncilTokenMetaData
+	f.set(d.SecurityCouncilTokenProxy, token, "balanceOf", func(args []any) []any {
+		if owners[args[0].(common.Address)] {
+			return []any{big.NewInt(1)}
+		}
+		return []any{new(big.Int)}
+	})
+	f.set(d.SecurityCouncilTokenProxy, token, "totalSupply", returns(big.NewInt(int64(len(owners)))))
+	return f
+}
+
+func loadTestConfig(t *testing.T) (*genesis.DeployConfig, *genesis.L1Deployments) {
+	cfg, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	deployments, err := genesis.NewL1Deployments("../genesis/testdata/l1-deployments.json")
+	require.NoError(t, err)
+	return cfg, deployments
+}
+
+func TestVerifyMatchingDeployment(t *testing.T) {
+	cfg, deployments := loadTestConfig(t)
+	contracts := deployedContracts(cfg, deployments)
+
+	differences, err := NewVerifier(contracts, cfg, deployments, testDeployer).Verify(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, differences)
+}
+
+func TestVerifyReportsDifferences(t *testing.T) {
+	cfg, deployments := loadTestConfig(t)
+	contracts := deployedContracts(cfg, deployments)
+
+	contracts.set(deployments.ProxyAdmin, bindings.ProxyAdminMetaData, "getProxyImplementation", returns(common.Address{0x1}))
+	contracts.set(deployments.L2OutputOracleProxy, bindings.L2OutputOracleMetaData, "FINALIZATION_PERIOD_SECONDS", returns(big.NewInt(1)))
+	contracts.set(deployments.ColosseumProxy, bindings.ColosseumMetaData, "getSegmentsLength", returns(big.NewInt(2)))
+	contracts.set(deployments.SecurityCouncilTokenProxy, bindings.SecurityCouncilTokenMetaData, "balanceOf", returns(new(big.Int)))
+	// the owner of the L2 ProxyAdmin predeploy does not own the L1 ProxyAdmin
+	contracts.set(deployments.ProxyAdmin, bindings.ProxyAdminMetaData, "owner", returns(cfg.ProxyAdminOwner))
+
+	differences, err := NewVerifier(contracts, cfg, deployments, testDeployer).Verify(context.Background())
+	require.NoError(t, err)
+
+	byField := make(map[string]Difference)
+	for _, d := range differences {
+		byField[d.Contract+"."+d.Field] = d
+	}
+	require.Equal(t, testDeployer.Hex(), byField["ProxyAdmin.owner"].Expected)
+	require.Contains(t, byField, "KromaPortalProxy.implementation")
+	require.Equal(t, deployments.KromaPortal.Hex(), byField["KromaPortalProxy.implementation"].Expected)
+	require.Equal(t, Difference{
+		Contract: "L2OutputOracle",
+		Field:    "FINALIZATION_PERIOD_SECONDS",
+		Expected: fmt.Sprint(cfg.FinalizationPeriodSeconds),
+		Actual:   "1",
+	}, byField["L2OutputOracle.FINALIZATION_PERIOD_SECONDS"])
+	// the config is "2,2,3,3", so the 3rd and 4th turns and the unset 5th turn differ
+	require.NotContains(t, byField, "Colosseum.segmentsLength(1)")
+	require.Contains(t, byField, "Colosseum.segmentsLength(3)")
+	require.Contains(t, byField, "Colosseum.segmentsLength(5)")
+	for _, owner := range cfg.SecurityCouncilOwners {
+		require.Contains(t, byField, fmt.Sprintf("SecurityCouncil.owner(%s)", owner))
+	}
+}
+
+func TestVerifyFailsOnCallError(t *testing.T) {
+	cfg, deployments := loadTestConfig(t)
+	contracts := deployedContracts(cfg, deployments)
+	delete(contracts.methods[deployments.ColosseumProxy], "MAX_TXS")
+
+	_, err := NewVerifier(contracts, cfg, deployments, testDeployer).Verify(context.Background())
+	require.ErrorContains(t, err, "failed to check Colosseum")
+}
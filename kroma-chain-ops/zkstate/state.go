@@ -0,0 +1,242 @@
+package zkstate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	zkt "github.com/kroma-network/zktrie/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// State is an in-memory zkTrie state, that is built the same way kroma-geth builds its state,
+// so the state root and the proofs match the ones of a kroma-geth node with the same state.
+type State struct {
+	db       *trie.Database
+	accounts *trie.ZkTrie
+	storages map[common.Address]*trie.ZkTrie
+	data     map[common.Address]*types.StateAccount
+}
+
+// New creates an empty state.
+func New() (*State, error) {
+	db := trie.NewZkDatabase(rawdb.NewMemoryDatabase())
+	accounts, err := trie.NewZkTrie(types.GetEmptyRootHash(true), db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account trie: %w", err)
+	}
+	return &State{
+		db:       db,
+		accounts: accounts,
+		storages: make(map[common.Address]*trie.ZkTrie),
+		data:     make(map[common.Address]*types.StateAccount),
+	}, nil
+}
+
+// FromAlloc builds the state of the genesis allocs.
+func FromAlloc(alloc core.GenesisAlloc) (*State, error) {
+	s, err := New()
+	if err != nil {
+		return nil, err
+	}
+	// insert in a fixed order, the root does not depend on it but the errors do
+	addrs := make([]common.Address, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Cmp(addrs[j]) < 0 })
+	for _, addr := range addrs {
+		if err := s.SetAccount(addr, alloc[addr]); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// FromDump builds the state of a state dump, e.g. the allocs dumped by the contracts deployment.
+func FromDump(dump *gstate.Dump) (*State, error) {
+	alloc, err := DumpToAlloc(dump)
+	if err != nil {
+		return nil, err
+	}
+	return FromAlloc(alloc)
+}
+
+// DumpToAlloc converts a state dump to genesis allocs.
+func DumpToAlloc(dump *gstate.Dump) (core.GenesisAlloc, error) {
+	alloc := make(core.GenesisAlloc, len(dump.Accounts))
+	for key, account := range dump.Accounts {
+		if !common.IsHexAddress(key) {
+			return nil, fmt.Errorf("account %s of the dump has no address", key)
+		}
+		balance := new(big.Int)
+		if account.Balance != "" {
+			var ok bool
+			if balance, ok = new(big.Int).SetString(account.Balance, 0); !ok {
+				return nil, fmt.Errorf("invalid balance %q of %s", account.Balance, key)
+			}
+		}
+		storage := make(map[common.Hash]common.Hash, len(account.Storage))
+		for slot, value := range account.Storage {
+			if !strings.HasPrefix(value, "0x") {
+				value = "0x" + value
+			}
+			v, err := hexutil.Decode(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid storage value %q of %s at %s: %w", value, key, slot, err)
+			}
+			storage[slot] = common.BytesToHash(v)
+		}
+		alloc[common.HexToAddress(key)] = core.GenesisAccount{
+			Code:    account.Code,
+			Storage: storage,
+			Balance: balance,
+			Nonce:   account.Nonce,
+		}
+	}
+	return alloc, nil
+}
+
+// SetAccount sets the account and its storage, replacing the account if it is already set.
+func (s *State) SetAccount(addr common.Address, account core.GenesisAccount) error {
+	storage, err := trie.NewZkTrie(types.GetEmptyRootHash(true), s.db)
+	if err != nil {
+		return fmt.Errorf("failed to create storage trie of %s: %w", addr, err)
+	}
+	for slot, value := range account.Storage {
+		// zero values are not stored, as in the state of kroma-geth
+		if value == (common.Hash{}) {
+			continue
+		}
+		if err := storage.UpdateStorage(addr, slot.Bytes(), common.TrimLeftZeroes(value[:])); err != nil {
+			return fmt.Errorf("failed to set storage of %s at %s: %w", addr, slot, err)
+		}
+	}
+
+	data := types.NewEmptyStateAccount(true)
+	data.Nonce = account.Nonce
+	if account.Balance != nil {
+		data.Balance = new(big.Int).Set(account.Balance)
+	}
+	data.CodeHash = crypto.Keccak256(account.Code)
+	data.Root = storage.Hash()
+	// the balance must be a field element to be marshaled into the trie
+	if _, err := data.Encode(true); err != nil {
+		return fmt.Errorf("invalid account %s: %w", addr, err)
+	}
+	if err := s.accounts.UpdateAccount(addr, data); err != nil {
+		return fmt.Errorf("failed to set account %s: %w", addr, err)
+	}
+	s.storages[addr] = storage
+	s.data[addr] = data
+	return nil
+}
+
+// Root is the state root.
+func (s *State) Root() common.Hash {
+	return s.accounts.Hash()
+}
+
+// Account returns the account, nil if it is not in the state.
+func (s *State) Account(addr common.Address) *types.StateAccount {
+	if data, ok := s.data[addr]; ok {
+		return data.Copy()
+	}
+	return nil
+}
+
+// StorageRoot is the root of the storage trie of the account, e.g. the MessagePasserStorageRoot of an output.
+func (s *State) StorageRoot(addr common.Address) common.Hash {
+	if data, ok := s.data[addr]; ok {
+		return data.Root
+	}
+	return types.GetEmptyRootHash(true)
+}
+
+// Storage returns the value stored in the slot of the account.
+func (s *State) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	storage, ok := s.storages[addr]
+	if !ok {
+		return common.Hash{}, nil
+	}
+	value, err := storage.GetStorage(addr, slot.Bytes())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get storage of %s at %s: %w", addr, slot, err)
+	}
+	return common.BytesToHash(value), nil
+}
+
+// proofList collects the nodes of a zkTrie proof, in the order of the eth_getProof response.
+type proofList []hexutil.Bytes
+
+func (l *proofList) Put(_ []byte, value []byte) error {
+	*l = append(*l, common.CopyBytes(value))
+	return nil
+}
+
+func (l *proofList) Delete([]byte) error {
+	return errors.New("not supported")
+}
+
+func prove(t *trie.ZkTrie, key []byte) ([]hexutil.Bytes, error) {
+	secureKey, err := zkt.ToSecureKeyBytes(key)
+	if err != nil {
+		return nil, err
+	}
+	var proof proofList
+	if err := t.Prove(secureKey.Bytes(), &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// Proof builds the account proof and the storage proofs of the slots, as returned by eth_getProof of kroma-geth.
+// The proof of an account that is not in the state proves its absence.
+func (s *State) Proof(addr common.Address, slots ...common.Hash) (*eth.AccountResult, error) {
+	accountProof, err := prove(s.accounts, addr.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove account %s: %w", addr, err)
+	}
+	data := s.data[addr]
+	if data == nil {
+		data = types.NewEmptyStateAccount(true)
+	}
+	res := &eth.AccountResult{
+		AccountProof: accountProof,
+		Address:      addr,
+		Balance:      (*hexutil.Big)(new(big.Int).Set(data.Balance)),
+		CodeHash:     common.BytesToHash(data.CodeHash),
+		Nonce:        hexutil.Uint64(data.Nonce),
+		StorageHash:  data.Root,
+	}
+	for _, slot := range slots {
+		value, err := s.Storage(addr, slot)
+		if err != nil {
+			return nil, err
+		}
+		storage, ok := s.storages[addr]
+		if !ok {
+			// prove the absence of the slot in the empty storage of the absent account
+			if storage, err = trie.NewZkTrie(types.GetEmptyRootHash(true), s.db); err != nil {
+				return nil, err
+			}
+		}
+		proof, err := prove(storage, slot.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove storage of %s at %s: %w", addr, slot, err)
+		}
+		res.StorageProof = append(res.StorageProof, eth.StorageProofEntry{Key: slot, Value: hexutil.Big(*value.Big()), Proof: proof})
+	}
+	return res, nil
+}
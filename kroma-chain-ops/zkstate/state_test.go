@@ -0,0 +1,129 @@
+package zkstate
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/kroma-chain-ops/genesis"
+)
+
+var (
+	eoa      = common.HexToAddress("0x1111")
+	contract = common.HexToAddress("0x2222")
+	slot1    = common.HexToHash("0x01")
+	slot2    = common.HexToHash("0xabcdef")
+)
+
+func testAlloc() core.GenesisAlloc {
+	return core.GenesisAlloc{
+		eoa: {Balance: big.NewInt(1e18), Nonce: 3},
+		contract: {
+			Code:    []byte{0x60, 0x00, 0x60, 0x00, 0xf3},
+			Balance: big.NewInt(5),
+			Storage: map[common.Hash]common.Hash{
+				slot1:                    common.HexToHash("0x1234"),
+				slot2:                    common.HexToHash("0xff00000000000000000000000000000000000000000000000000000000000001"),
+				common.HexToHash("0x03"): {},
+			},
+		},
+	}
+}
+
+// zkGenesisRoot is the state root computed by the genesis of kroma-geth.
+func zkGenesisRoot(alloc core.GenesisAlloc) common.Hash {
+	cfg := *params.TestChainConfig
+	cfg.Zktrie = true
+	g := &core.Genesis{Config: &cfg, Alloc: alloc, BaseFee: big.NewInt(params.InitialBaseFee)}
+	return g.ToBlock().Root()
+}
+
+func TestRootMatchesGenesis(t *testing.T) {
+	alloc := testAlloc()
+	s, err := FromAlloc(alloc)
+	require.NoError(t, err)
+	require.Equal(t, zkGenesisRoot(alloc), s.Root())
+
+	empty, err := New()
+	require.NoError(t, err)
+	require.Equal(t, zkGenesisRoot(core.GenesisAlloc{}), empty.Root())
+}
+
+func TestRootOfL2Genesis(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	l1Block := types.NewBlock(&types.Header{Number: common.Big0, Time: 1700000000, BaseFee: common.Big1}, nil, nil, nil, trie.NewStackTrie(nil))
+	gen, err := genesis.BuildL2Genesis(config, l1Block)
+	require.NoError(t, err)
+	require.True(t, gen.Config.Zktrie)
+
+	s, err := FromAlloc(gen.Alloc)
+	require.NoError(t, err)
+	require.Equal(t, gen.ToBlock().Root(), s.Root())
+}
+
+func TestFromDump(t *testing.T) {
+	var dump state.Dump
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"root": "",
+		"accounts": {
+			"0x0000000000000000000000000000000000001111": {"balance": "1000000000000000000", "nonce": 3},
+			"0x0000000000000000000000000000000000002222": {
+				"balance": "5",
+				"nonce": 0,
+				"code": "0x60006000f3",
+				"storage": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": "0x1234",
+					"0x0000000000000000000000000000000000000000000000000000000000abcdef": "ff00000000000000000000000000000000000000000000000000000000000001",
+					"0x0000000000000000000000000000000000000000000000000000000000000003": "0x00"
+				}
+			}
+		}
+	}`), &dump))
+	s, err := FromDump(&dump)
+	require.NoError(t, err)
+	require.Equal(t, zkGenesisRoot(testAlloc()), s.Root())
+
+	// balances out of the scalar field cannot be in the state
+	dump.Accounts["0x0000000000000000000000000000000000001111"] = state.DumpAccount{Balance: new(big.Int).Lsh(common.Big1, 255).String()}
+	_, err = FromDump(&dump)
+	require.ErrorContains(t, err, "balance overflow")
+}
+
+func TestProof(t *testing.T) {
+	s, err := FromAlloc(testAlloc())
+	require.NoError(t, err)
+	root := s.Root()
+
+	res, err := s.Proof(eoa)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), uint64(res.Nonce))
+	require.NoError(t, res.Verify(root))
+
+	res, err = s.Proof(contract, slot1, slot2, common.HexToHash("0x03"))
+	require.NoError(t, err)
+	require.Equal(t, s.StorageRoot(contract), res.StorageHash)
+	require.Equal(t, big.NewInt(0x1234), res.StorageProof[0].Value.ToInt())
+	require.Zero(t, res.StorageProof[2].Value.ToInt().Sign())
+	require.NoError(t, res.Verify(root))
+
+	// a proof does not verify against another state
+	other := testAlloc()
+	other[eoa] = core.GenesisAccount{Balance: big.NewInt(1)}
+	s2, err := FromAlloc(other)
+	require.NoError(t, err)
+	require.Error(t, res.Verify(s2.Root()))
+
+	// a tampered value does not verify
+	res.StorageProof[1].Value = hexutil.Big(*big.NewInt(2))
+	require.Error(t, res.Verify(root))
+}
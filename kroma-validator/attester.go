@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/watcher"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	"github.com/kroma-network/kroma/kroma-validator/metrics"
+)
+
+// OutputAttester publishes the output root the validator computed for each submission interval,
+// signed with its ValidatorPool key, to the output attestations gossip topic of the rollup node.
+// That way differences between validators show up before an output is submitted to L1.
+type OutputAttester struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	cfg  Config
+	log  log.Logger
+	metr metrics.Metricer
+
+	l2ooContract *bindings.L2OutputOracleCaller
+
+	submissionInterval *big.Int
+	// lastAttested is the L2 block number of the last published attestation, 0 if none.
+	lastAttested uint64
+
+	wg sync.WaitGroup
+}
+
+// NewOutputAttester creates a new OutputAttester.
+func NewOutputAttester(cfg Config, l log.Logger, m metrics.Metricer) (*OutputAttester, error) {
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutputAttester{
+		cfg:          cfg,
+		log:          l.New("service", "attester"),
+		metr:         m,
+		l2ooContract: l2ooContract,
+	}, nil
+}
+
+func (a *OutputAttester) InitConfig(ctx context.Context) error {
+	contractWatcher := watcher.NewContractWatcher(ctx, a.cfg.L1Client, a.log)
+
+	err := contractWatcher.WatchUpgraded(a.cfg.L2OutputOracleAddr, func() error {
+		cCtx, cCancel := context.WithTimeout(ctx, a.cfg.NetworkTimeout)
+		defer cCancel()
+		submissionInterval, err := a.l2ooContract.SUBMISSIONINTERVAL(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get submission interval: %w", err)
+		}
+		a.submissionInterval = submissionInterval
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initiate l2oo config: %w", err)
+	}
+
+	return nil
+}
+
+func (a *OutputAttester) Start(ctx context.Context) error {
+	a.ctx, a.cancel = context.WithCancel(ctx)
+
+	if err := a.InitConfig(a.ctx); err != nil {
+		return err
+	}
+
+	a.wg.Add(1)
+	go a.loop()
+
+	return nil
+}
+
+func (a *OutputAttester) Stop() error {
+	a.cancel()
+	a.wg.Wait()
+
+	return nil
+}
+
+func (a *OutputAttester) loop() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.cfg.AttestationPollInterval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		select {
+		case <-a.ctx.Done():
+			return
+		default:
+			if err := a.attestOutputs(a.ctx); err != nil {
+				a.log.Error("failed to attest outputs", "err", err)
+			}
+		}
+	}
+}
+
+// attestOutputs attests to every output from the next one to be submitted up to the current L2 head.
+func (a *OutputAttester) attestOutputs(ctx context.Context) error {
+	currentBlockNumber, err := a.fetchCurrentBlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, a.cfg.NetworkTimeout)
+	defer cCancel()
+	nextBlockNumber, err := a.l2ooContract.NextBlockNumber(optsutils.NewSimpleCallOpts(cCtx))
+	if err != nil {
+		return fmt.Errorf("unable to get next block number: %w", err)
+	}
+
+	interval := a.submissionInterval.Uint64()
+	target := nextBlockNumber.Uint64()
+	if a.lastAttested >= target {
+		target = a.lastAttested + interval
+	}
+
+	for ; target <= currentBlockNumber; target += interval {
+		if err := a.attestOutput(ctx, target); err != nil {
+			return err
+		}
+		a.lastAttested = target
+	}
+
+	return nil
+}
+
+func (a *OutputAttester) attestOutput(ctx context.Context, blockNumber uint64) error {
+	cCtx, cCancel := context.WithTimeout(ctx, a.cfg.NetworkTimeout)
+	defer cCancel()
+	output, err := a.cfg.RollupClient.OutputAtBlock(cCtx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output at block %d: %w", blockNumber, err)
+	}
+	if output.Version != eth.OutputVersionV0 {
+		return fmt.Errorf("mismatched l2 output version: %s", output.Version)
+	}
+	if output.BlockRef.Number != blockNumber { // sanity check, e.g. in case of bad RPC caching
+		return fmt.Errorf("invalid block number: expected %d, got %d", blockNumber, output.BlockRef.Number)
+	}
+
+	att, err := p2p.SignOutputAttestation(ctx, a.cfg.RollupConfig.L2ChainID, a.cfg.AttestationSigner, p2p.OutputAttestation{
+		L2BlockNumber: blockNumber,
+		OutputRoot:    output.OutputRoot,
+	})
+	if err != nil {
+		return err
+	}
+
+	cCtx, cCancel = context.WithTimeout(ctx, a.cfg.NetworkTimeout)
+	defer cCancel()
+	if err := a.cfg.P2PClient.PublishOutputAttestation(cCtx, att); err != nil {
+		return fmt.Errorf("failed to publish output attestation: %w", err)
+	}
+
+	a.log.Info("output attestation published", "blockNumber", blockNumber, "outputRoot", output.OutputRoot, "validator", att.Validator)
+	a.metr.RecordL2OutputAttested(output.BlockRef)
+	return nil
+}
+
+func (a *OutputAttester) fetchCurrentBlockNumber(ctx context.Context) (uint64, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, a.cfg.NetworkTimeout)
+	defer cCancel()
+	status, err := a.cfg.RollupClient.SyncStatus(cCtx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get sync status: %w", err)
+	}
+
+	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
+	if a.cfg.AllowNonFinalized {
+		return status.SafeL2.Number, nil
+	}
+	return status.FinalizedL2.Number, nil
+}
@@ -0,0 +1,34 @@
+package challenge
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+// HashPublicInput computes the public input hash that the Colosseum verifies the zk proof of a fault with.
+// It is the same as Hashing.hashPublicInput of the contracts, with the tx hashes padded with the dummy hash up to maxTxs.
+func HashPublicInput(prevStateRoot common.Hash, publicInput bindings.TypesPublicInput, dummyHash common.Hash, maxTxs uint64) common.Hash {
+	var buf []byte
+	buf = append(buf, prevStateRoot[:]...)
+	buf = append(buf, publicInput.StateRoot[:]...)
+	// the withdrawalsRoot is not used in the zkEVM circuit, so it is filled by zero
+	buf = append(buf, make([]byte, 32)...)
+	buf = append(buf, publicInput.BlockHash[:]...)
+	buf = append(buf, publicInput.ParentHash[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, publicInput.Number)
+	buf = binary.BigEndian.AppendUint64(buf, publicInput.Timestamp)
+	buf = append(buf, common.BigToHash(publicInput.BaseFee).Bytes()...)
+	buf = binary.BigEndian.AppendUint64(buf, publicInput.GasLimit)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(publicInput.TxHashes)))
+	for _, txHash := range publicInput.TxHashes {
+		buf = append(buf, txHash[:]...)
+	}
+	for i := uint64(len(publicInput.TxHashes)); i < maxTxs; i++ {
+		buf = append(buf, dummyHash[:]...)
+	}
+	return crypto.Keccak256Hash(buf)
+}
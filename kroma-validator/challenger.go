@@ -10,17 +10,19 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/watcher"
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
@@ -29,6 +31,11 @@ import (
 
 var deletedOutputRoot = [32]byte{}
 
+var tracer = tracing.Tracer("kroma-validator")
+
+// proofAttempts is the number of proofs fetched for a fault before giving up, if none of them is verified.
+const proofAttempts = 3
+
 type ProofFetcher interface {
 	FetchProofAndPair(ctx context.Context, trace string) (*chal.ProofAndPair, error)
 }
@@ -48,18 +55,25 @@ type Challenger struct {
 	colosseumContract *bindings.Colosseum
 	colosseumABI      *abi.ABI
 	valpoolContract   *bindings.ValidatorPoolCaller
+	contracts         *ValidatorContracts
+	poller            *StatePoller
 
 	submissionInterval        *big.Int
 	finalizationPeriodSeconds *big.Int
 	l2BlockTime               *big.Int
 	checkpoint                *big.Int
 	requiredBondAmount        *big.Int
+	zkVerifierAddr            common.Address
+	dummyHash                 common.Hash
+	maxTxs                    uint64
 
-	l2OutputSubmittedSub ethereum.Subscription
-	challengeCreatedSub  ethereum.Subscription
+	indexer *LogIndexer
 
-	l2OutputSubmittedEventChan chan *bindings.L2OutputOracleOutputSubmitted
-	challengeCreatedEventChan  chan *bindings.ColosseumChallengeCreated
+	// handlersLock guards the outputs and challenges that are being handled,
+	// so an event that is indexed again after an L1 reorg does not start a second handler.
+	handlersLock      sync.Mutex
+	outputHandlers    map[uint64]struct{}
+	challengeHandlers map[ChallengeKey]struct{}
 
 	wg sync.WaitGroup
 }
@@ -90,8 +104,22 @@ func NewChallenger(cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, e
 		return nil, err
 	}
 
+	contracts, err := NewValidatorContracts(cfg.L1Client.Client(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// OutputSubmitted events are indexed only when challenger mode is on
+	addresses := []common.Address{cfg.ColosseumAddr}
+	topics := []common.Hash{colosseumABI.Events[KeyEventChallengeCreated].ID, colosseumABI.Events[KeyEventBisected].ID}
+	if cfg.ChallengerEnabled {
+		addresses = append(addresses, cfg.L2OutputOracleAddr)
+		topics = append(topics, l2ooABI.Events[KeyEventOutputSubmitted].ID)
+	}
+
+	logger := l.New("service", "challenge")
 	return &Challenger{
-		log:  l.New("service", "challenge"),
+		log:  logger,
 		cfg:  cfg,
 		metr: m,
 
@@ -103,6 +131,12 @@ func NewChallenger(cfg Config, l log.Logger, m metrics.Metricer) (*Challenger, e
 		colosseumContract: colosseumContract,
 		colosseumABI:      colosseumABI,
 		valpoolContract:   valpoolContract,
+		contracts:         contracts,
+		poller:            NewStatePoller(logger, contracts, cfg.L1Client, cfg.ChallengerPollInterval, cfg.NetworkTimeout),
+		indexer:           NewLogIndexer(logger, cfg.L1Client, addresses, topics, cfg.ChallengerL1Confirmations, cfg.ChallengerPollInterval, cfg.NetworkTimeout),
+
+		outputHandlers:    make(map[uint64]struct{}),
+		challengeHandlers: make(map[ChallengeKey]struct{}),
 	}, nil
 }
 
@@ -155,30 +189,38 @@ func (c *Challenger) InitConfig(ctx context.Context) error {
 		return fmt.Errorf("failed to initiate valpool config: %w", err)
 	}
 
-	return nil
-}
-
-// initSub initialize subscriptions
-func (c *Challenger) initSub() {
-	opts := optsutils.NewSimpleWatchOpts(c.ctx)
+	err = contractWatcher.WatchUpgraded(c.cfg.ColosseumAddr, func() error {
+		cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+		defer cCancel()
+		zkVerifierAddr, err := c.colosseumContract.ZKVERIFIER(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get zk verifier address: %w", err)
+		}
+		c.zkVerifierAddr = zkVerifierAddr
 
-	if c.cfg.ChallengerEnabled {
-		c.l2OutputSubmittedEventChan = make(chan *bindings.L2OutputOracleOutputSubmitted)
-		c.l2OutputSubmittedSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
-			if err != nil {
-				c.log.Warn("resubscribing after failed OutputSubmitted event", "err", err)
-			}
-			return c.l2ooContract.WatchOutputSubmitted(opts, c.l2OutputSubmittedEventChan, nil, nil, nil)
-		})
-	}
+		cCtx, cCancel = context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+		defer cCancel()
+		dummyHash, err := c.colosseumContract.DUMMYHASH(optsutils.NewSimpleCallOpts(cCtx))
+		if err != nil {
+			return fmt.Errorf("failed to get dummy hash: %w", err)
+		}
+		c.dummyHash = dummyHash
 
-	c.challengeCreatedEventChan = make(chan *bindings.ColosseumChallengeCreated)
-	c.challengeCreatedSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
+		cCtx, cCancel = context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+		defer cCancel()
+		maxTxs, err := c.colosseumContract.MAXTXS(optsutils.NewSimpleCallOpts(cCtx))
 		if err != nil {
-			c.log.Warn("resubscribing after failed ChallengeCreated event", "err", err)
+			return fmt.Errorf("failed to get max txs: %w", err)
 		}
-		return c.colosseumContract.WatchChallengeCreated(opts, c.challengeCreatedEventChan, nil, nil, nil)
+		c.maxTxs = maxTxs.Uint64()
+
+		return nil
 	})
+	if err != nil {
+		return fmt.Errorf("failed to initiate colosseum config: %w", err)
+	}
+
+	return nil
 }
 
 func (c *Challenger) Start(ctx context.Context) error {
@@ -187,7 +229,12 @@ func (c *Challenger) Start(ctx context.Context) error {
 	if err := c.InitConfig(c.ctx); err != nil {
 		return err
 	}
-	c.initSub()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.poller.Run(c.ctx)
+	}()
 
 	c.wg.Add(1)
 	go c.loop()
@@ -196,19 +243,9 @@ func (c *Challenger) Start(ctx context.Context) error {
 }
 
 func (c *Challenger) Stop() error {
-	if c.l2OutputSubmittedSub != nil {
-		c.l2OutputSubmittedSub.Unsubscribe()
-	}
-	c.challengeCreatedSub.Unsubscribe()
-
 	c.cancel()
 	c.wg.Wait()
 
-	if c.l2OutputSubmittedEventChan != nil {
-		close(c.l2OutputSubmittedEventChan)
-	}
-	close(c.challengeCreatedEventChan)
-
 	return nil
 }
 
@@ -230,21 +267,15 @@ func (c *Challenger) loop() {
 				}
 			}
 
-			if err := c.scanPrevOutputs(); err != nil {
-				c.log.Error("failed to scan previous outputs", "err", err)
+			fromBlock, err := c.indexStartBlock()
+			if err != nil {
+				c.log.Error("failed to get the block to start indexing events from", "err", err)
 				continue
 			}
 
-			// if challenge mode on, subscribe L2 output submission events
-			if c.cfg.ChallengerEnabled {
-				c.wg.Add(1)
-				go c.subscribeL2OutputSubmitted()
-			}
-
-			// subscribe challenge creation events
-			c.wg.Add(1)
-			go c.subscribeChallengeCreated()
-
+			// index the events from the start of the finalization window, so the outputs and challenges
+			// that are still in progress are handled as well as the new ones
+			c.indexer.Run(c.ctx, fromBlock, c.dispatch)
 			return
 		}
 	}
@@ -270,115 +301,96 @@ func (c *Challenger) updateCheckpoint() error {
 	return nil
 }
 
-// scanPrevOutputs scans all the previous outputs before current L1 block within the finalization window.
-// If there are invalid outputs, create challenge.
-// If there are challenges in progress, keep handling them.
-func (c *Challenger) scanPrevOutputs() error {
+// indexStartBlock returns the first L1 block of the finalization window, before which no output can be challenged.
+func (c *Challenger) indexStartBlock() (uint64, error) {
 	status, err := c.cfg.RollupClient.SyncStatus(c.ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get sync status: %w", err)
+		return 0, fmt.Errorf("failed to get sync status: %w", err)
 	}
 
 	toBlock := new(big.Int).SetUint64(status.CurrentL1.Number)
 	// TODO(0xHansLee): add L1BlockTime to rollup config and change to use it
 	finalizationStartL1Block := new(big.Int).Sub(toBlock, new(big.Int).Div(c.finalizationPeriodSeconds, big.NewInt(12)))
 	// The fromBlock is the maximum value of either genesis block(1) or the first block of the finalization window
-	fromBlock := math.BigMax(common.Big1, finalizationStartL1Block)
-
-	outputSubmittedEvent := c.l2ooABI.Events[KeyEventOutputSubmitted]
-	challengeCreatedEvent := c.colosseumABI.Events[KeyEventChallengeCreated]
-
-	addresses := []common.Address{c.cfg.ColosseumAddr}
-	topics := []common.Hash{challengeCreatedEvent.ID}
-
-	// scan OutputSubmittedEvents only when challenger mode is on
-	if c.cfg.ChallengerEnabled {
-		addresses = append(addresses, c.cfg.L2OutputOracleAddr)
-		topics = append(topics, outputSubmittedEvent.ID)
-	}
-
-	query := ethereum.FilterQuery{
-		FromBlock: fromBlock,
-		ToBlock:   toBlock,
-		Addresses: addresses,
-		Topics:    [][]common.Hash{topics},
-	}
-
-	logs, err := c.l1Client.FilterLogs(c.ctx, query)
-	if err != nil {
-		return fmt.Errorf("failed to get event logs related to outputs: %w", err)
-	}
-
-	for _, vLog := range logs {
-		switch vLog.Address {
-		// for OutputSubmitted event
-		case c.cfg.L2OutputOracleAddr:
-			ev := NewOutputSubmittedEvent(vLog)
-			// handle output
-			c.wg.Add(1)
-			go c.handleOutput(ev.OutputIndex)
-		// for ChallengeCreated event
-		case c.cfg.ColosseumAddr:
-			ev := NewChallengeCreatedEvent(vLog)
-			if ev.OutputIndex.Sign() == 1 && c.isRelatedChallenge(ev.Asserter, ev.Challenger) {
-				c.wg.Add(1)
-				go c.handleChallenge(ev.OutputIndex, ev.Asserter, ev.Challenger)
+	return math.BigMax(common.Big1, finalizationStartL1Block).Uint64(), nil
+}
+
+// dispatch handles an indexed event log.
+// An OutputSubmitted event handles all the outputs between the checkpoint output index and the output index of the event.
+// A ChallengeCreated event handles the challenge if it is related, and a Bisected event polls the state of the
+// challenge right away, so the next turn is taken without waiting for the poll interval.
+func (c *Challenger) dispatch(vLog types.Log) {
+	switch {
+	case vLog.Address == c.cfg.L2OutputOracleAddr && vLog.Topics[0] == c.l2ooABI.Events[KeyEventOutputSubmitted].ID:
+		ev := NewOutputSubmittedEvent(vLog)
+		c.log.Info("indexed output submitted event", "l2BlockNumber", ev.L2BlockNumber, "outputRoot", ev.ExpectedOutputRoot, "outputIndex", ev.OutputIndex)
+		// if the emitted output index is less than or equal to the checkpoint, it is considered reorg occurred.
+		if ev.OutputIndex.Cmp(c.checkpoint) <= 0 {
+			c.startHandleOutput(new(big.Int).Set(ev.OutputIndex))
+		} else {
+			// validate all outputs between the checkpoint and the current outputIndex
+			for i := new(big.Int).Add(c.checkpoint, common.Big1); i.Cmp(ev.OutputIndex) != 1; i.Add(i, common.Big1) {
+				c.startHandleOutput(new(big.Int).Set(i))
 			}
-		default:
-			c.log.Warn("unknown event log", "logs", vLog)
+			c.checkpoint = ev.OutputIndex
+			c.metr.RecordChallengeCheckpoint(c.checkpoint)
+		}
+	case vLog.Address == c.cfg.ColosseumAddr && vLog.Topics[0] == c.colosseumABI.Events[KeyEventChallengeCreated].ID:
+		ev := NewChallengeCreatedEvent(vLog)
+		c.log.Info("indexed challenge created event", "outputIndex", ev.OutputIndex, "challenger", ev.Challenger)
+		if ev.OutputIndex.Sign() == 1 && c.isRelatedChallenge(ev.Asserter, ev.Challenger) {
+			c.startHandleChallenge(ev.OutputIndex, ev.Asserter, ev.Challenger)
+		}
+	case vLog.Address == c.cfg.ColosseumAddr && vLog.Topics[0] == c.colosseumABI.Events[KeyEventBisected].ID:
+		ev := NewBisectedEvent(vLog)
+		c.handlersLock.Lock()
+		_, ok := c.challengeHandlers[ChallengeKey{OutputIndex: ev.OutputIndex.Uint64(), Challenger: ev.Challenger}]
+		c.handlersLock.Unlock()
+		if ok {
+			c.log.Info("indexed bisected event", "outputIndex", ev.OutputIndex, "challenger", ev.Challenger)
+			c.poller.triggerPoll()
 		}
+	default:
+		c.log.Warn("unknown event log", "logs", vLog)
 	}
-
-	return nil
 }
 
-// subscribeL2OutputSubmitted subscribes the OutputSubmitted event from L2OutputOracle contract.
-// It handles all the outputs between the checkpoint output index and the output index from the watched event.
-// If the L2 output root is invalid, create challenge.
-// This function should be called only when challenger mode is on.
-func (c *Challenger) subscribeL2OutputSubmitted() {
-	defer c.wg.Done()
-
-	for {
-		select {
-		case ev := <-c.l2OutputSubmittedEventChan:
-			c.log.Info("watched output submitted event", "l2BlockNumber", ev.L2BlockNumber, "outputRoot", ev.OutputRoot, "outputIndex", ev.L2OutputIndex)
-			// if the emitted output index is less than or equal to the checkpoint, it is considered reorg occurred.
-			if ev.L2OutputIndex.Cmp(c.checkpoint) <= 0 {
-				c.wg.Add(1)
-				go c.handleOutput(new(big.Int).Set(ev.L2OutputIndex))
-			} else {
-				// validate all outputs between the checkpoint and the current outputIndex
-				for i := new(big.Int).Add(c.checkpoint, common.Big1); i.Cmp(ev.L2OutputIndex) != 1; i.Add(i, common.Big1) {
-					c.wg.Add(1)
-					go c.handleOutput(new(big.Int).Set(i))
-				}
-			}
-			c.checkpoint = ev.L2OutputIndex
-			c.metr.RecordChallengeCheckpoint(c.checkpoint)
-		case <-c.ctx.Done():
-			return
-		}
+// startHandleOutput handles the output, unless it is already being handled.
+func (c *Challenger) startHandleOutput(outputIndex *big.Int) {
+	key := outputIndex.Uint64()
+	c.handlersLock.Lock()
+	defer c.handlersLock.Unlock()
+	if _, ok := c.outputHandlers[key]; ok {
+		return
 	}
-}
+	c.outputHandlers[key] = struct{}{}
 
-// subscribeChallengeCreated subscribes the ChallengeCreated event from Colosseum contract and handle challenge.
-func (c *Challenger) subscribeChallengeCreated() {
-	defer c.wg.Done()
+	c.wg.Add(1)
+	go func() {
+		c.handleOutput(outputIndex)
+		c.handlersLock.Lock()
+		delete(c.outputHandlers, key)
+		c.handlersLock.Unlock()
+	}()
+}
 
-	for {
-		select {
-		case ev := <-c.challengeCreatedEventChan:
-			c.log.Info("watched challenge created event", "outputIndex", ev.OutputIndex, "challenger", ev.Challenger)
-			// when challenge created, handle it
-			if ev.OutputIndex.Sign() == 1 && c.isRelatedChallenge(ev.Asserter, ev.Challenger) {
-				c.wg.Add(1)
-				go c.handleChallenge(ev.OutputIndex, ev.Asserter, ev.Challenger)
-			}
-		case <-c.ctx.Done():
-			return
-		}
+// startHandleChallenge handles the challenge, unless it is already being handled.
+func (c *Challenger) startHandleChallenge(outputIndex *big.Int, asserter common.Address, challenger common.Address) {
+	key := ChallengeKey{OutputIndex: outputIndex.Uint64(), Challenger: challenger}
+	c.handlersLock.Lock()
+	defer c.handlersLock.Unlock()
+	if _, ok := c.challengeHandlers[key]; ok {
+		return
 	}
+	c.challengeHandlers[key] = struct{}{}
+
+	c.wg.Add(1)
+	go func() {
+		c.handleChallenge(outputIndex, asserter, challenger)
+		c.handlersLock.Lock()
+		delete(c.challengeHandlers, key)
+		c.handlersLock.Unlock()
+	}()
 }
 
 // handleOutput handles output when output submitted, creates challenge if the output is invalid.
@@ -387,81 +399,76 @@ func (c *Challenger) handleOutput(outputIndex *big.Int) {
 	c.log.Info("handling output to detect invalid output", "outputIndex", outputIndex)
 	defer c.wg.Done()
 
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
+	untrack := c.poller.TrackOutput(outputIndex.Uint64())
+	defer untrack()
 
-	for ; ; <-ticker.C {
-		select {
-		case <-c.ctx.Done():
+	var state *ContractState
+	for {
+		var output *OutputState
+		var err error
+		state, output, err = c.poller.NextOutput(c.ctx, state, outputIndex.Uint64())
+		if err != nil {
 			return
-		default:
-			// check if challenge creation period is not past
-			isInCreationPeriod, err := c.IsInChallengeCreationPeriod(c.ctx, outputIndex)
-			if err != nil {
-				c.log.Error("unable to get if challenge creation period is not past", "err", err, "outputIndex", outputIndex)
-				continue
-			}
-			// if challenge creation period is past, terminate handling
-			if !isInCreationPeriod {
-				c.log.Info("challenge creation period is already past", "outputIndex", outputIndex)
-				return
-			}
-
-			outputs, err := c.OutputsAtIndex(c.ctx, outputIndex)
-			if err != nil {
-				c.log.Error("unable to get outputs when handling output", "err", err, "outputIndex", outputIndex)
-				continue
-			}
+		}
 
-			outputRange := c.ValidateOutput(outputIndex, outputs)
-			// if output is valid, terminate handling
-			if outputRange == nil {
-				c.log.Info("output is validated", "outputIndex", outputIndex)
-				return
-			}
+		// if challenge creation period is past, terminate handling
+		if !output.IsInCreationPeriod {
+			c.log.Info("challenge creation period is already past", "outputIndex", outputIndex)
+			return
+		}
 
-			// if challenge from another challenger is already proven and output is deleted, terminate handling
-			if IsOutputDeleted(outputs.RemoteOutput.OutputRoot) {
-				c.log.Info("found invalid output, but output is already deleted", "outputIndex", outputIndex)
-				return
-			}
+		localOutput, err := c.OutputAtBlockSafe(c.ctx, output.Output.L2BlockNumber.Uint64())
+		if err != nil {
+			c.log.Error("unable to get outputs when handling output", "err", err, "outputIndex", outputIndex)
+			continue
+		}
+		outputs := &Outputs{RemoteOutput: output.Output, LocalOutput: localOutput}
 
-			// check the status of my challenge
-			status, err := c.GetChallengeStatus(c.ctx, outputIndex, c.cfg.TxManager.From())
-			if err != nil {
-				c.log.Error("unable to get challenge status", "err", err, "outputIndex", outputIndex)
-				continue
-			}
-			// if challenge is already in progress, terminate handing
-			if status != chal.StatusNone && status != chal.StatusChallengerTimeout {
-				c.log.Info("found invalid output, but challenge is already in progress", "outputIndex", outputIndex)
-				return
-			}
+		outputRange := c.ValidateOutput(outputIndex, outputs)
+		// if output is valid, terminate handling
+		if outputRange == nil {
+			c.log.Info("output is validated", "outputIndex", outputIndex)
+			return
+		}
 
-			hasEnoughDeposit, err := c.HasEnoughDeposit(c.ctx)
-			if err != nil {
-				c.log.Error(err.Error())
-				continue
-			}
-			if !hasEnoughDeposit {
-				continue
-			}
+		// if challenge from another challenger is already proven and output is deleted, terminate handling
+		if IsOutputDeleted(outputs.RemoteOutput.OutputRoot) {
+			c.log.Info("found invalid output, but output is already deleted", "outputIndex", outputIndex)
+			return
+		}
 
-			// if all of the above conditions are satisfied, create a new challenge
-			tx, err := c.CreateChallenge(c.ctx, outputRange)
-			if err != nil {
-				c.log.Error("failed to create createChallenge tx", "err", err, "outputIndex", outputIndex)
-				continue
-			}
+		// check the status of my challenge, at the same L1 block as the output
+		status, err := c.challengeStatusAt(c.ctx, rpcblock.ByNumber(state.L1Block), outputIndex, c.cfg.TxManager.From())
+		if err != nil {
+			c.log.Error("unable to get challenge status", "err", err, "outputIndex", outputIndex)
+			continue
+		}
+		// if challenge is already in progress, terminate handing
+		if status != chal.StatusNone && status != chal.StatusChallengerTimeout {
+			c.log.Info("found invalid output, but challenge is already in progress", "outputIndex", outputIndex)
+			return
+		}
 
-			if err := c.submitChallengeTx(tx); err != nil {
-				c.log.Error("failed to submit create challenge tx", "err", err, "outputIndex", outputIndex)
-				continue
-			}
+		hasEnoughDeposit, err := c.HasEnoughDeposit(c.ctx)
+		if err != nil {
+			c.log.Error(err.Error())
+			continue
+		}
+		if !hasEnoughDeposit {
+			continue
+		}
 
-			c.log.Info("submit create challenge tx", "outputIndex", outputIndex)
-			return
+		// if all of the above conditions are satisfied, create a new challenge
+		err = c.runAction("createChallenge", outputIndex, c.cfg.TxManager.From(), func(ctx context.Context) (*types.Transaction, error) {
+			return c.CreateChallenge(ctx, outputRange)
+		})
+		if err != nil {
+			c.log.Error("failed to create challenge", "err", err, "outputIndex", outputIndex)
+			continue
 		}
+
+		c.log.Info("submit create challenge tx", "outputIndex", outputIndex)
+		return
 	}
 }
 
@@ -473,138 +480,137 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 	isAsserter := asserter == c.cfg.TxManager.From()
 	isChallenger := challenger == c.cfg.TxManager.From()
 
-	ticker := time.NewTicker(c.cfg.ChallengerPollInterval)
-	defer ticker.Stop()
+	key := ChallengeKey{OutputIndex: outputIndex.Uint64(), Challenger: challenger}
+	untrack := c.poller.TrackChallenge(key)
+	defer untrack()
 
-	for ; ; <-ticker.C {
-		select {
-		case <-c.ctx.Done():
+	var state *ContractState
+	for {
+		var challengeState *ChallengeState
+		var output *OutputState
+		var err error
+		state, challengeState, output, err = c.poller.NextChallenge(c.ctx, state, key)
+		if err != nil {
 			return
-		default:
-			// check the status of challenge
-			status, err := c.GetChallengeStatus(c.ctx, outputIndex, challenger)
-			if err != nil {
-				c.log.Error("unable to get challenge status", "err", err, "outputIndex", outputIndex, "challenger", challenger)
-				continue
-			}
-			// if challenge is not in progress, terminate handling
-			if status == chal.StatusNone {
-				c.log.Info("challenge is not in progress", "outputIndex", outputIndex, "challenger", challenger)
-				return
-			}
+		}
 
-			outputs, err := c.OutputsAtIndex(c.ctx, outputIndex)
-			if err != nil {
-				c.log.Error("unable to get outputs when handling challenge", "err", err, "outputIndex", outputIndex)
-				continue
-			}
-			isOutputDeleted := IsOutputDeleted(outputs.RemoteOutput.OutputRoot)
+		// check the status of challenge
+		status := challengeState.Status
+		// if challenge is not in progress, terminate handling
+		if status == chal.StatusNone {
+			c.log.Info("challenge is not in progress", "outputIndex", outputIndex, "challenger", challenger)
+			return
+		}
 
-			isOutputFinalized, err := c.IsOutputFinalized(c.ctx, outputIndex)
-			if err != nil {
-				c.log.Error("unable to get if output is finalized when handling challenge", "err", err, "outputIndex", outputIndex)
-				continue
-			}
+		isOutputDeleted := IsOutputDeleted(output.Output.OutputRoot)
+		isOutputFinalized := output.IsFinalized
 
-			// if asserter
-			if isAsserter {
-				// if output is already deleted, asserter has no incentives to handle challenge any further
-				if isOutputDeleted {
-					c.log.Info("do nothing because output is already deleted", "outputIndex", outputIndex, "challenger", challenger)
-					return
-				}
-				// if output is already finalized and not `ChallengerTimeout` status, terminate handling
-				if isOutputFinalized && status != chal.StatusChallengerTimeout {
-					c.log.Info("output is already finalized when handling challenge", "outputIndex", outputIndex, "challenger", challenger)
-					return
+		// if asserter
+		if isAsserter {
+			// if output is already deleted, asserter has no incentives to handle challenge any further
+			if isOutputDeleted {
+				c.log.Info("do nothing because output is already deleted", "outputIndex", outputIndex, "challenger", challenger)
+				return
+			}
+			// if output is already finalized and not `ChallengerTimeout` status, terminate handling
+			if isOutputFinalized && status != chal.StatusChallengerTimeout {
+				c.log.Info("output is already finalized when handling challenge", "outputIndex", outputIndex, "challenger", challenger)
+				return
+			}
+			switch status {
+			case chal.StatusAsserterTurn:
+				err := c.runAction("bisect", outputIndex, challenger, func(ctx context.Context) (*types.Transaction, error) {
+					return c.bisect(ctx, outputIndex, challenger, challengeState.Challenge)
+				})
+				if err != nil {
+					c.log.Error("failed to bisect", "err", err, "outputIndex", outputIndex, "challenger", challenger)
+					continue
 				}
-				switch status {
-				case chal.StatusAsserterTurn:
-					tx, err := c.Bisect(c.ctx, outputIndex, challenger)
-					if err != nil {
-						c.log.Error("failed to create bisect tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
-						continue
-					}
-					if err := c.submitChallengeTx(tx); err != nil {
-						c.log.Error("failed to submit bisect tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
-						continue
-					}
-				case chal.StatusChallengerTimeout:
-					// call challenger timeout to increase bond from pending bond
-					tx, err := c.ChallengerTimeout(c.ctx, outputIndex, challenger)
-					if err != nil {
-						c.log.Error("failed to create challenger timeout tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
-						continue
-					}
-					if err := c.submitChallengeTx(tx); err != nil {
-						c.log.Error("failed to submit challenger timeout tx", "err", err, "outputIndex", outputIndex, "challenger", challenger)
-						continue
-					}
+			case chal.StatusChallengerTimeout:
+				// call challenger timeout to increase bond from pending bond
+				err := c.runAction("challengerTimeout", outputIndex, challenger, func(ctx context.Context) (*types.Transaction, error) {
+					return c.ChallengerTimeout(ctx, outputIndex, challenger)
+				})
+				if err != nil {
+					c.log.Error("failed to call challenger timeout", "err", err, "outputIndex", outputIndex, "challenger", challenger)
+					continue
 				}
 			}
+		}
 
-			// if challenger
-			if isChallenger && c.cfg.ChallengerEnabled {
-				// if output has been already deleted, cancel challenge to refund pending bond
-				if isOutputDeleted && status != chal.StatusChallengerTimeout {
-					tx, err := c.CancelChallenge(c.ctx, outputIndex)
-					if err != nil {
-						c.log.Error("failed to create cancel challenge tx", "err", err, "outputIndex", outputIndex)
-						continue
-					}
-					if err := c.submitChallengeTx(tx); err != nil {
-						c.log.Error("failed to submit cancel challenge tx", "err", err, "outputIndex", outputIndex)
-						continue
-					}
+		// if challenger
+		if isChallenger && c.cfg.ChallengerEnabled {
+			// if output has been already deleted, cancel challenge to refund pending bond
+			if isOutputDeleted && status != chal.StatusChallengerTimeout {
+				err := c.runAction("cancelChallenge", outputIndex, challenger, func(ctx context.Context) (*types.Transaction, error) {
+					return c.CancelChallenge(ctx, outputIndex)
+				})
+				if err != nil {
+					c.log.Error("failed to cancel challenge", "err", err, "outputIndex", outputIndex)
+					continue
 				}
+			}
 
-				// if output is already finalized, terminate handling
-				if isOutputFinalized {
-					c.log.Info("output is already finalized when handling challenge", "outputIndex", outputIndex)
-					return
-				}
+			// if output is already finalized, terminate handling
+			if isOutputFinalized {
+				c.log.Info("output is already finalized when handling challenge", "outputIndex", outputIndex)
+				return
+			}
 
-				// Challenger doesn't need to check if output is already deleted or not. Because when trying to bisect or prove fault with deleted output index,
-				// the contract automatically cancels the challenge.
-				switch status {
-				case chal.StatusChallengerTurn:
-					tx, err := c.Bisect(c.ctx, outputIndex, challenger)
-					if err != nil {
-						c.log.Error("failed to create bisect tx", "err", err, "outputIndex", outputIndex)
-						continue
-					}
-					if err := c.submitChallengeTx(tx); err != nil {
-						c.log.Error("failed to submit bisect tx", "err", err, "outputIndex", outputIndex)
-						continue
-					}
-				case chal.StatusAsserterTimeout, chal.StatusReadyToProve:
-					skipSelectFaultPosition := status == chal.StatusAsserterTimeout
-					tx, err := c.ProveFault(c.ctx, outputIndex, challenger, skipSelectFaultPosition)
-					if err != nil {
-						c.log.Error("failed to create prove fault tx", "err", err, "outputIndex", outputIndex)
-						continue
-					}
-					if err := c.submitChallengeTx(tx); err != nil {
-						c.log.Error("failed to submit prove fault tx", "err", err, "outputIndex", outputIndex)
-						continue
-					}
+			// Challenger doesn't need to check if output is already deleted or not. Because when trying to bisect or prove fault with deleted output index,
+			// the contract automatically cancels the challenge.
+			switch status {
+			case chal.StatusChallengerTurn:
+				err := c.runAction("bisect", outputIndex, challenger, func(ctx context.Context) (*types.Transaction, error) {
+					return c.bisect(ctx, outputIndex, challenger, challengeState.Challenge)
+				})
+				if err != nil {
+					c.log.Error("failed to bisect", "err", err, "outputIndex", outputIndex)
+					continue
+				}
+			case chal.StatusAsserterTimeout, chal.StatusReadyToProve:
+				skipSelectFaultPosition := status == chal.StatusAsserterTimeout
+				err := c.runAction("proveFault", outputIndex, challenger, func(ctx context.Context) (*types.Transaction, error) {
+					return c.proveFault(ctx, outputIndex, challenger, challengeState.Challenge, skipSelectFaultPosition)
+				})
+				if err != nil {
+					c.log.Error("failed to prove fault", "err", err, "outputIndex", outputIndex)
+					continue
 				}
 			}
 		}
 	}
 }
 
-func (c *Challenger) submitChallengeTx(tx *types.Transaction) error {
-	return c.cfg.TxManager.SendTransaction(c.ctx, tx).Err
+// runAction creates the tx of a challenge action and submits it, in a span that covers both.
+func (c *Challenger) runAction(action string, outputIndex *big.Int, challenger common.Address, createTx func(ctx context.Context) (*types.Transaction, error)) (err error) {
+	ctx, span := tracer.Start(c.ctx, "challenger."+action, trace.WithAttributes(
+		attribute.Int64("output_index", outputIndex.Int64()),
+		attribute.String("challenger", challenger.Hex()),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := createTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create %s tx: %w", action, err)
+	}
+	if err := c.submitChallengeTx(ctx, tx); err != nil {
+		return fmt.Errorf("failed to submit %s tx: %w", action, err)
+	}
+	return nil
+}
+
+func (c *Challenger) submitChallengeTx(ctx context.Context, tx *types.Transaction) error {
+	return c.cfg.TxManager.SendTransaction(ctx, tx).Err
 }
 
 // HasEnoughDeposit checks if challenger has enough deposit to bond when creating challenge.
 func (c *Challenger) HasEnoughDeposit(ctx context.Context) (bool, error) {
 	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	balance, err := c.valpoolContract.BalanceOf(optsutils.NewSimpleCallOpts(cCtx), c.cfg.TxManager.From())
+	balance, err := c.contracts.Deposit(cCtx, rpcblock.Latest, c.cfg.TxManager.From())
 	if err != nil {
-		return false, fmt.Errorf("failed to fetch deposit amount: %w", err)
+		return false, err
 	}
 	c.metr.RecordDepositAmount(balance)
 
@@ -620,19 +626,19 @@ func (c *Challenger) HasEnoughDeposit(ctx context.Context) (bool, error) {
 func (c *Challenger) IsInChallengeCreationPeriod(ctx context.Context, outputIndex *big.Int) (bool, error) {
 	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	return c.colosseumContract.IsInCreationPeriod(optsutils.NewSimpleCallOpts(cCtx), outputIndex)
+	return c.contracts.IsInCreationPeriod(cCtx, rpcblock.Latest, outputIndex)
 }
 
 func (c *Challenger) IsOutputFinalized(ctx context.Context, outputIndex *big.Int) (bool, error) {
 	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	return c.l2ooContract.IsFinalized(optsutils.NewSimpleCallOpts(cCtx), outputIndex)
+	return c.contracts.IsOutputFinalized(cCtx, rpcblock.Latest, outputIndex)
 }
 
 func (c *Challenger) GetChallenge(ctx context.Context, outputIndex *big.Int, challenger common.Address) (bindings.TypesChallenge, error) {
 	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	return c.colosseumContract.GetChallenge(optsutils.NewSimpleCallOpts(cCtx), outputIndex, challenger)
+	return c.contracts.Challenge(cCtx, rpcblock.Latest, outputIndex, challenger)
 }
 
 func (c *Challenger) OutputAtBlockSafe(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, error) {
@@ -698,7 +704,7 @@ type Outputs struct {
 func (c *Challenger) OutputsAtIndex(ctx context.Context, outputIndex *big.Int) (*Outputs, error) {
 	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	RemoteOutput, err := c.l2ooContract.GetL2Output(optsutils.NewSimpleCallOpts(cCtx), outputIndex)
+	RemoteOutput, err := c.contracts.Output(cCtx, rpcblock.Latest, outputIndex)
 	if err != nil {
 		return nil, err
 	}
@@ -753,9 +759,13 @@ func (c *Challenger) isRelatedChallenge(asserter common.Address, challenger comm
 }
 
 func (c *Challenger) GetChallengeStatus(ctx context.Context, outputIndex *big.Int, challenger common.Address) (uint8, error) {
+	return c.challengeStatusAt(ctx, rpcblock.Latest, outputIndex, challenger)
+}
+
+func (c *Challenger) challengeStatusAt(ctx context.Context, block rpcblock.Block, outputIndex *big.Int, challenger common.Address) (uint8, error) {
 	cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
 	defer cCancel()
-	return c.colosseumContract.GetStatus(optsutils.NewSimpleCallOpts(cCtx), outputIndex, challenger)
+	return c.contracts.ChallengeStatus(cCtx, block, outputIndex, challenger)
 }
 
 func (c *Challenger) BuildSegments(ctx context.Context, turn uint8, segStart, segSize uint64) (*chal.Segments, error) {
@@ -819,12 +829,15 @@ func (c *Challenger) CreateChallenge(ctx context.Context, outputRange *OutputRan
 }
 
 func (c *Challenger) Bisect(ctx context.Context, outputIndex *big.Int, challenger common.Address) (*types.Transaction, error) {
-	c.log.Info("crafting bisect tx", "outputIndex", outputIndex, "challenger", challenger)
-
 	challenge, err := c.GetChallenge(ctx, outputIndex, challenger)
 	if err != nil {
 		return nil, err
 	}
+	return c.bisect(ctx, outputIndex, challenger, challenge)
+}
+
+func (c *Challenger) bisect(ctx context.Context, outputIndex *big.Int, challenger common.Address, challenge bindings.TypesChallenge) (*types.Transaction, error) {
+	c.log.Info("crafting bisect tx", "outputIndex", outputIndex, "challenger", challenger)
 
 	prevSegments := chal.NewSegments(challenge.SegStart.Uint64(), challenge.SegSize.Uint64(), challenge.Segments)
 	position, err := c.selectFaultPosition(ctx, prevSegments)
@@ -864,13 +877,17 @@ func (c *Challenger) CancelChallenge(ctx context.Context, outputIndex *big.Int)
 // ProveFault creates proveFault transaction for invalid output root.
 // TODO: ProveFault will take long time, so that we may have to handle it carefully.
 func (c *Challenger) ProveFault(ctx context.Context, outputIndex *big.Int, challenger common.Address, skipSelectFaultPosition bool) (*types.Transaction, error) {
-	c.log.Info("crafting proveFault tx", "outputIndex", outputIndex, "challenger", challenger)
-
 	challenge, err := c.GetChallenge(ctx, outputIndex, challenger)
 	if err != nil {
 		return nil, err
 	}
+	return c.proveFault(ctx, outputIndex, challenger, challenge, skipSelectFaultPosition)
+}
+
+func (c *Challenger) proveFault(ctx context.Context, outputIndex *big.Int, challenger common.Address, challenge bindings.TypesChallenge, skipSelectFaultPosition bool) (*types.Transaction, error) {
+	c.log.Info("crafting proveFault tx", "outputIndex", outputIndex, "challenger", challenger)
 
+	var err error
 	// when asserter timeout, skip finding fault position since the same segments have been stored in colosseum
 	position := common.Big0
 	blockNumber := challenge.SegStart
@@ -902,9 +919,10 @@ func (c *Challenger) ProveFault(ctx context.Context, outputIndex *big.Int, chall
 		return nil, fmt.Errorf("failed to marshal block trace(fault position blockNumber: %d): %w", targetBlockNumber.Uint64(), err)
 	}
 
-	fetchResult, err := c.cfg.ProofFetcher.FetchProofAndPair(ctx, string(traceBz))
+	publicInputHash := chal.HashPublicInput(proof.SrcOutputRootProof.StateRoot, proof.PublicInput, c.dummyHash, c.maxTxs)
+	fetchResult, err := c.fetchVerifiedProof(ctx, string(traceBz), publicInputHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch proof and pair(fault position blockNumber: %d): %w", targetBlockNumber.Uint64(), err)
+		return nil, fmt.Errorf("failed to fetch verified proof and pair(fault position blockNumber: %d): %w", targetBlockNumber.Uint64(), err)
 	}
 
 	txOpts := optsutils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
@@ -920,6 +938,47 @@ func (c *Challenger) ProveFault(ctx context.Context, outputIndex *big.Int, chall
 	)
 }
 
+// fetchVerifiedProof fetches the proof and pair of a fault, and verifies them with the ZKVerifier before
+// they are submitted, so a bad proof from the prover does not burn gas and lose the challenge.
+// A proof that fails the verification is fetched again, up to proofAttempts times.
+func (c *Challenger) fetchVerifiedProof(ctx context.Context, trace string, publicInputHash common.Hash) (*chal.ProofAndPair, error) {
+	var lastErr error
+	for i := 0; i < proofAttempts; i++ {
+		fetchResult, err := c.cfg.ProofFetcher.FetchProofAndPair(ctx, trace)
+		if err != nil {
+			c.metr.RecordProofVerification(metrics.ProofFetchError)
+			lastErr = err
+			continue
+		}
+		if len(fetchResult.Pair) < 4 {
+			c.metr.RecordProofVerification(metrics.ProofMalformed)
+			lastErr = fmt.Errorf("pair has %d elements, expected at least 4", len(fetchResult.Pair))
+			c.log.Warn("fetched malformed proof, fetching again", "err", lastErr, "attempt", i+1)
+			continue
+		}
+
+		cCtx, cCancel := context.WithTimeout(ctx, c.cfg.NetworkTimeout)
+		verified, err := c.contracts.VerifyProof(cCtx, rpcblock.Latest, c.zkVerifierAddr, fetchResult.Proof, fetchResult.Pair[:4], publicInputHash)
+		cCancel()
+		if err != nil {
+			c.metr.RecordProofVerification(metrics.ProofVerifyError)
+			lastErr = err
+			c.log.Warn("failed to verify proof, fetching again", "err", err, "attempt", i+1)
+			continue
+		}
+		if !verified {
+			c.metr.RecordProofVerification(metrics.ProofInvalid)
+			lastErr = errors.New("proof is rejected by the zk verifier")
+			c.log.Warn("fetched invalid proof, fetching again", "publicInputHash", publicInputHash, "attempt", i+1)
+			continue
+		}
+
+		c.metr.RecordProofVerification(metrics.ProofValid)
+		return fetchResult, nil
+	}
+	return nil, fmt.Errorf("no verified proof after %d attempts: %w", proofAttempts, lastErr)
+}
+
 // IsOutputDeleted checks if the output is deleted.
 func IsOutputDeleted(outputRoot [32]byte) bool {
 	return bytes.Equal(outputRoot[:], deletedOutputRoot[:])
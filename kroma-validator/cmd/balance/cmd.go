@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
@@ -86,9 +89,9 @@ func sendTransaction(ctx *cli.Context, txData []byte, txValue string) error {
 		return fmt.Errorf("failed to create tx manager: %w", err)
 	}
 
-	valpoolAddr, err := opservice.ParseAddress(ctx.String(flags.ValPoolAddressFlag.Name))
+	valpoolAddr, err := validatorPoolAddress(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+		return err
 	}
 
 	value, success := new(big.Int).SetString(txValue, 10)
@@ -110,3 +113,22 @@ func sendTransaction(ctx *cli.Context, txData []byte, txValue string) error {
 
 	return nil
 }
+
+// validatorPoolAddress returns the ValidatorPool address set by flag, or else the one of the selected network.
+func validatorPoolAddress(ctx *cli.Context) (common.Address, error) {
+	if ctx.String(flags.ValPoolAddressFlag.Name) != "" || ctx.String(opflags.NetworkFlagName) == "" {
+		addr, err := opservice.ParseAddress(ctx.String(flags.ValPoolAddressFlag.Name))
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to parse ValidatorPool address: %w", err)
+		}
+		return addr, nil
+	}
+	network, err := chaincfg.LoadNetwork(ctx.String(opflags.NetworkRegistryFlagName), ctx.String(opflags.NetworkFlagName))
+	if err != nil {
+		return common.Address{}, err
+	}
+	if network.Addresses.ValidatorPool == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("network %s has no ValidatorPool address, it must be set by flag", network.Name)
+	}
+	return network.Addresses.ValidatorPool, nil
+}
@@ -3,21 +3,31 @@ package validator
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
+	hdwallet "github.com/ethereum-optimism/go-ethereum-hdwallet"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	pprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
 	"github.com/kroma-network/kroma/kroma-validator/flags"
@@ -32,6 +42,7 @@ type Config struct {
 	SecurityCouncilAddr             common.Address
 	ValidatorPoolAddr               common.Address
 	ChallengerPollInterval          time.Duration
+	ChallengerL1Confirmations       uint64
 	NetworkTimeout                  time.Duration
 	TxManager                       *txmgr.BufferedTxManager
 	L1Client                        *ethclient.Client
@@ -46,6 +57,10 @@ type Config struct {
 	ChallengerEnabled               bool
 	GuardianEnabled                 bool
 	ProofFetcher                    ProofFetcher
+	AttestationEnabled              bool
+	AttestationPollInterval         time.Duration
+	AttestationSigner               p2p.Signer
+	P2PClient                       *p2p.Client
 }
 
 // Check ensures that the [Config] is valid.
@@ -81,9 +96,19 @@ type CLIConfig struct {
 	// ValPoolAddress is the ValidatorPool contract address.
 	ValPoolAddress string
 
+	// Network is the name of the network whose contract addresses are used for the addresses
+	// that are not set.
+	Network string
+
+	// NetworkRegistry is the directory of additional networks to select from.
+	NetworkRegistry string
+
 	// ChallengerPollInterval is how frequently to poll L2 for new finalized outputs.
 	ChallengerPollInterval time.Duration
 
+	// ChallengerL1Confirmations is the number of confirmations of an L1 block before the challenger and the guardian handle its events.
+	ChallengerL1Confirmations uint64
+
 	// ProverRPC is the URL of prover jsonRPC server.
 	ProverRPC string
 
@@ -107,20 +132,33 @@ type CLIConfig struct {
 
 	FetchingProofTimeout time.Duration
 
+	// AttestationEnabled publishes the computed output roots as signed attestations over p2p.
+	AttestationEnabled bool
+
+	// AttestationPollInterval is how frequently to check whether a new output can be attested.
+	AttestationPollInterval time.Duration
+
 	TxMgrConfig   txmgr.CLIConfig
 	RPCConfig     oprpc.CLIConfig
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   pprof.CLIConfig
+	TracingConfig tracing.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
+	if c.Network == "" && (c.L2OOAddress == "" || c.ColosseumAddress == "" || c.ValPoolAddress == "") {
+		return errors.New("L2OOAddress, ColosseumAddress and ValPoolAddress are required when no network is selected")
+	}
 	if !(c.OutputSubmitterEnabled || c.ChallengerEnabled || c.GuardianEnabled) {
 		return errors.New("one of output submitter, challenger, guardian should be enabled")
 	}
 	if c.OutputSubmitterAllowPublicRound && !c.OutputSubmitterEnabled {
 		return errors.New("OutputSubmitterAllowPublicRound is meaningful when OutputSubmitterEnabled enabled")
 	}
+	if c.AttestationEnabled && c.AttestationPollInterval <= 0 {
+		return errors.New("AttestationPollInterval must be positive when AttestationEnabled enabled")
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -130,6 +168,9 @@ func (c CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.TracingConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -146,6 +187,8 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		L2OOAddress:            ctx.String(flags.L2OOAddressFlag.Name),
 		ColosseumAddress:       ctx.String(flags.ColosseumAddressFlag.Name),
 		ValPoolAddress:         ctx.String(flags.ValPoolAddressFlag.Name),
+		Network:                ctx.String(opflags.NetworkFlagName),
+		NetworkRegistry:        ctx.String(opflags.NetworkRegistryFlagName),
 		OutputSubmitterEnabled: ctx.Bool(flags.OutputSubmitterEnabledFlag.Name),
 		ChallengerEnabled:      ctx.Bool(flags.ChallengerEnabledFlag.Name),
 		ChallengerPollInterval: ctx.Duration(flags.ChallengerPollIntervalFlag.Name),
@@ -153,6 +196,7 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 
 		// Optional Flags
 		AllowNonFinalized:               ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		ChallengerL1Confirmations:       ctx.Uint64(flags.ChallengerL1ConfirmationsFlag.Name),
 		OutputSubmitterRetryInterval:    ctx.Duration(flags.OutputSubmitterRetryIntervalFlag.Name),
 		OutputSubmitterRoundBuffer:      ctx.Uint64(flags.OutputSubmitterRoundBufferFlag.Name),
 		OutputSubmitterAllowPublicRound: ctx.Bool(flags.OutputSubmitterAllowPublicRoundFlag.Name),
@@ -160,15 +204,55 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		ProverRPC:                       ctx.String(flags.ProverRPCFlag.Name),
 		GuardianEnabled:                 ctx.Bool(flags.GuardianEnabledFlag.Name),
 		FetchingProofTimeout:            ctx.Duration(flags.FetchingProofTimeoutFlag.Name),
+		AttestationEnabled:              ctx.Bool(flags.AttestationEnabledFlag.Name),
+		AttestationPollInterval:         ctx.Duration(flags.AttestationPollIntervalFlag.Name),
 		RPCConfig:                       oprpc.ReadCLIConfig(ctx),
 		LogConfig:                       oplog.ReadCLIConfig(ctx),
 		MetricsConfig:                   opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:                     pprof.ReadCLIConfig(ctx),
+		TracingConfig:                   tracing.ReadCLIConfig(ctx),
 	}
 }
 
+// applyNetwork sets the contract addresses that are not set to the addresses of the selected network.
+func (c *CLIConfig) applyNetwork() error {
+	network, err := chaincfg.LoadNetwork(c.NetworkRegistry, c.Network)
+	if err != nil {
+		return err
+	}
+	for _, a := range []struct {
+		name     string
+		value    *string
+		addr     common.Address
+		required bool
+	}{
+		{"L2OutputOracle", &c.L2OOAddress, network.Addresses.L2OutputOracle, true},
+		{"Colosseum", &c.ColosseumAddress, network.Addresses.Colosseum, true},
+		{"ValidatorPool", &c.ValPoolAddress, network.Addresses.ValidatorPool, true},
+		{"SecurityCouncil", &c.SecurityCouncilAddress, network.Addresses.SecurityCouncil, false},
+	} {
+		if *a.value != "" {
+			continue
+		}
+		if a.addr == (common.Address{}) {
+			if a.required {
+				return fmt.Errorf("network %s has no %s address, it must be set by flag", network.Name, a.name)
+			}
+			continue
+		}
+		*a.value = a.addr.Hex()
+	}
+	return nil
+}
+
 // NewValidatorConfig creates a validator config with given the CLIConfig
 func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config, error) {
+	if cfg.Network != "" {
+		if err := cfg.applyNetwork(); err != nil {
+			return nil, err
+		}
+	}
+
 	l2ooAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
 	if err != nil {
 		return nil, err
@@ -231,12 +315,27 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		return nil, err
 	}
 
+	var attestationSigner p2p.Signer
+	var p2pClient *p2p.Client
+	if cfg.AttestationEnabled {
+		attestationSigner, err = NewAttestationSigner(l, cfg.TxMgrConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create attestation signer: %w", err)
+		}
+		rpcClient, err := dial.DialRPCClientWithTimeout(ctx, dial.DefaultDialTimeout, l, cfg.RollupRpc)
+		if err != nil {
+			return nil, err
+		}
+		p2pClient = p2p.NewClient(rpcClient)
+	}
+
 	return &Config{
 		L2OutputOracleAddr:              l2ooAddress,
 		ColosseumAddr:                   colosseumAddress,
 		SecurityCouncilAddr:             securityCouncilAddress,
 		ValidatorPoolAddr:               valPoolAddress,
 		ChallengerPollInterval:          cfg.ChallengerPollInterval,
+		ChallengerL1Confirmations:       cfg.ChallengerL1Confirmations,
 		NetworkTimeout:                  cfg.TxMgrConfig.NetworkTimeout,
 		TxManager:                       txManager,
 		L1Client:                        l1Client,
@@ -251,5 +350,41 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		ChallengerEnabled:               cfg.ChallengerEnabled,
 		GuardianEnabled:                 cfg.GuardianEnabled,
 		ProofFetcher:                    fetcher,
+		AttestationEnabled:              cfg.AttestationEnabled,
+		AttestationPollInterval:         cfg.AttestationPollInterval,
+		AttestationSigner:               attestationSigner,
+		P2PClient:                       p2pClient,
 	}, nil
 }
+
+// NewAttestationSigner creates the signer of output attestations from the key of the validator's tx manager,
+// so attestations are signed by the address that is registered in the ValidatorPool.
+// With a remote signer, the attestations are signed by the signer service with the key of the configured address.
+func NewAttestationSigner(l log.Logger, cfg txmgr.CLIConfig) (p2p.Signer, error) {
+	if cfg.SignerCLIConfig.Enabled() {
+		client, err := opsigner.NewSignerClientFromConfig(l, cfg.SignerCLIConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create signer client: %w", err)
+		}
+		return p2p.NewRemoteSigner(l, client, common.HexToAddress(cfg.SignerCLIConfig.Address)), nil
+	}
+	if cfg.PrivateKey != "" && cfg.Mnemonic != "" {
+		return nil, errors.New("cannot specify both a private key and a mnemonic")
+	}
+	if cfg.PrivateKey != "" {
+		privKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the private key: %w", err)
+		}
+		return p2p.NewLocalSigner(privKey), nil
+	}
+	wallet, err := hdwallet.NewFromMnemonic(cfg.Mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mnemonic: %w", err)
+	}
+	privKey, err := wallet.PrivateKey(accounts.Account{URL: accounts.URL{Path: cfg.HDPath}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a wallet: %w", err)
+	}
+	return p2p.NewLocalSigner(privKey), nil
+}
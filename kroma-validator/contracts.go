@@ -0,0 +1,241 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+const (
+	methodGetL2Output           = "getL2Output"
+	methodIsFinalized           = "isFinalized"
+	methodIsInCreationPeriod    = "isInCreationPeriod"
+	methodGetStatus             = "getStatus"
+	methodGetChallenge          = "getChallenge"
+	methodBalanceOf             = "balanceOf"
+	methodIsConfirmed           = "isConfirmed"
+	methodTransactions          = "transactions"
+	methodGetL2OutputIndexAfter = "getL2OutputIndexAfter"
+	methodVerify                = "verify"
+)
+
+// OutputState is the state of an output in the L2OutputOracle and Colosseum.
+type OutputState struct {
+	Output             bindings.TypesCheckpointOutput
+	IsFinalized        bool
+	IsInCreationPeriod bool
+}
+
+// ChallengeKey identifies a challenge in the Colosseum.
+type ChallengeKey struct {
+	OutputIndex uint64
+	Challenger  common.Address
+}
+
+// ChallengeState is the state of a challenge in the Colosseum.
+type ChallengeState struct {
+	Status    uint8
+	Challenge bindings.TypesChallenge
+}
+
+// ContractState is the state of a set of outputs and challenges read at the same L1 block.
+type ContractState struct {
+	// L1Block is the number of the L1 block the state is read at.
+	L1Block    uint64
+	Outputs    map[uint64]*OutputState
+	Challenges map[ChallengeKey]*ChallengeState
+
+	// seq orders the states polled by a StatePoller.
+	seq uint64
+}
+
+// ConfirmationState is the state of a SecurityCouncil transaction that confirms a request about an output.
+type ConfirmationState struct {
+	IsOutputFinalized bool
+	IsConfirmed       bool
+	IsExecuted        bool
+}
+
+// ValidatorContracts reads the contracts the validator watches.
+// The reads of one method are sent as a batch of calls pinned to the same L1 block.
+type ValidatorContracts struct {
+	caller          *batching.MultiCaller
+	l2oo            *batching.BoundContract
+	colosseum       *batching.BoundContract
+	valpool         *batching.BoundContract
+	securityCouncil *batching.BoundContract
+	zkVerifierABI   *abi.ABI
+}
+
+func NewValidatorContracts(rpc batching.EthRpc, cfg Config) (*ValidatorContracts, error) {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L2OutputOracle ABI: %w", err)
+	}
+	colosseumABI, err := bindings.ColosseumMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Colosseum ABI: %w", err)
+	}
+	valpoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ValidatorPool ABI: %w", err)
+	}
+	securityCouncilABI, err := bindings.SecurityCouncilMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SecurityCouncil ABI: %w", err)
+	}
+	zkVerifierABI, err := bindings.ZKVerifierMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ZKVerifier ABI: %w", err)
+	}
+	return &ValidatorContracts{
+		caller:          batching.NewMultiCaller(rpc, batching.DefaultBatchSize),
+		l2oo:            batching.NewBoundContract(l2ooABI, cfg.L2OutputOracleAddr),
+		colosseum:       batching.NewBoundContract(colosseumABI, cfg.ColosseumAddr),
+		valpool:         batching.NewBoundContract(valpoolABI, cfg.ValidatorPoolAddr),
+		securityCouncil: batching.NewBoundContract(securityCouncilABI, cfg.SecurityCouncilAddr),
+		zkVerifierABI:   zkVerifierABI,
+	}, nil
+}
+
+// State reads the state of the given outputs and challenges at the given L1 block.
+func (c *ValidatorContracts) State(ctx context.Context, l1Block uint64, outputs []uint64, challenges []ChallengeKey) (*ContractState, error) {
+	calls := make([]batching.Call, 0, 3*len(outputs)+2*len(challenges))
+	for _, index := range outputs {
+		outputIndex := new(big.Int).SetUint64(index)
+		calls = append(calls,
+			c.l2oo.Call(methodGetL2Output, outputIndex),
+			c.l2oo.Call(methodIsFinalized, outputIndex),
+			c.colosseum.Call(methodIsInCreationPeriod, outputIndex))
+	}
+	for _, key := range challenges {
+		outputIndex := new(big.Int).SetUint64(key.OutputIndex)
+		calls = append(calls,
+			c.colosseum.Call(methodGetStatus, outputIndex, key.Challenger),
+			c.colosseum.Call(methodGetChallenge, outputIndex, key.Challenger))
+	}
+
+	state := &ContractState{
+		L1Block:    l1Block,
+		Outputs:    make(map[uint64]*OutputState, len(outputs)),
+		Challenges: make(map[ChallengeKey]*ChallengeState, len(challenges)),
+	}
+	if len(calls) == 0 {
+		return state, nil
+	}
+	results, err := c.caller.Call(ctx, rpcblock.ByNumber(l1Block), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outputs and challenges at L1 block %d: %w", l1Block, err)
+	}
+
+	for i, index := range outputs {
+		output := &OutputState{
+			IsFinalized:        results[3*i+1].GetBool(0),
+			IsInCreationPeriod: results[3*i+2].GetBool(0),
+		}
+		results[3*i].GetStruct(0, &output.Output)
+		state.Outputs[index] = output
+	}
+	results = results[3*len(outputs):]
+	for i, key := range challenges {
+		challenge := &ChallengeState{Status: results[2*i].GetUint8(0)}
+		results[2*i+1].GetStruct(0, &challenge.Challenge)
+		state.Challenges[key] = challenge
+	}
+	return state, nil
+}
+
+// Output reads the output of the given index at the given L1 block.
+func (c *ValidatorContracts) Output(ctx context.Context, block rpcblock.Block, outputIndex *big.Int) (bindings.TypesCheckpointOutput, error) {
+	var output bindings.TypesCheckpointOutput
+	result, err := c.caller.SingleCall(ctx, block, c.l2oo.Call(methodGetL2Output, outputIndex))
+	if err != nil {
+		return output, fmt.Errorf("failed to get output %d: %w", outputIndex, err)
+	}
+	result.GetStruct(0, &output)
+	return output, nil
+}
+
+func (c *ValidatorContracts) IsOutputFinalized(ctx context.Context, block rpcblock.Block, outputIndex *big.Int) (bool, error) {
+	result, err := c.caller.SingleCall(ctx, block, c.l2oo.Call(methodIsFinalized, outputIndex))
+	if err != nil {
+		return false, fmt.Errorf("failed to get if output %d is finalized: %w", outputIndex, err)
+	}
+	return result.GetBool(0), nil
+}
+
+func (c *ValidatorContracts) IsInCreationPeriod(ctx context.Context, block rpcblock.Block, outputIndex *big.Int) (bool, error) {
+	result, err := c.caller.SingleCall(ctx, block, c.colosseum.Call(methodIsInCreationPeriod, outputIndex))
+	if err != nil {
+		return false, fmt.Errorf("failed to get if output %d is in creation period: %w", outputIndex, err)
+	}
+	return result.GetBool(0), nil
+}
+
+func (c *ValidatorContracts) L2OutputIndexAfter(ctx context.Context, block rpcblock.Block, l2BlockNumber *big.Int) (*big.Int, error) {
+	result, err := c.caller.SingleCall(ctx, block, c.l2oo.Call(methodGetL2OutputIndexAfter, l2BlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output index after L2 block %d: %w", l2BlockNumber, err)
+	}
+	return result.GetBigInt(0), nil
+}
+
+func (c *ValidatorContracts) ChallengeStatus(ctx context.Context, block rpcblock.Block, outputIndex *big.Int, challenger common.Address) (uint8, error) {
+	result, err := c.caller.SingleCall(ctx, block, c.colosseum.Call(methodGetStatus, outputIndex, challenger))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get status of challenge of output %d: %w", outputIndex, err)
+	}
+	return result.GetUint8(0), nil
+}
+
+func (c *ValidatorContracts) Challenge(ctx context.Context, block rpcblock.Block, outputIndex *big.Int, challenger common.Address) (bindings.TypesChallenge, error) {
+	var challenge bindings.TypesChallenge
+	result, err := c.caller.SingleCall(ctx, block, c.colosseum.Call(methodGetChallenge, outputIndex, challenger))
+	if err != nil {
+		return challenge, fmt.Errorf("failed to get challenge of output %d: %w", outputIndex, err)
+	}
+	result.GetStruct(0, &challenge)
+	return challenge, nil
+}
+
+func (c *ValidatorContracts) Deposit(ctx context.Context, block rpcblock.Block, validator common.Address) (*big.Int, error) {
+	result, err := c.caller.SingleCall(ctx, block, c.valpool.Call(methodBalanceOf, validator))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch deposit amount: %w", err)
+	}
+	return result.GetBigInt(0), nil
+}
+
+// Confirmation reads the state of a SecurityCouncil transaction and of the output it is about.
+func (c *ValidatorContracts) Confirmation(ctx context.Context, block rpcblock.Block, transactionId *big.Int, outputIndex *big.Int) (*ConfirmationState, error) {
+	results, err := c.caller.Call(ctx, block,
+		c.l2oo.Call(methodIsFinalized, outputIndex),
+		c.securityCouncil.Call(methodIsConfirmed, transactionId),
+		c.securityCouncil.Call(methodTransactions, transactionId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmation of transaction %d: %w", transactionId, err)
+	}
+	return &ConfirmationState{
+		IsOutputFinalized: results[0].GetBool(0),
+		IsConfirmed:       results[1].GetBool(0),
+		// transactions returns (target, executed, value, data)
+		IsExecuted: results[2].GetBool(1),
+	}, nil
+}
+
+// VerifyProof calls the ZKVerifier at the given address to check the zk proof of a fault, before it is submitted to the Colosseum.
+func (c *ValidatorContracts) VerifyProof(ctx context.Context, block rpcblock.Block, zkVerifier common.Address, proof []*big.Int, pair []*big.Int, publicInputHash common.Hash) (bool, error) {
+	verifier := batching.NewBoundContract(c.zkVerifierABI, zkVerifier)
+	result, err := c.caller.SingleCall(ctx, block, verifier.Call(methodVerify, proof, pair, [32]byte(publicInputHash)))
+	if err != nil {
+		return false, fmt.Errorf("failed to verify proof: %w", err)
+	}
+	return result.GetBool(0), nil
+}
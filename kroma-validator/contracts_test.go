@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+	chal "github.com/kroma-network/kroma/kroma-validator/challenge"
+)
+
+var (
+	testL2OOAddr            = common.HexToAddress("0x01")
+	testColosseumAddr       = common.HexToAddress("0x02")
+	testValPoolAddr         = common.HexToAddress("0x03")
+	testSecurityCouncilAddr = common.HexToAddress("0x04")
+	testChallenger          = common.HexToAddress("0x05")
+)
+
+func setupContractsTest(t *testing.T) (*batchingTest.AbiBasedRpc, *ValidatorContracts) {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	colosseumABI, err := bindings.ColosseumMetaData.GetAbi()
+	require.NoError(t, err)
+	valpoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	require.NoError(t, err)
+	securityCouncilABI, err := bindings.SecurityCouncilMetaData.GetAbi()
+	require.NoError(t, err)
+
+	stubRpc := batchingTest.NewAbiBasedRpc(t, testL2OOAddr, l2ooABI)
+	stubRpc.AddContract(testColosseumAddr, colosseumABI)
+	stubRpc.AddContract(testValPoolAddr, valpoolABI)
+	stubRpc.AddContract(testSecurityCouncilAddr, securityCouncilABI)
+
+	contracts, err := NewValidatorContracts(stubRpc, Config{
+		L2OutputOracleAddr:  testL2OOAddr,
+		ColosseumAddr:       testColosseumAddr,
+		ValidatorPoolAddr:   testValPoolAddr,
+		SecurityCouncilAddr: testSecurityCouncilAddr,
+	})
+	require.NoError(t, err)
+	return stubRpc, contracts
+}
+
+func testOutput(index int64) bindings.TypesCheckpointOutput {
+	return bindings.TypesCheckpointOutput{
+		Submitter:     common.HexToAddress("0x10"),
+		OutputRoot:    common.BigToHash(big.NewInt(index)),
+		Timestamp:     big.NewInt(1000 + index),
+		L2BlockNumber: big.NewInt(1800 * index),
+	}
+}
+
+func setOutputResponses(stubRpc *batchingTest.AbiBasedRpc, block rpcblock.Block, index int64, finalized, inCreationPeriod bool) {
+	outputIndex := big.NewInt(index)
+	stubRpc.SetResponse(testL2OOAddr, methodGetL2Output, block, []interface{}{outputIndex}, []interface{}{testOutput(index)})
+	stubRpc.SetResponse(testL2OOAddr, methodIsFinalized, block, []interface{}{outputIndex}, []interface{}{finalized})
+	stubRpc.SetResponse(testColosseumAddr, methodIsInCreationPeriod, block, []interface{}{outputIndex}, []interface{}{inCreationPeriod})
+}
+
+func testChallenge() bindings.TypesChallenge {
+	return bindings.TypesChallenge{
+		Turn:       2,
+		TimeoutAt:  3000,
+		Asserter:   common.HexToAddress("0x10"),
+		Challenger: testChallenger,
+		Segments:   [][32]byte{{0x01}, {0x02}},
+		SegSize:    big.NewInt(1800),
+		SegStart:   big.NewInt(3600),
+	}
+}
+
+func setChallengeResponses(stubRpc *batchingTest.AbiBasedRpc, block rpcblock.Block, index int64, status uint8) {
+	args := []interface{}{big.NewInt(index), testChallenger}
+	stubRpc.SetResponse(testColosseumAddr, methodGetStatus, block, args, []interface{}{status})
+	stubRpc.SetResponse(testColosseumAddr, methodGetChallenge, block, args, []interface{}{testChallenge()})
+}
+
+func TestValidatorContractsState(t *testing.T) {
+	stubRpc, contracts := setupContractsTest(t)
+	block := rpcblock.ByNumber(100)
+	setOutputResponses(stubRpc, block, 1, true, false)
+	setOutputResponses(stubRpc, block, 2, false, true)
+	setChallengeResponses(stubRpc, block, 2, chal.StatusReadyToProve)
+
+	key := ChallengeKey{OutputIndex: 2, Challenger: testChallenger}
+	state, err := contracts.State(context.Background(), 100, []uint64{1, 2}, []ChallengeKey{key})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), state.L1Block)
+	require.Equal(t, map[uint64]*OutputState{
+		1: {Output: testOutput(1), IsFinalized: true},
+		2: {Output: testOutput(2), IsInCreationPeriod: true},
+	}, state.Outputs)
+	require.Equal(t, map[ChallengeKey]*ChallengeState{
+		key: {Status: chal.StatusReadyToProve, Challenge: testChallenge()},
+	}, state.Challenges)
+}
+
+func TestValidatorContractsConfirmation(t *testing.T) {
+	stubRpc, contracts := setupContractsTest(t)
+	txId, outputIndex := big.NewInt(7), big.NewInt(3)
+	stubRpc.SetResponse(testL2OOAddr, methodIsFinalized, rpcblock.Latest, []interface{}{outputIndex}, []interface{}{false})
+	stubRpc.SetResponse(testSecurityCouncilAddr, methodIsConfirmed, rpcblock.Latest, []interface{}{txId}, []interface{}{true})
+	stubRpc.SetResponse(testSecurityCouncilAddr, methodTransactions, rpcblock.Latest, []interface{}{txId},
+		[]interface{}{testL2OOAddr, true, big.NewInt(0), []byte{0x01}})
+
+	confirmation, err := contracts.Confirmation(context.Background(), rpcblock.Latest, txId, outputIndex)
+	require.NoError(t, err)
+	require.Equal(t, &ConfirmationState{IsConfirmed: true, IsExecuted: true}, confirmation)
+}
+
+func TestValidatorContractsVerifyProof(t *testing.T) {
+	stubRpc, contracts := setupContractsTest(t)
+	zkVerifierABI, err := bindings.ZKVerifierMetaData.GetAbi()
+	require.NoError(t, err)
+	zkVerifierAddr := common.HexToAddress("0x06")
+	stubRpc.AddContract(zkVerifierAddr, zkVerifierABI)
+
+	proof := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	pair := []*big.Int{big.NewInt(3), big.NewInt(4), big.NewInt(5), big.NewInt(6)}
+	publicInputHash := common.Hash{0x07}
+	stubRpc.SetResponse(zkVerifierAddr, methodVerify, rpcblock.Latest,
+		[]interface{}{proof, pair, [32]byte(publicInputHash)}, []interface{}{true})
+
+	verified, err := contracts.VerifyProof(context.Background(), rpcblock.Latest, zkVerifierAddr, proof, pair, publicInputHash)
+	require.NoError(t, err)
+	require.True(t, verified)
+}
+
+type stubL1BlockNumberer uint64
+
+func (n stubL1BlockNumberer) BlockNumber(context.Context) (uint64, error) {
+	return uint64(n), nil
+}
+
+func TestStatePoller(t *testing.T) {
+	stubRpc, contracts := setupContractsTest(t)
+	block := rpcblock.ByNumber(100)
+	setOutputResponses(stubRpc, block, 1, false, false)
+	setOutputResponses(stubRpc, block, 2, false, false)
+	setChallengeResponses(stubRpc, block, 2, chal.StatusChallengerTurn)
+
+	poller := NewStatePoller(testlog.Logger(t, log.LevelInfo), contracts, stubL1BlockNumberer(100), time.Hour, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go poller.Run(ctx)
+
+	untrackOutput := poller.TrackOutput(1)
+	state, output, err := poller.NextOutput(ctx, nil, 1)
+	require.NoError(t, err)
+	require.Equal(t, testOutput(1), output.Output)
+
+	key := ChallengeKey{OutputIndex: 2, Challenger: testChallenger}
+	untrackChallenge := poller.TrackChallenge(key)
+	state, challenge, output, err := poller.NextChallenge(ctx, state, key)
+	require.NoError(t, err)
+	require.Equal(t, chal.StatusChallengerTurn, challenge.Status)
+	require.Equal(t, testOutput(2), output.Output)
+	require.Contains(t, state.Outputs, uint64(1))
+
+	untrackOutput()
+	untrackChallenge()
+	poller.mu.Lock()
+	defer poller.mu.Unlock()
+	require.Empty(t, poller.outputs)
+	require.Empty(t, poller.challenges)
+}
@@ -11,6 +11,10 @@ const (
 	KeyEventOutputSubmitted  = "OutputSubmitted"
 	KeyEventChallengeCreated = "ChallengeCreated"
 	KeyEventReadyToProve     = "ReadyToProve"
+	KeyEventBisected         = "Bisected"
+
+	KeyEventValidationRequested = "ValidationRequested"
+	KeyEventDeletionRequested   = "DeletionRequested"
 )
 
 type ChallengeCreatedEvent struct {
@@ -40,3 +44,15 @@ func NewOutputSubmittedEvent(log types.Log) OutputSubmittedEvent {
 		L2BlockNumber:      new(big.Int).SetBytes(log.Topics[3][:]),
 	}
 }
+
+type BisectedEvent struct {
+	OutputIndex *big.Int
+	Challenger  common.Address
+}
+
+func NewBisectedEvent(log types.Log) BisectedEvent {
+	return BisectedEvent{
+		OutputIndex: new(big.Int).SetBytes(log.Topics[1][:]),
+		Challenger:  common.BytesToAddress(log.Topics[2][:]),
+	}
+}
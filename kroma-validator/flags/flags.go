@@ -7,10 +7,12 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -25,7 +27,7 @@ var (
 
 	L1EthRpcFlag = &cli.StringFlag{
 		Name:     "l1-eth-rpc",
-		Usage:    "Websocket provider URL for L1",
+		Usage:    "Websocket provider URL for L1. Multiple comma-separated URLs enable failover between them",
 		Required: true,
 		EnvVars:  prefixEnvVars("L1_ETH_RPC"),
 	}
@@ -42,22 +44,19 @@ var (
 		EnvVars:  prefixEnvVars("ROLLUP_RPC"),
 	}
 	L2OOAddressFlag = &cli.StringFlag{
-		Name:     "l2oo-address",
-		Usage:    "Address of the L2OutputOracle contract",
-		Required: true,
-		EnvVars:  prefixEnvVars("L2OO_ADDRESS"),
+		Name:    "l2oo-address",
+		Usage:   "Address of the L2OutputOracle contract. Required unless the selected network has it",
+		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
 	}
 	ColosseumAddressFlag = &cli.StringFlag{
-		Name:     "colosseum-address",
-		Usage:    "Address of the Colosseum contract",
-		Required: true,
-		EnvVars:  prefixEnvVars("COLOSSEUM_ADDRESS"),
+		Name:    "colosseum-address",
+		Usage:   "Address of the Colosseum contract. Required unless the selected network has it",
+		EnvVars: prefixEnvVars("COLOSSEUM_ADDRESS"),
 	}
 	ValPoolAddressFlag = &cli.StringFlag{
-		Name:     "valpool-address",
-		Usage:    "Address of the ValidatorPool contract",
-		Required: true,
-		EnvVars:  prefixEnvVars("VALPOOL_ADDRESS"),
+		Name:    "valpool-address",
+		Usage:   "Address of the ValidatorPool contract. Required unless the selected network has it",
+		EnvVars: prefixEnvVars("VALPOOL_ADDRESS"),
 	}
 	OutputSubmitterEnabledFlag = &cli.BoolFlag{
 		Name:     "output-submitter.enabled",
@@ -85,6 +84,12 @@ var (
 		Usage:   "Allow the validator to submit outputs for L2 blocks derived from non-finalized L1 blocks.",
 		EnvVars: prefixEnvVars("ALLOW_NON_FINALIZED"),
 	}
+	ChallengerL1ConfirmationsFlag = &cli.Uint64Flag{
+		Name:    "challenger.l1-confirmations",
+		Usage:   "Number of confirmations of an L1 block before the challenger and the guardian handle its events",
+		EnvVars: prefixEnvVars("CHALLENGER_L1_CONFIRMATIONS"),
+		Value:   4,
+	}
 	OutputSubmitterRetryIntervalFlag = &cli.DurationFlag{
 		Name:    "output-submitter.retry-interval",
 		Usage:   "Retry interval for output submission process",
@@ -109,7 +114,7 @@ var (
 	}
 	SecurityCouncilAddressFlag = &cli.StringFlag{
 		Name:    "securitycouncil-address",
-		Usage:   "Address of the SecurityCouncil contract",
+		Usage:   "Address of the SecurityCouncil contract. Defaults to the address of the selected network",
 		EnvVars: prefixEnvVars("SECURITYCOUNCIL_ADDRESS"),
 	}
 	GuardianEnabledFlag = &cli.BoolFlag{
@@ -123,22 +128,34 @@ var (
 		EnvVars: prefixEnvVars("FETCHING_PROOF_TIMEOUT"),
 		Value:   time.Hour * 4,
 	}
+	AttestationEnabledFlag = &cli.BoolFlag{
+		Name:    "attestation.enabled",
+		Usage:   "Enable publishing the computed output roots as signed attestations over the p2p network of the rollup node",
+		EnvVars: prefixEnvVars("ATTESTATION_ENABLED"),
+	}
+	AttestationPollIntervalFlag = &cli.DurationFlag{
+		Name:    "attestation.poll-interval",
+		Usage:   "Poll interval for checking whether a new output can be attested",
+		EnvVars: prefixEnvVars("ATTESTATION_POLL_INTERVAL"),
+		Value:   time.Second * 12,
+	}
 )
 
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	L2EthRpcFlag,
 	RollupRpcFlag,
-	L2OOAddressFlag,
-	ColosseumAddressFlag,
-	ValPoolAddressFlag,
 	OutputSubmitterEnabledFlag,
 	ChallengerEnabledFlag,
 	ChallengerPollIntervalFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	L2OOAddressFlag,
+	ColosseumAddressFlag,
+	ValPoolAddressFlag,
 	AllowNonFinalizedFlag,
+	ChallengerL1ConfirmationsFlag,
 	OutputSubmitterRetryIntervalFlag,
 	OutputSubmitterRoundBufferFlag,
 	OutputSubmitterAllowPublicRoundFlag,
@@ -146,6 +163,8 @@ var optionalFlags = []cli.Flag{
 	SecurityCouncilAddressFlag,
 	GuardianEnabledFlag,
 	FetchingProofTimeoutFlag,
+	AttestationEnabledFlag,
+	AttestationPollIntervalFlag,
 }
 
 func init() {
@@ -153,7 +172,9 @@ func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, tracing.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opflags.CLINetworkFlag(EnvVarPrefix, ""), opflags.CLINetworkRegistryFlag(EnvVarPrefix, ""))
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
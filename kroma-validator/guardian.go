@@ -14,11 +14,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/watcher"
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 )
@@ -35,17 +35,19 @@ type Guardian struct {
 	securityCouncilContract *bindings.SecurityCouncil
 	colosseumContract       *bindings.Colosseum
 	colosseumABI            *abi.ABI
+	contracts               *ValidatorContracts
+	poller                  *StatePoller
 
 	l1BlockTime               *big.Int
 	l2BlockTime               *big.Int
 	finalizationPeriodSeconds *big.Int
 	creationPeriodSeconds     *big.Int
 
-	validationRequestedSub ethereum.Subscription
-	deletionRequestedSub   ethereum.Subscription
-
-	validationRequestedChan chan *bindings.SecurityCouncilValidationRequested
-	deletionRequestedChan   chan *bindings.SecurityCouncilDeletionRequested
+	indexer *LogIndexer
+	// handling is the set of the transaction ids of the requests being handled, so a request the indexer hands again
+	// after a rollback is not handled twice at the same time.
+	handlingMu sync.Mutex
+	handling   map[string]struct{}
 
 	checkpoint *big.Int
 }
@@ -72,14 +74,30 @@ func NewGuardian(cfg Config, l log.Logger) (*Guardian, error) {
 		return nil, err
 	}
 
+	contracts, err := NewValidatorContracts(cfg.L1Client.Client(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	securityCouncilABI, err := bindings.SecurityCouncilMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	topics := []common.Hash{securityCouncilABI.Events[KeyEventValidationRequested].ID, securityCouncilABI.Events[KeyEventDeletionRequested].ID}
+
+	logger := l.New("service", "guardian")
 	return &Guardian{
-		log:                     l.New("service", "guardian"),
+		log:                     logger,
 		cfg:                     cfg,
 		securityCouncilContract: securityCouncilContract,
 		l2ooContract:            l2ooContract,
 		colosseumContract:       colosseumContract,
 		colosseumABI:            colosseumABI,
+		contracts:               contracts,
+		poller:                  NewStatePoller(logger, contracts, cfg.L1Client, time.Minute, cfg.NetworkTimeout),
+		indexer:                 NewLogIndexer(logger, cfg.L1Client, []common.Address{cfg.SecurityCouncilAddr}, topics, cfg.ChallengerL1Confirmations, cfg.ChallengerPollInterval, cfg.NetworkTimeout),
 		l1BlockTime:             big.NewInt(12),
+		handling:                make(map[string]struct{}),
 	}, nil
 }
 
@@ -133,7 +151,12 @@ func (g *Guardian) Start(ctx context.Context) error {
 	if err := g.InitConfig(g.ctx); err != nil {
 		return err
 	}
-	g.initSub()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.poller.Run(g.ctx)
+	}()
 
 	g.wg.Add(1)
 	go g.confirmationLoop()
@@ -145,43 +168,12 @@ func (g *Guardian) Start(ctx context.Context) error {
 }
 
 func (g *Guardian) Stop() error {
-	if g.validationRequestedSub != nil {
-		g.validationRequestedSub.Unsubscribe()
-	}
-
-	if g.deletionRequestedSub != nil {
-		g.deletionRequestedSub.Unsubscribe()
-	}
-
 	g.cancel()
 	g.wg.Wait()
 
-	close(g.validationRequestedChan)
-	close(g.deletionRequestedChan)
-
 	return nil
 }
 
-func (g *Guardian) initSub() {
-	opts := optsutils.NewSimpleWatchOpts(g.ctx)
-
-	g.validationRequestedChan = make(chan *bindings.SecurityCouncilValidationRequested)
-	g.validationRequestedSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
-		if err != nil {
-			g.log.Warn("resubscribing after failed ValidationRequested event", "err", err)
-		}
-		return g.securityCouncilContract.WatchValidationRequested(opts, g.validationRequestedChan, nil)
-	})
-
-	g.deletionRequestedChan = make(chan *bindings.SecurityCouncilDeletionRequested)
-	g.deletionRequestedSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
-		if err != nil {
-			g.log.Warn("resubscribing after failed DeletionRequested event", "err", err)
-		}
-		return g.securityCouncilContract.WatchDeletionRequested(opts, g.deletionRequestedChan, nil, nil)
-	})
-}
-
 // inspectorLoop finds and deletes outputs whose zk fault proving has failed due to an undeniable bug
 // among whose creation period has passed but not finalized
 func (g *Guardian) inspectorLoop() {
@@ -229,7 +221,7 @@ func (g *Guardian) inspectorLoop() {
 				func() {
 					cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
 					defer cCancel()
-					startOutputIndex, err := g.l2ooContract.GetL2OutputIndexAfter(optsutils.NewSimpleCallOpts(cCtx), finalizedL2)
+					startOutputIndex, err := g.contracts.L2OutputIndexAfter(cCtx, rpcblock.Latest, finalizedL2)
 					if err != nil {
 						g.log.Error("failed to get output index after", "err", err, "afterL2Block", finalizedL2.Uint64())
 						return
@@ -237,7 +229,7 @@ func (g *Guardian) inspectorLoop() {
 
 					cCtx, cCancel = context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
 					defer cCancel()
-					endOutputIndex, err := g.l2ooContract.GetL2OutputIndexAfter(optsutils.NewSimpleCallOpts(cCtx), creationEndedL2)
+					endOutputIndex, err := g.contracts.L2OutputIndexAfter(cCtx, rpcblock.Latest, creationEndedL2)
 					if err != nil {
 						g.log.Error("failed to get output index after", "err", err, "afterL2Block", creationEndedL2.Uint64())
 						return
@@ -254,7 +246,7 @@ func (g *Guardian) inspectorLoop() {
 				func() {
 					cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
 					defer cCancel()
-					outputIndex, err := g.l2ooContract.GetL2OutputIndexAfter(optsutils.NewSimpleCallOpts(cCtx), creationEndedL2)
+					outputIndex, err := g.contracts.L2OutputIndexAfter(cCtx, rpcblock.Latest, creationEndedL2)
 					if err != nil {
 						g.log.Error("failed to get output index after", "err", err, "afterL2Block", creationEndedL2.Uint64())
 						return
@@ -277,99 +269,132 @@ func (g *Guardian) inspectOutput(outputIndex, fromBlock, toBlock *big.Int) {
 	g.log.Info("inspect output if there is an undeniable bug", "outputIndex", outputIndex)
 	defer g.wg.Done()
 
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
+	untrack := g.poller.TrackOutput(outputIndex.Uint64())
+	defer untrack()
 
-	for ; ; <-ticker.C {
-		select {
-		case <-g.ctx.Done():
+	var state *ContractState
+	for {
+		var output *OutputState
+		var err error
+		state, output, err = g.poller.NextOutput(g.ctx, state, outputIndex.Uint64())
+		if err != nil {
 			return
-		default:
-			retry := func() bool {
-				isFinalized, err := g.isOutputFinalized(g.ctx, outputIndex)
-				if err != nil {
-					g.log.Error("unable to check if the output is finalized or not", "err", err, "outputIndex", outputIndex)
-					return true
-				}
-
-				// outputs that have been finalized are not target.
-				if isFinalized {
-					g.log.Info("the output is finalized", "outputIndex", outputIndex, "isFinalized", isFinalized)
-					return false
-				}
-
-				cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
-				defer cCancel()
-				isInCreationPeriod, err := g.colosseumContract.IsInCreationPeriod(optsutils.NewSimpleCallOpts(cCtx), outputIndex)
-				if err != nil {
-					g.log.Error("unable to check if the output is in challenge creation period or not", "err", err, "outputIndex", outputIndex)
-					return true
-				}
-
-				if isInCreationPeriod {
-					g.log.Info("the creation period of output is not passed. try again", "outputIndex", outputIndex, "isInCreationPeriod", isInCreationPeriod)
-					return true
-				}
-
-				shouldBeDeleted, err := g.shouldBeDeleted(outputIndex, fromBlock, toBlock)
-				if err != nil {
-					g.log.Error("unable to inspect the output for force deletion", "err", err, "outputIndex", outputIndex)
-					return true
-				}
-
-				if !shouldBeDeleted {
-					g.log.Info("no need to delete output forcefully", "outputIndex", outputIndex)
-					return false
-				}
-
-				tx, err := g.RequestDeletion(g.ctx, outputIndex)
-				if err != nil {
-					g.log.Error("failed to create tx for output deletion", "err", err, "outputIndex", outputIndex)
-					return true
-				}
-
-				if txResponse := g.cfg.TxManager.SendTransaction(g.ctx, tx); txResponse.Err != nil {
-					g.log.Error("failed to send deletion request tx", "err", txResponse.Err, "outputIndex", outputIndex)
-					return true
-				}
-
-				return false
-			}()
-
-			if retry {
-				continue
-			}
+		}
+
+		// outputs that have been finalized are not target.
+		if output.IsFinalized {
+			g.log.Info("the output is finalized", "outputIndex", outputIndex, "isFinalized", output.IsFinalized)
 			return
 		}
+
+		if output.IsInCreationPeriod {
+			g.log.Info("the creation period of output is not passed. try again", "outputIndex", outputIndex, "isInCreationPeriod", output.IsInCreationPeriod)
+			continue
+		}
+
+		shouldBeDeleted, err := g.shouldBeDeleted(outputIndex, output.Output, fromBlock, toBlock)
+		if err != nil {
+			g.log.Error("unable to inspect the output for force deletion", "err", err, "outputIndex", outputIndex)
+			continue
+		}
+
+		if !shouldBeDeleted {
+			g.log.Info("no need to delete output forcefully", "outputIndex", outputIndex)
+			return
+		}
+
+		tx, err := g.RequestDeletion(g.ctx, outputIndex)
+		if err != nil {
+			g.log.Error("failed to create tx for output deletion", "err", err, "outputIndex", outputIndex)
+			continue
+		}
+
+		if txResponse := g.cfg.TxManager.SendTransaction(g.ctx, tx); txResponse.Err != nil {
+			g.log.Error("failed to send deletion request tx", "err", txResponse.Err, "outputIndex", outputIndex)
+			continue
+		}
+
+		return
 	}
 }
 
 // confirmationLoop validates and sends confirm txs when multi sig tx that requires confirmation is created.
+// The requests are indexed from the confirmed L1 blocks that follow the start of the guardian.
 func (g *Guardian) confirmationLoop() {
 	defer g.wg.Done()
 
-	for {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
 		select {
-		case ev := <-g.validationRequestedChan:
-			g.wg.Add(1)
-			go g.processOutputValidation(ev)
-		case ev := <-g.deletionRequestedChan:
-			g.wg.Add(1)
-			go g.processOutputDeletion(ev)
 		case <-g.ctx.Done():
 			return
+		default:
+			cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
+			head, err := g.cfg.L1Client.HeaderByNumber(cCtx, nil)
+			cCancel()
+			if err != nil {
+				g.log.Error("failed to get L1 head", "err", err)
+				continue
+			}
+
+			// the requests in the blocks that are not confirmed yet are handled once they are confirmed
+			fromBlock := head.Number.Uint64() + 1
+			if fromBlock > g.cfg.ChallengerL1Confirmations {
+				fromBlock -= g.cfg.ChallengerL1Confirmations
+			} else {
+				fromBlock = 1
+			}
+			g.indexer.Run(g.ctx, fromBlock, g.dispatch)
+			return
 		}
 	}
 }
 
+// dispatch handles an indexed ValidationRequested or DeletionRequested event log.
+func (g *Guardian) dispatch(vLog types.Log) {
+	if ev, err := g.securityCouncilContract.ParseValidationRequested(vLog); err == nil {
+		g.handleRequest(ev.TransactionId, func() { g.processOutputValidation(ev) })
+		return
+	}
+	if ev, err := g.securityCouncilContract.ParseDeletionRequested(vLog); err == nil {
+		g.handleRequest(ev.TransactionId, func() { g.processOutputDeletion(ev) })
+		return
+	}
+	g.log.Warn("unknown event log", "logs", vLog)
+}
+
+// handleRequest processes the request with the given transaction id in the background, unless it is being handled.
+// A request handled before is processed again, as the rollback may have removed its confirmation,
+// and processing checks the confirmation first.
+func (g *Guardian) handleRequest(transactionId *big.Int, process func()) {
+	key := transactionId.String()
+	g.handlingMu.Lock()
+	defer g.handlingMu.Unlock()
+	if _, ok := g.handling[key]; ok {
+		g.log.Info("skipping request that is already being handled", "transactionId", transactionId)
+		return
+	}
+	g.handling[key] = struct{}{}
+
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			g.handlingMu.Lock()
+			delete(g.handling, key)
+			g.handlingMu.Unlock()
+			g.wg.Done()
+		}()
+		process()
+	}()
+}
+
 func (g *Guardian) processOutputValidation(event *bindings.SecurityCouncilValidationRequested) {
 	g.log.Info("processing validation of the deleted output", "l2BlockNumber", event.L2BlockNumber, "outputRoot", event.OutputRoot, "transactionId", event.TransactionId)
 
 	ticker := time.NewTicker(10 * time.Second)
-	defer func() {
-		ticker.Stop()
-		g.wg.Done()
-	}()
+	defer ticker.Stop()
 
 	for ; ; <-ticker.C {
 		select {
@@ -389,10 +414,7 @@ func (g *Guardian) processOutputDeletion(event *bindings.SecurityCouncilDeletion
 	g.log.Info("processing validation of the output to be deleted", "outputIndex", event.OutputIndex, "transactionId", event.TransactionId)
 
 	ticker := time.NewTicker(10 * time.Second)
-	defer func() {
-		ticker.Stop()
-		g.wg.Done()
-	}()
+	defer ticker.Stop()
 
 	for ; ; <-ticker.C {
 		select {
@@ -457,7 +479,7 @@ func (g *Guardian) tryConfirmRequestDeletionTx(event *bindings.SecurityCouncilDe
 
 	cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
 	defer cCancel()
-	output, err := g.l2ooContract.GetL2Output(optsutils.NewSimpleCallOpts(cCtx), event.OutputIndex)
+	output, err := g.contracts.Output(cCtx, rpcblock.Latest, event.OutputIndex)
 	if err != nil {
 		return fmt.Errorf("failed to get output from L2OutputOracle contract(outputIndex: %d): %w", event.OutputIndex.Uint64(), err)
 	}
@@ -485,31 +507,21 @@ func (g *Guardian) tryConfirmRequestDeletionTx(event *bindings.SecurityCouncilDe
 }
 
 func (g *Guardian) CheckConfirmCondition(ctx context.Context, transactionId *big.Int, outputIndex *big.Int) (bool, error) {
-	outputFinalized, err := g.isOutputFinalized(ctx, outputIndex)
+	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
+	defer cCancel()
+	confirmation, err := g.contracts.Confirmation(cCtx, rpcblock.Latest, transactionId, outputIndex)
 	if err != nil {
-		return true, fmt.Errorf("failed to get if output is finalized. (outputIndex: %d): %w", outputIndex.Int64(), err)
+		return true, fmt.Errorf("failed to get confirmation. (transactionId: %d, outputIndex: %d): %w", transactionId.Int64(), outputIndex.Int64(), err)
 	}
-	if outputFinalized {
+	if confirmation.IsOutputFinalized {
 		g.log.Info("output is already finalized", "outputIndex", outputIndex)
 		return false, nil
 	}
-
-	isConfirmed, err := g.isTransactionConfirmed(ctx, transactionId)
-	if err != nil {
-		return true, fmt.Errorf("failed to get confirmation. (transactionId: %d): %w", transactionId.Int64(), err)
-	}
-	if isConfirmed {
+	if confirmation.IsConfirmed {
 		g.log.Info("transaction is already confirmed", "transactionId", transactionId)
 		return false, nil
 	}
-
-	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
-	defer cCancel()
-	executionTx, err := g.securityCouncilContract.Transactions(optsutils.NewSimpleCallOpts(cCtx), transactionId)
-	if err != nil {
-		return true, fmt.Errorf("failed to get transaction with transactionId %d: %w", transactionId.Int64(), err)
-	}
-	if executionTx.Executed {
+	if confirmation.IsExecuted {
 		g.log.Info("transaction is already executed", "transactionId", transactionId)
 		return false, nil
 	}
@@ -520,7 +532,7 @@ func (g *Guardian) CheckConfirmCondition(ctx context.Context, transactionId *big
 // shouldBeDeleted checks the output should have been deleted or not.
 // It finds the output of the challenge that triggered the ReadyToProve event
 // and compares it to the local output of the guardian.
-func (g *Guardian) shouldBeDeleted(outputIndex, fromBlock, toBlock *big.Int) (bool, error) {
+func (g *Guardian) shouldBeDeleted(outputIndex *big.Int, output bindings.TypesCheckpointOutput, fromBlock, toBlock *big.Int) (bool, error) {
 	readyToProveEvent := g.colosseumABI.Events[KeyEventReadyToProve]
 	addresses := []common.Address{g.cfg.ColosseumAddr}
 	eventIDTopic := []common.Hash{readyToProveEvent.ID}
@@ -542,13 +554,6 @@ func (g *Guardian) shouldBeDeleted(outputIndex, fromBlock, toBlock *big.Int) (bo
 		return false, nil
 	}
 
-	cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
-	defer cCancel()
-	output, err := g.l2ooContract.GetL2Output(optsutils.NewSimpleCallOpts(cCtx), outputIndex)
-	if err != nil {
-		return false, fmt.Errorf("failed to get output from L2OutputOracle contract(outputIndex: %d): %w", outputIndex.Uint64(), err)
-	}
-
 	if IsOutputDeleted(output.OutputRoot) {
 		g.log.Info("output has already been deleted", "outputIndex", outputIndex)
 		return false, nil
@@ -597,17 +602,5 @@ func (g *Guardian) OutputRootAtBlock(ctx context.Context, l2BlockNumber uint64)
 func (g *Guardian) getL2OutputIndexAfter(l2BlockNumber *big.Int) (*big.Int, error) {
 	cCtx, cCancel := context.WithTimeout(g.ctx, g.cfg.NetworkTimeout)
 	defer cCancel()
-	return g.l2ooContract.GetL2OutputIndexAfter(optsutils.NewSimpleCallOpts(cCtx), l2BlockNumber)
-}
-
-func (g *Guardian) isOutputFinalized(ctx context.Context, outputIndex *big.Int) (bool, error) {
-	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
-	defer cCancel()
-	return g.l2ooContract.IsFinalized(optsutils.NewSimpleCallOpts(cCtx), outputIndex)
-}
-
-func (g *Guardian) isTransactionConfirmed(ctx context.Context, transactionId *big.Int) (bool, error) {
-	cCtx, cCancel := context.WithTimeout(ctx, g.cfg.NetworkTimeout)
-	defer cCancel()
-	return g.securityCouncilContract.IsConfirmed(optsutils.NewSimpleCallOpts(cCtx), transactionId)
+	return g.contracts.L2OutputIndexAfter(cCtx, rpcblock.Latest, l2BlockNumber)
 }
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+func TestGuardianDispatchAfterRollback(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	securityCouncil, err := bindings.NewSecurityCouncil(common.Address{}, nil)
+	require.NoError(t, err)
+	securityCouncilABI, err := bindings.SecurityCouncilMetaData.GetAbi()
+	require.NoError(t, err)
+	// the processing of the requests stops right away
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g := &Guardian{
+		log:                     logger,
+		ctx:                     ctx,
+		securityCouncilContract: securityCouncil,
+		handling:                make(map[string]struct{}),
+	}
+
+	transactionId := big.NewInt(7)
+	vLog := types.Log{
+		Topics: []common.Hash{
+			securityCouncilABI.Events[KeyEventDeletionRequested].ID,
+			common.BigToHash(transactionId),
+			common.BigToHash(big.NewInt(3)),
+		},
+	}
+	skipped := testlog.NewMessageFilter("skipping request that is already being handled")
+	processed := testlog.NewMessageFilter("processing validation of the output to be deleted")
+
+	// the indexer rolls back and hands the log again, while the request is still being handled
+	release := make(chan struct{})
+	g.handleRequest(transactionId, func() { <-release })
+	g.dispatch(vLog)
+	require.NotNil(t, logs.FindLog(skipped))
+	require.Nil(t, logs.FindLog(processed))
+	close(release)
+	g.wg.Wait()
+
+	// once it is handled, the request is processed again, as the rollback may have removed the confirmation
+	logs.Clear()
+	g.dispatch(vLog)
+	g.wg.Wait()
+	require.Nil(t, logs.FindLog(skipped))
+	require.NotNil(t, logs.FindLog(processed))
+}
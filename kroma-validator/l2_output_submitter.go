@@ -15,9 +15,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/op-service/watcher"
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
@@ -200,7 +203,10 @@ func (l *L2OutputSubmitter) trySubmitL2Output(ctx context.Context) (time.Duratio
 }
 
 // doSubmitL2Output submits l2 Output submission transaction.
-func (l *L2OutputSubmitter) doSubmitL2Output(ctx context.Context, nextBlockNumber *big.Int) error {
+func (l *L2OutputSubmitter) doSubmitL2Output(ctx context.Context, nextBlockNumber *big.Int) (err error) {
+	ctx, span := tracer.Start(ctx, "validator.submitOutput", trace.WithAttributes(attribute.Int64("l2_block_number", nextBlockNumber.Int64())))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	output, err := l.FetchOutput(ctx, nextBlockNumber)
 	if err != nil {
 		return err
@@ -211,7 +217,7 @@ func (l *L2OutputSubmitter) doSubmitL2Output(ctx context.Context, nextBlockNumbe
 		return fmt.Errorf("failed to create submit l2 output transaction data: %w", err)
 	}
 
-	if txResponse := l.submitL2OutputTx(data); txResponse.Err != nil {
+	if txResponse := l.submitL2OutputTx(ctx, data); txResponse.Err != nil {
 		return txResponse.Err
 	}
 
@@ -422,7 +428,7 @@ func SubmitL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, err
 }
 
 // submitL2OutputTx creates l2 output submit tx candidate and sends it to txCandidates channel to process validator's tx candidates in order.
-func (l *L2OutputSubmitter) submitL2OutputTx(data []byte) *txmgr.TxResponse {
+func (l *L2OutputSubmitter) submitL2OutputTx(ctx context.Context, data []byte) *txmgr.TxResponse {
 	layout, err := bindings.GetStorageLayout("ValidatorPool")
 	if err != nil {
 		return &txmgr.TxResponse{
@@ -452,7 +458,7 @@ func (l *L2OutputSubmitter) submitL2OutputTx(data []byte) *txmgr.TxResponse {
 	}
 
 	// Do the gas estimation and set 150% of it to gas limit to prevent tx failed because of dynamic gas usage in unbond and priority validator selection
-	gasTipCap, baseFee, _, err := l.cfg.TxManager.SuggestGasPriceCaps(l.ctx)
+	gasTipCap, baseFee, _, err := l.cfg.TxManager.SuggestGasPriceCaps(ctx)
 	if err != nil {
 		return &txmgr.TxResponse{
 			Receipt: nil,
@@ -462,7 +468,7 @@ func (l *L2OutputSubmitter) submitL2OutputTx(data []byte) *txmgr.TxResponse {
 	gasFeeCap := txmgr.CalcGasFeeCap(baseFee, gasTipCap)
 
 	to := &l.cfg.L2OutputOracleAddr
-	estimatedGas, err := l.cfg.L1Client.EstimateGas(l.ctx, ethereum.CallMsg{
+	estimatedGas, err := l.cfg.L1Client.EstimateGas(ctx, ethereum.CallMsg{
 		From:      l.cfg.TxManager.From(),
 		To:        to,
 		GasFeeCap: gasFeeCap,
@@ -476,7 +482,7 @@ func (l *L2OutputSubmitter) submitL2OutputTx(data []byte) *txmgr.TxResponse {
 		}
 	}
 
-	return l.cfg.TxManager.SendTxCandidate(l.ctx, &txmgr.TxCandidate{
+	return l.cfg.TxManager.SendTxCandidate(ctx, &txmgr.TxCandidate{
 		TxData:     data,
 		To:         to,
 		GasLimit:   estimatedGas * 3 / 2,
@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// maxLogRange is the maximum number of blocks the logs are fetched for at once.
+	maxLogRange = 1000
+	// maxIndexedRanges is the number of the last indexed ranges that are kept to find the block to roll back to.
+	maxIndexedRanges = 64
+)
+
+// LogIndexerClient is the L1 client the LogIndexer fetches headers and logs with.
+type LogIndexerClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// indexedRange is a range of blocks whose logs are handed over.
+type indexedRange struct {
+	from uint64
+	last eth.BlockID
+}
+
+// LogIndexer polls the logs of a set of contracts with eth_getLogs, and hands them over in order.
+// It only indexes blocks that have the given number of confirmations, and keeps the last block
+// of each indexed range so that an L1 reorg that removes indexed blocks is detected. When that happens,
+// it rolls back to the last indexed block that is still canonical, and hands over the logs of the new blocks.
+type LogIndexer struct {
+	log           log.Logger
+	client        LogIndexerClient
+	addresses     []common.Address
+	topics        []common.Hash
+	confirmations uint64
+	interval      time.Duration
+	timeout       time.Duration
+
+	// next is the number of the next block to index.
+	next uint64
+	// indexed are the last indexed ranges, in order.
+	indexed []indexedRange
+}
+
+func NewLogIndexer(l log.Logger, client LogIndexerClient, addresses []common.Address, topics []common.Hash, confirmations uint64, interval, timeout time.Duration) *LogIndexer {
+	return &LogIndexer{
+		log:           l,
+		client:        client,
+		addresses:     addresses,
+		topics:        topics,
+		confirmations: confirmations,
+		interval:      interval,
+		timeout:       timeout,
+	}
+}
+
+// Run indexes the blocks from the given block until the context is done, and calls handle with each log.
+func (x *LogIndexer) Run(ctx context.Context, from uint64, handle func(types.Log)) {
+	x.next = from
+	x.indexed = nil
+
+	ticker := time.NewTicker(x.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := x.step(ctx, handle); err != nil {
+			x.log.Error("failed to index logs", "next", x.next, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step indexes the confirmed blocks that are not indexed yet.
+func (x *LogIndexer) step(ctx context.Context, handle func(types.Log)) error {
+	head, err := x.header(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	if head.Number.Uint64() < x.confirmations {
+		return nil
+	}
+	target := head.Number.Uint64() - x.confirmations
+
+	if err := x.rollback(ctx); err != nil {
+		return err
+	}
+
+	for x.next <= target {
+		to := min(target, x.next+maxLogRange-1)
+		header, err := x.header(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return fmt.Errorf("failed to get L1 block %d: %w", to, err)
+		}
+		logs, err := x.filterLogs(ctx, x.next, to)
+		if err != nil {
+			return err
+		}
+		for _, vLog := range logs {
+			// the logs of the last block are from another chain than the header if a reorg happened in between,
+			// so retry the whole range later
+			if vLog.BlockNumber == to && vLog.BlockHash != header.Hash() {
+				return fmt.Errorf("L1 block %d was reorged while indexing", to)
+			}
+		}
+		for _, vLog := range logs {
+			if vLog.Removed {
+				continue
+			}
+			handle(vLog)
+		}
+
+		x.indexed = append(x.indexed, indexedRange{from: x.next, last: eth.BlockID{Hash: header.Hash(), Number: to}})
+		if len(x.indexed) > maxIndexedRanges {
+			x.indexed = x.indexed[1:]
+		}
+		x.next = to + 1
+	}
+	return nil
+}
+
+// rollback moves the next block to index back to the block after the last indexed block that is still canonical.
+func (x *LogIndexer) rollback(ctx context.Context) error {
+	for i := len(x.indexed) - 1; i >= 0; i-- {
+		block := x.indexed[i].last
+		header, err := x.header(ctx, new(big.Int).SetUint64(block.Number))
+		if err != nil {
+			return fmt.Errorf("failed to get L1 block %d: %w", block.Number, err)
+		}
+		if header.Hash() == block.Hash {
+			if i < len(x.indexed)-1 {
+				x.log.Warn("rolled back indexed logs due to L1 reorg", "from", x.next-1, "to", block.Number)
+				x.indexed = x.indexed[:i+1]
+				x.next = block.Number + 1
+			}
+			return nil
+		}
+	}
+	if len(x.indexed) > 0 {
+		// none of the kept blocks is canonical, so index again from the oldest kept range
+		from := x.indexed[0].from
+		x.log.Error("L1 reorg is deeper than the indexed blocks that are kept, indexing them again", "from", from)
+		x.indexed = nil
+		x.next = from
+	}
+	return nil
+}
+
+func (x *LogIndexer) header(ctx context.Context, number *big.Int) (*types.Header, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, x.timeout)
+	defer cCancel()
+	return x.client.HeaderByNumber(cCtx, number)
+}
+
+func (x *LogIndexer) filterLogs(ctx context.Context, from, to uint64) ([]types.Log, error) {
+	cCtx, cCancel := context.WithTimeout(ctx, x.timeout)
+	defer cCancel()
+	logs, err := x.client.FilterLogs(cCtx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: x.addresses,
+		Topics:    [][]common.Hash{x.topics},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs from L1 block %d to %d: %w", from, to, err)
+	}
+	return logs, nil
+}
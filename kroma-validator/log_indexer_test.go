@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubL1Chain is a chain of headers with at most one log per block.
+type stubL1Chain struct {
+	headers []*types.Header
+	logs    map[common.Hash]types.Log
+}
+
+func newStubL1Chain() *stubL1Chain {
+	return &stubL1Chain{
+		headers: []*types.Header{{Number: big.NewInt(0)}},
+		logs:    make(map[common.Hash]types.Log),
+	}
+}
+
+// extend adds a block on top of the block of the given number, removing the blocks after it.
+func (c *stubL1Chain) extend(parent uint64, withLog bool, extra byte) {
+	c.headers = c.headers[:parent+1]
+	header := &types.Header{
+		ParentHash: c.headers[parent].Hash(),
+		Number:     new(big.Int).SetUint64(parent + 1),
+		Extra:      []byte{extra},
+	}
+	c.headers = append(c.headers, header)
+	if withLog {
+		c.logs[header.Hash()] = types.Log{
+			Address:     testColosseumAddr,
+			Topics:      []common.Hash{{0x01}},
+			BlockNumber: header.Number.Uint64(),
+			BlockHash:   header.Hash(),
+		}
+	}
+}
+
+func (c *stubL1Chain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *stubL1Chain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for i := q.FromBlock.Uint64(); i <= q.ToBlock.Uint64(); i++ {
+		if l, ok := c.logs[c.headers[i].Hash()]; ok {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func TestLogIndexer(t *testing.T) {
+	chain := newStubL1Chain()
+	for i := uint64(0); i < 10; i++ {
+		chain.extend(i, true, 0)
+	}
+
+	indexer := NewLogIndexer(testlog.Logger(t, log.LevelInfo), chain, nil, nil, 2, time.Second, time.Second)
+	indexer.next = 3
+
+	var handled []uint64
+	handle := func(l types.Log) {
+		require.Equal(t, chain.headers[l.BlockNumber].Hash(), l.BlockHash)
+		handled = append(handled, l.BlockNumber)
+	}
+
+	// only the blocks with enough confirmations are indexed
+	require.NoError(t, indexer.step(context.Background(), handle))
+	require.Equal(t, []uint64{3, 4, 5, 6, 7, 8}, handled)
+	require.Equal(t, uint64(9), indexer.next)
+
+	// a reorg below the confirmation depth does not roll back
+	chain.extend(9, true, 1)
+	chain.extend(10, false, 1)
+	handled = nil
+	require.NoError(t, indexer.step(context.Background(), handle))
+	require.Equal(t, []uint64{9}, handled)
+
+	chain.extend(11, true, 1)
+	handled = nil
+	require.NoError(t, indexer.step(context.Background(), handle))
+	require.Equal(t, []uint64{10}, handled)
+
+	// a reorg of indexed blocks rolls back to the last indexed block that is still canonical
+	chain.extend(8, false, 2)
+	for i := uint64(9); i < 13; i++ {
+		chain.extend(i, true, 2)
+	}
+	handled = nil
+	require.NoError(t, indexer.step(context.Background(), handle))
+	require.Equal(t, []uint64{10, 11}, handled)
+	require.Equal(t, uint64(12), indexer.next)
+}
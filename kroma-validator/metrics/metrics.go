@@ -18,6 +18,14 @@ import (
 const (
 	Namespace         = "kroma_validator"
 	L2OutputSubmitted = "submitted"
+	L2OutputAttested  = "attested"
+
+	// results of the local verification of a fault proof
+	ProofValid       = "valid"
+	ProofInvalid     = "invalid"
+	ProofMalformed   = "malformed"
+	ProofFetchError  = "fetch_error"
+	ProofVerifyError = "verify_error"
 )
 
 type Metricer interface {
@@ -31,9 +39,11 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	RecordL2OutputSubmitted(l2ref eth.L2BlockRef)
+	RecordL2OutputAttested(l2ref eth.L2BlockRef)
 	RecordDepositAmount(amount *big.Int)
 	RecordNextValidator(address common.Address)
 	RecordChallengeCheckpoint(outputIndex *big.Int)
+	RecordProofVerification(result string)
 }
 
 type Metrics struct {
@@ -50,6 +60,7 @@ type Metrics struct {
 	DepositAmount       prometheus.Gauge
 	NextValidator       prometheus.GaugeVec
 	ChallengeCheckpoint prometheus.Gauge
+	ProofVerifications  prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -101,6 +112,13 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "challenge_checkpoint",
 			Help:      "The output index that the challenge function last checked",
 		}),
+		ProofVerifications: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proof_verifications_total",
+			Help:      "Number of fault proofs verified locally before submission, by result",
+		}, []string{
+			"result",
+		}),
 	}
 }
 
@@ -135,6 +153,11 @@ func (m *Metrics) RecordL2OutputSubmitted(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(L2OutputSubmitted, l2ref)
 }
 
+// RecordL2OutputAttested should be called when an output attestation is published
+func (m *Metrics) RecordL2OutputAttested(l2ref eth.L2BlockRef) {
+	m.RecordL2Ref(L2OutputAttested, l2ref)
+}
+
 // RecordDepositAmount sets the amount deposited into the ValidatorPool contract.
 func (m *Metrics) RecordDepositAmount(amount *big.Int) {
 	m.DepositAmount.Set(opmetrics.WeiToEther(amount))
@@ -149,3 +172,8 @@ func (m *Metrics) RecordNextValidator(address common.Address) {
 func (m *Metrics) RecordChallengeCheckpoint(outputIndex *big.Int) {
 	m.ChallengeCheckpoint.Set(float64(outputIndex.Uint64()))
 }
+
+// RecordProofVerification counts a local verification of a fault proof with its result.
+func (m *Metrics) RecordProofVerification(result string) {
+	m.ProofVerifications.WithLabelValues(result).Inc()
+}
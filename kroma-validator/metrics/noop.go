@@ -21,6 +21,8 @@ func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordL2OutputSubmitted(l2ref eth.L2BlockRef)   {}
+func (*noopMetrics) RecordL2OutputAttested(l2ref eth.L2BlockRef)    {}
 func (*noopMetrics) RecordDepositAmount(amount *big.Int)            {}
 func (*noopMetrics) RecordNextValidator(address common.Address)     {}
 func (*noopMetrics) RecordChallengeCheckpoint(outputIndex *big.Int) {}
+func (*noopMetrics) RecordProofVerification(result string)          {}
@@ -0,0 +1,227 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// L1BlockNumberer returns the number of the latest L1 block.
+type L1BlockNumberer interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// StatePoller polls the state of the outputs and challenges that are tracked by the handlers of the validator.
+// Each poll reads all of them in a few batched calls pinned to the latest L1 block,
+// instead of each handler reading its own output or challenge one call at a time.
+type StatePoller struct {
+	log          log.Logger
+	contracts    *ValidatorContracts
+	l1           L1BlockNumberer
+	interval     time.Duration
+	timeout      time.Duration
+	pollTrigger  chan struct{}
+	mu           sync.Mutex
+	outputs      map[uint64]int
+	challenges   map[ChallengeKey]int
+	state        *ContractState
+	stateUpdated chan struct{}
+}
+
+func NewStatePoller(l log.Logger, contracts *ValidatorContracts, l1 L1BlockNumberer, interval, timeout time.Duration) *StatePoller {
+	return &StatePoller{
+		log:          l,
+		contracts:    contracts,
+		l1:           l1,
+		interval:     interval,
+		timeout:      timeout,
+		pollTrigger:  make(chan struct{}, 1),
+		outputs:      make(map[uint64]int),
+		challenges:   make(map[ChallengeKey]int),
+		stateUpdated: make(chan struct{}),
+	}
+}
+
+// Run polls until the context is done.
+func (p *StatePoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.poll(ctx); err != nil {
+			p.log.Error("failed to poll contract state", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-p.pollTrigger:
+		}
+	}
+}
+
+func (p *StatePoller) poll(ctx context.Context) error {
+	p.mu.Lock()
+	outputs := make([]uint64, 0, len(p.outputs))
+	for index := range p.outputs {
+		outputs = append(outputs, index)
+	}
+	challenges := make([]ChallengeKey, 0, len(p.challenges))
+	for key := range p.challenges {
+		challenges = append(challenges, key)
+	}
+	p.mu.Unlock()
+
+	if len(outputs) == 0 && len(challenges) == 0 {
+		return nil
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, p.timeout)
+	defer cCancel()
+	l1Block, err := p.l1.BlockNumber(cCtx)
+	if err != nil {
+		return err
+	}
+	state, err := p.contracts.State(cCtx, l1Block, outputs, challenges)
+	if err != nil && len(outputs)+len(challenges) > 1 {
+		// an output or challenge that cannot be read, e.g. an output removed by an L1 reorg, fails the whole batch,
+		// so read them one by one to not hold back the others.
+		p.log.Warn("failed to read contract state in batch, reading one by one", "err", err)
+		state, err = p.stateOneByOne(cCtx, l1Block, outputs, challenges)
+	}
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state != nil {
+		state.seq = p.state.seq + 1
+	} else {
+		state.seq = 1
+	}
+	p.state = state
+	close(p.stateUpdated)
+	p.stateUpdated = make(chan struct{})
+	return nil
+}
+
+func (p *StatePoller) stateOneByOne(ctx context.Context, l1Block uint64, outputs []uint64, challenges []ChallengeKey) (*ContractState, error) {
+	merged := &ContractState{
+		L1Block:    l1Block,
+		Outputs:    make(map[uint64]*OutputState, len(outputs)),
+		Challenges: make(map[ChallengeKey]*ChallengeState, len(challenges)),
+	}
+	for _, index := range outputs {
+		state, err := p.contracts.State(ctx, l1Block, []uint64{index}, nil)
+		if err != nil {
+			p.log.Error("failed to read output state", "outputIndex", index, "err", err)
+			continue
+		}
+		merged.Outputs[index] = state.Outputs[index]
+	}
+	for _, key := range challenges {
+		state, err := p.contracts.State(ctx, l1Block, nil, []ChallengeKey{key})
+		if err != nil {
+			p.log.Error("failed to read challenge state", "outputIndex", key.OutputIndex, "challenger", key.Challenger, "err", err)
+			continue
+		}
+		merged.Challenges[key] = state.Challenges[key]
+	}
+	if len(merged.Outputs) == 0 && len(merged.Challenges) == 0 {
+		return nil, errors.New("failed to read any output or challenge")
+	}
+	return merged, nil
+}
+
+// TrackOutput adds the output to the next polls, until the returned function is called.
+func (p *StatePoller) TrackOutput(outputIndex uint64) (untrack func()) {
+	p.mu.Lock()
+	p.outputs[outputIndex]++
+	p.mu.Unlock()
+	p.triggerPoll()
+
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.outputs[outputIndex]--; p.outputs[outputIndex] <= 0 {
+			delete(p.outputs, outputIndex)
+		}
+	}
+}
+
+// TrackChallenge adds the challenge and its output to the next polls, until the returned function is called.
+func (p *StatePoller) TrackChallenge(key ChallengeKey) (untrack func()) {
+	p.mu.Lock()
+	p.outputs[key.OutputIndex]++
+	p.challenges[key]++
+	p.mu.Unlock()
+	p.triggerPoll()
+
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.outputs[key.OutputIndex]--; p.outputs[key.OutputIndex] <= 0 {
+			delete(p.outputs, key.OutputIndex)
+		}
+		if p.challenges[key]--; p.challenges[key] <= 0 {
+			delete(p.challenges, key)
+		}
+	}
+}
+
+func (p *StatePoller) triggerPoll() {
+	select {
+	case p.pollTrigger <- struct{}{}:
+	default:
+	}
+}
+
+// Next waits for a state polled after the given state, nil for any state.
+func (p *StatePoller) Next(ctx context.Context, prev *ContractState) (*ContractState, error) {
+	for {
+		p.mu.Lock()
+		state, updated := p.state, p.stateUpdated
+		p.mu.Unlock()
+		if state != nil && (prev == nil || state.seq > prev.seq) {
+			return state, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// NextOutput waits for a state polled after the given state that has the tracked output.
+func (p *StatePoller) NextOutput(ctx context.Context, prev *ContractState, outputIndex uint64) (*ContractState, *OutputState, error) {
+	for {
+		state, err := p.Next(ctx, prev)
+		if err != nil {
+			return nil, nil, err
+		}
+		if output, ok := state.Outputs[outputIndex]; ok {
+			return state, output, nil
+		}
+		prev = state
+	}
+}
+
+// NextChallenge waits for a state polled after the given state that has the tracked challenge.
+func (p *StatePoller) NextChallenge(ctx context.Context, prev *ContractState, key ChallengeKey) (*ContractState, *ChallengeState, *OutputState, error) {
+	for {
+		state, err := p.Next(ctx, prev)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		challenge, ok := state.Challenges[key]
+		if output, hasOutput := state.Outputs[key.OutputIndex]; ok && hasOutput {
+			return state, challenge, output, nil
+		}
+		prev = state
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 	"github.com/ethereum-optimism/optimism/op-service/optsutils"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 
 	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 	"github.com/kroma-network/kroma/kroma-validator/flags"
@@ -47,9 +48,23 @@ func Main(version string, cliCtx *cli.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	stopTracing, err := tracing.Start(cfg.TracingConfig, "kroma-validator", version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stopTracing(context.Background()); err != nil {
+			l.Error("Error shutting down tracing", "err", err)
+		}
+	}()
+
 	monitoring.MaybeStartPprof(ctx, cfg.PprofConfig, l)
 	monitoring.MaybeStartMetrics(ctx, cfg.MetricsConfig, l, m, validatorCfg.L1Client, validatorCfg.TxManager.From())
-	server, err := monitoring.StartRPC(cfg.RPCConfig, version, oprpc.WithLogger(l))
+	policyOpt, err := cfg.RPCConfig.PolicyOption(m)
+	if err != nil {
+		return err
+	}
+	server, err := monitoring.StartRPC(cfg.RPCConfig, version, oprpc.WithLogger(l), policyOpt)
 	if err != nil {
 		return err
 	}
@@ -90,6 +105,7 @@ type Validator struct {
 	l2os       *L2OutputSubmitter
 	challenger *Challenger
 	guardian   *Guardian
+	attester   *OutputAttester
 
 	l2ooContract *bindings.L2OutputOracleCaller
 }
@@ -122,6 +138,14 @@ func NewValidator(cfg Config, l log.Logger, m metrics.Metricer) (*Validator, err
 		}
 	}
 
+	var attester *OutputAttester
+	if cfg.AttestationEnabled {
+		attester, err = NewOutputAttester(cfg, l, m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
 	if err != nil {
 		return nil, err
@@ -134,13 +158,14 @@ func NewValidator(cfg Config, l log.Logger, m metrics.Metricer) (*Validator, err
 		l2os:         l2os,
 		challenger:   challenger,
 		guardian:     guardian,
+		attester:     attester,
 		l2ooContract: l2ooContract,
 	}, nil
 }
 
 func (v *Validator) Start() error {
 	v.ctx, v.cancel = context.WithCancel(context.Background())
-	v.l.Info("starting Validator", "outputSubmitter", v.cfg.OutputSubmitterEnabled, "challenger", v.cfg.ChallengerEnabled, "guardian", v.cfg.GuardianEnabled)
+	v.l.Info("starting Validator", "outputSubmitter", v.cfg.OutputSubmitterEnabled, "challenger", v.cfg.ChallengerEnabled, "guardian", v.cfg.GuardianEnabled, "attestation", v.cfg.AttestationEnabled)
 
 	// wait for kroma node to sync completed
 	v.waitSyncCompleted()
@@ -167,6 +192,12 @@ func (v *Validator) Start() error {
 		}
 	}
 
+	if v.cfg.AttestationEnabled {
+		if err := v.attester.Start(v.ctx); err != nil {
+			return fmt.Errorf("cannot start output attester: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -194,6 +225,15 @@ func (v *Validator) Stop() error {
 		}
 	}
 
+	if v.cfg.AttestationEnabled {
+		if err := v.attester.Stop(); err != nil {
+			return fmt.Errorf("failed to stop output attester: %w", err)
+		}
+		if err := v.cfg.AttestationSigner.Close(); err != nil {
+			return fmt.Errorf("failed to close attestation signer: %w", err)
+		}
+	}
+
 	v.cancel()
 
 	return nil
@@ -11,10 +11,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -82,12 +84,23 @@ type CLIConfig struct {
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
 
+	// [Kroma: START]
+	// Network is the name of the network the rollup node is expected to run, empty to accept any.
+	Network string
+
+	// NetworkRegistry is the directory of additional networks to select from.
+	NetworkRegistry string
+	// [Kroma: END]
+
 	TxMgrConfig   txmgr.CLIConfig
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 	RPC           oprpc.CLIConfig
 	PlasmaDA      plasma.CLIConfig
+	// [Kroma: START]
+	Tracing tracing.CLIConfig
+	// [Kroma: END]
 }
 
 func (c *CLIConfig) Check() error {
@@ -136,6 +149,11 @@ func (c *CLIConfig) Check() error {
 	if err := c.RPC.Check(); err != nil {
 		return err
 	}
+	// [Kroma: START]
+	if err := c.Tracing.Check(); err != nil {
+		return err
+	}
+	// [Kroma: END]
 	return nil
 }
 
@@ -166,5 +184,10 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		PprofConfig:                  oppprof.ReadCLIConfig(ctx),
 		RPC:                          oprpc.ReadCLIConfig(ctx),
 		PlasmaDA:                     plasma.ReadCLIConfig(ctx),
+		// [Kroma: START]
+		Tracing:         tracing.ReadCLIConfig(ctx),
+		Network:         ctx.String(opflags.NetworkFlagName),
+		NetworkRegistry: ctx.String(opflags.NetworkRegistryFlagName),
+		// [Kroma: END]
 	}
 }
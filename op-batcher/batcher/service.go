@@ -26,6 +26,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -68,6 +69,9 @@ type BatcherService struct {
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 	rpcServer    *oprpc.Server
+	// [Kroma: START]
+	stopTracing func(ctx context.Context) error
+	// [Kroma: END]
 
 	balanceMetricer io.Closer
 	stopped         atomic.Bool
@@ -91,6 +95,14 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.Log = log
 	bs.NotSubmittingOnStart = cfg.Stopped
 
+	// [Kroma: START]
+	stopTracing, err := tracing.Start(cfg.Tracing, "op-batcher", version)
+	if err != nil {
+		return fmt.Errorf("failed to start tracing: %w", err)
+	}
+	bs.stopTracing = stopTracing
+	// [Kroma: END]
+
 	bs.initMetrics(cfg)
 
 	bs.PollInterval = cfg.PollInterval
@@ -99,7 +111,7 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
-	if err := bs.initRollupConfig(ctx); err != nil {
+	if err := bs.initRollupConfig(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to load rollup config: %w", err)
 	}
 	if err := bs.initChannelConfig(cfg); err != nil {
@@ -168,7 +180,7 @@ func (bs *BatcherService) initBalanceMonitor(cfg *CLIConfig) {
 	}
 }
 
-func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
+func (bs *BatcherService) initRollupConfig(ctx context.Context, cfg *CLIConfig) error {
 	rollupNode, err := bs.EndpointProvider.RollupClient(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve rollup client: %w", err)
@@ -181,10 +193,36 @@ func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
 	if err := bs.RollupConfig.Check(); err != nil {
 		return fmt.Errorf("invalid rollup config: %w", err)
 	}
+	// [Kroma: START]
+	if cfg.Network != "" {
+		network, err := chaincfg.LoadNetwork(cfg.NetworkRegistry, cfg.Network)
+		if err != nil {
+			return err
+		}
+		if err := checkNetwork(network, bs.RollupConfig); err != nil {
+			return err
+		}
+	}
+	// [Kroma: END]
 	bs.RollupConfig.LogDescription(bs.Log, chaincfg.L2ChainIDToNetworkDisplayName)
 	return nil
 }
 
+// [Kroma: START]
+// checkNetwork checks that the rollup node runs the selected network,
+// so that the batches are not submitted to the batch inbox of another chain.
+func checkNetwork(network *chaincfg.Network, rollupConfig *rollup.Config) error {
+	if network.Rollup.L2ChainID.Cmp(rollupConfig.L2ChainID) != 0 {
+		return fmt.Errorf("rollup node runs L2 chain %s, but network %s is L2 chain %s", rollupConfig.L2ChainID, network.Name, network.Rollup.L2ChainID)
+	}
+	if network.Rollup.BatchInboxAddress != rollupConfig.BatchInboxAddress {
+		return fmt.Errorf("rollup node uses batch inbox %s, but network %s uses %s", rollupConfig.BatchInboxAddress, network.Name, network.Rollup.BatchInboxAddress)
+	}
+	return nil
+}
+
+// [Kroma: END]
+
 func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	cc := ChannelConfig{
 		SeqWindowSize:      bs.RollupConfig.SeqWindowSize,
@@ -300,12 +338,27 @@ func (bs *BatcherService) initDriver() {
 }
 
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
+	/* [Kroma: START]
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		bs.Version,
 		oprpc.WithLogger(bs.Log),
 	)
+	[Kroma: END] */
+	// [Kroma: START]
+	policyOpt, err := cfg.RPC.PolicyOption(bs.Metrics)
+	if err != nil {
+		return err
+	}
+	server := oprpc.NewServer(
+		cfg.RPC.ListenAddr,
+		cfg.RPC.ListenPort,
+		bs.Version,
+		oprpc.WithLogger(bs.Log),
+		policyOpt,
+	)
+	// [Kroma: END]
 	if cfg.RPC.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(bs.driver, bs.Metrics, bs.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
@@ -403,6 +456,13 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 	if bs.EndpointProvider != nil {
 		bs.EndpointProvider.Close()
 	}
+	// [Kroma: START]
+	if bs.stopTracing != nil {
+		if err := bs.stopTracing(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop tracing: %w", err))
+		}
+	}
+	// [Kroma: END]
 
 	if result == nil {
 		bs.stopped.Store(true)
@@ -12,10 +12,12 @@ import (
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -29,7 +31,7 @@ var (
 	// Required flags
 	L1EthRpcFlag = &cli.StringFlag{
 		Name:    "l1-eth-rpc",
-		Usage:   "HTTP provider URL for L1",
+		Usage:   "HTTP provider URL for L1. Multiple comma-separated URLs enable failover between them",
 		EnvVars: prefixEnvVars("L1_ETH_RPC"),
 	}
 	L2EthRpcFlag = &cli.StringFlag{
@@ -160,6 +162,10 @@ func init() {
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, plasma.CLIFlags(EnvVarPrefix, "")...)
+	// [Kroma: START]
+	optionalFlags = append(optionalFlags, opflags.CLINetworkFlag(EnvVarPrefix, ""), opflags.CLINetworkRegistryFlag(EnvVarPrefix, ""))
+	optionalFlags = append(optionalFlags, tracing.CLIFlags(EnvVarPrefix)...)
+	// [Kroma: END]
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
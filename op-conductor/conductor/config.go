@@ -15,6 +15,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type Config struct {
@@ -55,6 +56,9 @@ type Config struct {
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 	RPC           oprpc.CLIConfig
+	// [Kroma: START]
+	Tracing tracing.CLIConfig
+	// [Kroma: END]
 }
 
 // Check validates the CLIConfig.
@@ -92,6 +96,11 @@ func (c *Config) Check() error {
 	if err := c.RPC.Check(); err != nil {
 		return errors.Wrap(err, "invalid rpc config")
 	}
+	// [Kroma: START]
+	if err := c.Tracing.Check(); err != nil {
+		return errors.Wrap(err, "invalid tracing config")
+	}
+	// [Kroma: END]
 	return nil
 }
 
@@ -127,6 +136,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:    oppprof.ReadCLIConfig(ctx),
 		RPC:            oprpc.ReadCLIConfig(ctx),
+		// [Kroma: START]
+		Tracing: tracing.ReadCLIConfig(ctx),
+		// [Kroma: END]
 	}, nil
 }
 
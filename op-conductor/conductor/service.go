@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 var (
@@ -97,6 +98,13 @@ func NewOpConductor(
 
 func (c *OpConductor) init(ctx context.Context) error {
 	c.log.Info("initializing OpConductor", "version", c.version)
+	// [Kroma: START]
+	stopTracing, err := tracing.Start(c.cfg.Tracing, "op-conductor", c.version)
+	if err != nil {
+		return errors.Wrap(err, "failed to start tracing")
+	}
+	c.stopTracing = stopTracing
+	// [Kroma: END]
 	if err := c.initSequencerControl(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize sequencer control")
 	}
@@ -186,12 +194,27 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 }
 
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
+	/* [Kroma: START]
+	server := oprpc.NewServer(
+		oc.cfg.RPC.ListenAddr,
+		oc.cfg.RPC.ListenPort,
+		oc.version,
+		oprpc.WithLogger(oc.log),
+	)
+	[Kroma: END] */
+	// [Kroma: START]
+	policyOpt, err := oc.cfg.RPC.PolicyOption(nil)
+	if err != nil {
+		return err
+	}
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
 		oc.cfg.RPC.ListenPort,
 		oc.version,
 		oprpc.WithLogger(oc.log),
+		policyOpt,
 	)
+	// [Kroma: END]
 	api := conductorrpc.NewAPIBackend(oc.log, oc)
 	server.AddAPI(rpc.API{
 		Namespace: conductorrpc.RPCNamespace,
@@ -271,6 +294,9 @@ type OpConductor struct {
 	shutdownCancel context.CancelFunc
 
 	rpcServer *oprpc.Server
+	// [Kroma: START]
+	stopTracing func(ctx context.Context) error
+	// [Kroma: END]
 }
 
 type state struct {
@@ -345,6 +371,14 @@ func (oc *OpConductor) Stop(ctx context.Context) error {
 		}
 	}
 
+	// [Kroma: START]
+	if oc.stopTracing != nil {
+		if err := oc.stopTracing(ctx); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop tracing"))
+		}
+	}
+	// [Kroma: END]
+
 	if result.ErrorOrNil() != nil {
 		oc.log.Error("failed to stop OpConductor", "err", result.ErrorOrNil())
 		return result.ErrorOrNil()
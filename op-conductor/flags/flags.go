@@ -11,6 +11,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 const EnvVarPrefix = "OP_CONDUCTOR"
@@ -113,6 +114,9 @@ func init() {
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix, "")...)
+	// [Kroma: START]
+	optionalFlags = append(optionalFlags, tracing.CLIFlags(EnvVarPrefix)...)
+	// [Kroma: END]
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	gnode "github.com/ethereum/go-ethereum/node"
@@ -134,6 +135,14 @@ func (s *l2VerifierBackend) BlockRefsWithStatus(ctx context.Context, num uint64)
 	return ref, nextRef, s.verifier.SyncStatus(), err
 }
 
+func (s *l2VerifierBackend) SubmitPriorityTx(ctx context.Context, tx hexutil.Bytes) (common.Hash, error) {
+	return common.Hash{}, errors.New("submitting priority txs to the L2Verifier is not supported")
+}
+
+func (s *l2VerifierBackend) PreconfirmTx(ctx context.Context, tx hexutil.Bytes) (uint64, error) {
+	return 0, errors.New("preconfirming txs on the L2Verifier is not supported")
+}
+
 func (s *l2VerifierBackend) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return s.verifier.SyncStatus(), nil
 }
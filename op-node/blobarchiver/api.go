@@ -0,0 +1,86 @@
+package blobarchiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const sidecarsPathPrefix = "/eth/v1/beacon/blob_sidecars/"
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewAPIHandler serves the archived blob sidecars over the subset of the beacon API
+// that op-node uses for its l1.beacon-archiver: the blob sidecars by slot, and the node version.
+func NewAPIHandler(log log.Logger, store *FileStore, version string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(sidecarsPathPrefix, func(w http.ResponseWriter, r *http.Request) {
+		serveSidecars(log, store, w, r)
+	})
+	mux.HandleFunc("/eth/v1/node/version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(log, w, http.StatusOK, &eth.APIVersionResponse{Data: eth.VersionInformation{Version: version}})
+	})
+	return mux
+}
+
+func serveSidecars(log log.Logger, store *FileStore, w http.ResponseWriter, r *http.Request) {
+	blockID := strings.TrimPrefix(r.URL.Path, sidecarsPathPrefix)
+	slot, err := strconv.ParseUint(blockID, 10, 64)
+	if err != nil {
+		// only slot numbers are supported as block ID, the archive does not know about beacon block roots
+		writeError(log, w, http.StatusBadRequest, fmt.Sprintf("invalid block id %q, only slot numbers are supported", blockID))
+		return
+	}
+	var indices map[uint64]bool
+	for _, raw := range r.URL.Query()["indices"] {
+		ix, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			writeError(log, w, http.StatusBadRequest, fmt.Sprintf("invalid index %q", raw))
+			return
+		}
+		if indices == nil {
+			indices = make(map[uint64]bool)
+		}
+		indices[ix] = true
+	}
+
+	sidecars, err := store.GetSidecars(slot)
+	if errors.Is(err, ethereum.NotFound) {
+		writeError(log, w, http.StatusNotFound, fmt.Sprintf("no blob sidecars archived for slot %d", slot))
+		return
+	} else if err != nil {
+		log.Error("Failed to load blob sidecars", "slot", slot, "err", err)
+		writeError(log, w, http.StatusInternalServerError, "failed to load blob sidecars")
+		return
+	}
+	resp := &eth.APIGetBlobSidecarsResponse{Data: []*eth.APIBlobSidecar{}}
+	for _, sc := range sidecars {
+		if indices == nil || indices[uint64(sc.Index)] {
+			resp.Data = append(resp.Data, sc)
+		}
+	}
+	writeJSON(log, w, http.StatusOK, resp)
+}
+
+func writeError(log log.Logger, w http.ResponseWriter, code int, msg string) {
+	writeJSON(log, w, code, &apiError{Code: code, Message: msg})
+}
+
+func writeJSON(log log.Logger, w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debug("Failed to write response", "err", err)
+	}
+}
@@ -0,0 +1,204 @@
+package blobarchiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+type L1Source interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	InfoAndTxsByNumber(ctx context.Context, number uint64) (eth.BlockInfo, types.Transactions, error)
+}
+
+type ArchiverConfig struct {
+	// BatchInboxAddress is the address the blobs are archived of.
+	// Blobs of any sender are archived: the batcher address may change over time, and extra blobs are harmless.
+	BatchInboxAddress common.Address
+	// StartBlock is the L1 block to start archiving from, if nothing was archived yet.
+	// Archiving starts from the finalized L1 block if zero.
+	StartBlock uint64
+	// PollInterval is the interval to poll for new finalized L1 blocks at.
+	PollInterval time.Duration
+}
+
+// Archiver follows the finalized L1 chain, and stores the blob sidecars of the blocks
+// that carry blob transactions to the batch inbox.
+// Only finalized blocks are archived, so the archive never has to deal with reorgs.
+type Archiver struct {
+	log    log.Logger
+	cfg    ArchiverConfig
+	l1     L1Source
+	beacon sources.BeaconClient
+	store  *FileStore
+
+	slotFn sources.TimeToSlotFn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewArchiver(log log.Logger, cfg ArchiverConfig, l1 L1Source, beacon sources.BeaconClient, store *FileStore) *Archiver {
+	return &Archiver{
+		log:    log,
+		cfg:    cfg,
+		l1:     l1,
+		beacon: beacon,
+		store:  store,
+	}
+}
+
+func (a *Archiver) Start(ctx context.Context) error {
+	slotFn, err := sources.NewL1BeaconClient(a.beacon, sources.L1BeaconClientConfig{}).GetTimeToSlotFn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch beacon chain config: %w", err)
+	}
+	a.slotFn = slotFn
+
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+	a.wg.Add(1)
+	go a.loop()
+	return nil
+}
+
+func (a *Archiver) Stop() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.wg.Wait()
+}
+
+func (a *Archiver) loop() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.ArchiveFinalized(a.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			a.log.Error("Failed to archive blobs", "err", err)
+		}
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveFinalized archives the blobs of all L1 blocks since the last archived block, up to the finalized L1 block.
+func (a *Archiver) ArchiveFinalized(ctx context.Context) error {
+	finalized, err := a.l1.L1BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil {
+		return fmt.Errorf("failed to fetch finalized L1 block: %w", err)
+	}
+
+	next := a.cfg.StartBlock
+	progress, err := a.store.ReadProgress()
+	if err == nil {
+		next = progress.L1Block.Number + 1
+	} else if !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("failed to read progress: %w", err)
+	} else if next == 0 {
+		next = finalized.Number
+	}
+
+	for ; next <= finalized.Number; next++ {
+		block, err := a.ArchiveBlock(ctx, next)
+		if err != nil {
+			return err
+		}
+		if err := a.store.WriteProgress(Progress{L1Block: block}); err != nil {
+			return fmt.Errorf("failed to write progress: %w", err)
+		}
+	}
+	return nil
+}
+
+// ArchiveBlock stores the blob sidecars of the batch inbox transactions in the given L1 block.
+// The sidecars are verified against the versioned hashes of the transactions before they are stored.
+func (a *Archiver) ArchiveBlock(ctx context.Context, number uint64) (eth.BlockID, error) {
+	info, txs, err := a.l1.InfoAndTxsByNumber(ctx, number)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to fetch L1 block %d: %w", number, err)
+	}
+	id := eth.ToBlockID(info)
+	hashes := inboxBlobHashes(txs, a.cfg.BatchInboxAddress)
+	if len(hashes) == 0 {
+		return id, nil
+	}
+
+	slot, err := a.slotFn(info.Time())
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to compute slot of L1 block %s: %w", id, err)
+	}
+	resp, err := a.beacon.BeaconBlobSideCars(ctx, false, slot, hashes)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to fetch blob sidecars of slot %d, L1 block %s: %w", slot, id, err)
+	}
+	sidecars, err := verifySidecars(resp.Data, hashes)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("invalid blob sidecars of slot %d, L1 block %s: %w", slot, id, err)
+	}
+	if err := a.store.PutSidecars(slot, sidecars); err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to store blob sidecars of slot %d: %w", slot, err)
+	}
+	a.log.Info("Archived blob sidecars", "l1Block", id, "slot", slot, "blobs", len(sidecars))
+	return id, nil
+}
+
+// inboxBlobHashes returns the blob hashes of the transactions to the batch inbox,
+// indexed by the position of the blob in the block.
+func inboxBlobHashes(txs types.Transactions, inbox common.Address) []eth.IndexedBlobHash {
+	var hashes []eth.IndexedBlobHash
+	blobIndex := uint64(0)
+	for _, tx := range txs {
+		if to := tx.To(); to != nil && *to == inbox {
+			for _, h := range tx.BlobHashes() {
+				hashes = append(hashes, eth.IndexedBlobHash{Index: blobIndex, Hash: h})
+				blobIndex++
+			}
+			continue
+		}
+		blobIndex += uint64(len(tx.BlobHashes()))
+	}
+	return hashes
+}
+
+// verifySidecars returns the sidecars of the given hashes, in order, checking each blob against its versioned hash.
+func verifySidecars(sidecars []*eth.APIBlobSidecar, hashes []eth.IndexedBlobHash) ([]*eth.APIBlobSidecar, error) {
+	out := make([]*eth.APIBlobSidecar, 0, len(hashes))
+	for _, h := range hashes {
+		var sidecar *eth.APIBlobSidecar
+		for _, sc := range sidecars {
+			if uint64(sc.Index) == h.Index {
+				sidecar = sc
+				break
+			}
+		}
+		if sidecar == nil {
+			return nil, fmt.Errorf("missing sidecar of blob %d", h.Index)
+		}
+		commitment := kzg4844.Commitment(sidecar.KZGCommitment)
+		if hash := eth.KZGToVersionedHash(commitment); hash != h.Hash {
+			return nil, fmt.Errorf("expected hash %s for blob %d, but got %s", h.Hash, h.Index, hash)
+		}
+		if err := eth.VerifyBlobProof(&sidecar.Blob, commitment, kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			return nil, fmt.Errorf("blob %d failed verification: %w", h.Index, err)
+		}
+		out = append(out, sidecar)
+	}
+	return out, nil
+}
@@ -0,0 +1,164 @@
+package blobarchiver
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/fakebeacon"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+const (
+	testGenesisTime = 1000
+	testBlockTime   = 12
+)
+
+type fakeL1 struct {
+	blocks    map[uint64]types.Transactions
+	finalized uint64
+}
+
+func (f *fakeL1) info(number uint64) *testutils.MockBlockInfo {
+	return &testutils.MockBlockInfo{
+		InfoHash: common.Hash{byte(number)},
+		InfoNum:  number,
+		InfoTime: testGenesisTime + number*testBlockTime,
+	}
+}
+
+func (f *fakeL1) L1BlockRefByLabel(_ context.Context, _ eth.BlockLabel) (eth.L1BlockRef, error) {
+	return eth.InfoToL1BlockRef(f.info(f.finalized)), nil
+}
+
+func (f *fakeL1) InfoAndTxsByNumber(_ context.Context, number uint64) (eth.BlockInfo, types.Transactions, error) {
+	return f.info(number), f.blocks[number], nil
+}
+
+type testBlob struct {
+	blob       eth.Blob
+	commitment kzg4844.Commitment
+	proof      kzg4844.Proof
+}
+
+func newTestBlob(t *testing.T, data string) *testBlob {
+	var b testBlob
+	require.NoError(t, b.blob.FromData(eth.Data(data)))
+	var err error
+	b.commitment, err = kzg4844.BlobToCommitment(*b.blob.KZGBlob())
+	require.NoError(t, err)
+	b.proof, err = kzg4844.ComputeBlobProof(*b.blob.KZGBlob(), b.commitment)
+	require.NoError(t, err)
+	return &b
+}
+
+func (b *testBlob) hash() common.Hash {
+	return eth.KZGToVersionedHash(b.commitment)
+}
+
+func blobTx(to common.Address, blobs ...*testBlob) *types.Transaction {
+	var hashes []common.Hash
+	for _, b := range blobs {
+		hashes = append(hashes, b.hash())
+	}
+	return types.NewTx(&types.BlobTx{To: to, BlobHashes: hashes})
+}
+
+func TestArchiver(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx := context.Background()
+	inbox := common.Address{0xff}
+
+	beaconDir := t.TempDir()
+	beacon := fakebeacon.NewBeacon(logger, beaconDir, testGenesisTime, testBlockTime)
+	require.NoError(t, beacon.Start("127.0.0.1:0"))
+	t.Cleanup(func() { _ = beacon.Close() })
+
+	other, batch := newTestBlob(t, "other rollup"), newTestBlob(t, "our batch")
+	bundle := &engine.BlobsBundleV1{}
+	for _, b := range []*testBlob{other, batch} {
+		bundle.Commitments = append(bundle.Commitments, b.commitment[:])
+		bundle.Proofs = append(bundle.Proofs, b.proof[:])
+		bundle.Blobs = append(bundle.Blobs, hexutil.Bytes(b.blob[:]))
+	}
+	require.NoError(t, beacon.StoreBlobsBundle(5, bundle))
+
+	l1 := &fakeL1{
+		blocks: map[uint64]types.Transactions{
+			5: {blobTx(common.Address{0xaa}, other), blobTx(inbox, batch)},
+		},
+		finalized: 6,
+	}
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	beaconCl := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(beacon.BeaconAddr(), logger))
+	archiver := NewArchiver(logger, ArchiverConfig{
+		BatchInboxAddress: inbox,
+		StartBlock:        4,
+		PollInterval:      10 * time.Millisecond,
+	}, l1, beaconCl, store)
+
+	require.NoError(t, archiver.Start(ctx))
+	require.Eventually(t, func() bool {
+		progress, err := store.ReadProgress()
+		return err == nil && progress.L1Block.Number == 6
+	}, 5*time.Second, 10*time.Millisecond)
+	archiver.Stop()
+
+	// only the blob of the batch inbox tx is archived
+	sidecars, err := store.GetSidecars(5)
+	require.NoError(t, err)
+	require.Len(t, sidecars, 1)
+	require.Equal(t, eth.Uint64String(1), sidecars[0].Index)
+
+	// the blobs expire from the beacon node, op-node falls back to the archiver
+	require.NoError(t, os.RemoveAll(beaconDir))
+	srv := httptest.NewServer(NewAPIHandler(logger, store, "test"))
+	t.Cleanup(srv.Close)
+	archiverCl := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(srv.URL, logger))
+	l1Beacon := sources.NewL1BeaconClient(beaconCl, sources.L1BeaconClientConfig{}, archiverCl)
+
+	ref := eth.InfoToL1BlockRef(l1.info(5))
+	blobs, err := l1Beacon.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 1, Hash: batch.hash()}})
+	require.NoError(t, err)
+	require.Equal(t, batch.blob, *blobs[0])
+
+	// blobs that were not archived are not found
+	_, err = archiverCl.BeaconBlobSideCars(ctx, false, 6, nil)
+	require.ErrorContains(t, err, "404")
+	resp, err := archiverCl.BeaconBlobSideCars(ctx, false, 5, []eth.IndexedBlobHash{{Index: 0}})
+	require.NoError(t, err)
+	require.Empty(t, resp.Data)
+}
+
+func TestArchiverRejectsInvalidSidecars(t *testing.T) {
+	good, bad := newTestBlob(t, "good"), newTestBlob(t, "bad")
+	sidecar := &eth.APIBlobSidecar{
+		Index:         0,
+		Blob:          bad.blob,
+		KZGCommitment: eth.Bytes48(good.commitment),
+		KZGProof:      eth.Bytes48(good.proof),
+	}
+	_, err := verifySidecars([]*eth.APIBlobSidecar{sidecar}, []eth.IndexedBlobHash{{Index: 0, Hash: good.hash()}})
+	require.ErrorContains(t, err, "failed verification")
+
+	_, err = verifySidecars([]*eth.APIBlobSidecar{sidecar}, []eth.IndexedBlobHash{{Index: 0, Hash: bad.hash()}})
+	require.ErrorContains(t, err, "expected hash")
+
+	_, err = verifySidecars(nil, []eth.IndexedBlobHash{{Index: 0, Hash: good.hash()}})
+	require.ErrorContains(t, err, "missing sidecar")
+}
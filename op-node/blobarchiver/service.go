@@ -0,0 +1,126 @@
+package blobarchiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+type Config struct {
+	Rollup *rollup.Config
+
+	L1Addr       string
+	L1BeaconAddr string
+
+	// DataDir is the directory the blob sidecars are stored in.
+	DataDir string
+
+	ListenAddr string
+	ListenPort int
+
+	StartBlock   uint64
+	PollInterval time.Duration
+}
+
+func (c *Config) Check() error {
+	if c.Rollup == nil {
+		return errors.New("missing rollup config")
+	}
+	if c.L1Addr == "" {
+		return errors.New("missing L1 RPC address")
+	}
+	if c.L1BeaconAddr == "" {
+		return errors.New("missing L1 beacon address")
+	}
+	if c.DataDir == "" {
+		return errors.New("missing data dir")
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	return nil
+}
+
+// Service runs an Archiver, and serves the archived blob sidecars over the beacon API.
+type Service struct {
+	log log.Logger
+
+	l1       client.RPC
+	archiver *Archiver
+	server   *httputil.HTTPServer
+
+	stopped atomic.Bool
+}
+
+func NewService(ctx context.Context, log log.Logger, cfg *Config, version string) (*Service, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	store, err := NewFileStore(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	l1RPC, err := client.NewRPC(ctx, log, cfg.L1Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 address (%s): %w", cfg.L1Addr, err)
+	}
+	l1, err := sources.NewL1Client(l1RPC, log, nil, sources.L1ClientDefaultConfig(cfg.Rollup, true, sources.RPCKindStandard))
+	if err != nil {
+		l1RPC.Close()
+		return nil, fmt.Errorf("failed to create L1 client: %w", err)
+	}
+	beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.L1BeaconAddr, log))
+
+	archiver := NewArchiver(log, ArchiverConfig{
+		BatchInboxAddress: cfg.Rollup.BatchInboxAddress,
+		StartBlock:        cfg.StartBlock,
+		PollInterval:      cfg.PollInterval,
+	}, l1, beacon, store)
+
+	addr := net.JoinHostPort(cfg.ListenAddr, strconv.Itoa(cfg.ListenPort))
+	server, err := httputil.StartHTTPServer(addr, NewAPIHandler(log, store, version))
+	if err != nil {
+		l1RPC.Close()
+		return nil, fmt.Errorf("failed to start blob archiver API server: %w", err)
+	}
+	log.Info("Started blob archiver API server", "addr", server.Addr())
+
+	return &Service{
+		log:      log,
+		l1:       l1RPC,
+		archiver: archiver,
+		server:   server,
+	}, nil
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	return s.archiver.Start(ctx)
+}
+
+func (s *Service) Stop(ctx context.Context) error {
+	s.archiver.Stop()
+	err := s.server.Stop(ctx)
+	s.l1.Close()
+	s.stopped.Store(true)
+	return err
+}
+
+func (s *Service) Stopped() bool {
+	return s.stopped.Load()
+}
+
+func (s *Service) Addr() string {
+	return s.server.Addr().String()
+}
@@ -0,0 +1,71 @@
+package blobarchiver
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// Progress is the last L1 block the archiver archived the blobs of.
+type Progress struct {
+	L1Block eth.BlockID `json:"l1Block"`
+}
+
+// FileStore stores the blob sidecars of each beacon slot as a JSON file in a directory.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "sidecars"), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob store dir: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) sidecarsPath(slot uint64) string {
+	return filepath.Join(s.dir, "sidecars", strconv.FormatUint(slot, 10)+".json")
+}
+
+func (s *FileStore) progressPath() string {
+	return filepath.Join(s.dir, "progress.json")
+}
+
+// PutSidecars stores the blob sidecars of a slot, replacing any sidecars stored before.
+func (s *FileStore) PutSidecars(slot uint64, sidecars []*eth.APIBlobSidecar) error {
+	return jsonutil.WriteJSON(s.sidecarsPath(slot), sidecars, 0o644)
+}
+
+// GetSidecars returns the blob sidecars of a slot, or ethereum.NotFound if none were stored.
+func (s *FileStore) GetSidecars(slot uint64) ([]*eth.APIBlobSidecar, error) {
+	return loadJSON[[]*eth.APIBlobSidecar](s.sidecarsPath(slot))
+}
+
+// ReadProgress returns the archiving progress, or ethereum.NotFound if nothing was archived yet.
+func (s *FileStore) ReadProgress() (Progress, error) {
+	return loadJSON[Progress](s.progressPath())
+}
+
+func (s *FileStore) WriteProgress(p Progress) error {
+	return jsonutil.WriteJSON(s.progressPath(), p, 0o644)
+}
+
+func loadJSON[X any](path string) (X, error) {
+	var out X
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return out, ethereum.NotFound
+	}
+	v, err := jsonutil.LoadJSON[X](path)
+	if err != nil {
+		return out, err
+	}
+	return *v, nil
+}
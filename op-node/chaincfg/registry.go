@@ -0,0 +1,202 @@
+package chaincfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+const (
+	rollupConfigFile = "rollup.json"
+	addressesFile    = "addresses.json"
+	deployConfigFile = "deploy-config.json"
+)
+
+// Addresses are the L1 contract addresses of a network.
+// The JSON keys are the ones of the L1 deployments file, so that file can be used as is.
+type Addresses struct {
+	KromaPortal            common.Address `json:"KromaPortalProxy"`
+	SystemConfig           common.Address `json:"SystemConfigProxy"`
+	L1CrossDomainMessenger common.Address `json:"L1CrossDomainMessengerProxy"`
+	L1StandardBridge       common.Address `json:"L1StandardBridgeProxy"`
+	L1ERC721Bridge         common.Address `json:"L1ERC721BridgeProxy"`
+	L2OutputOracle         common.Address `json:"L2OutputOracleProxy"`
+	Colosseum              common.Address `json:"ColosseumProxy"`
+	ValidatorPool          common.Address `json:"ValidatorPoolProxy"`
+	SecurityCouncil        common.Address `json:"SecurityCouncilProxy"`
+}
+
+// builtinAddresses are the L1 contract addresses of the built-in networks,
+// as in packages/contracts/deployments of the network.
+var builtinAddresses = map[string]Addresses{
+	"mainnet": {
+		KromaPortal:            common.HexToAddress("0x31F648572b67e60Ec6eb8E197E1848CC5F5558de"),
+		SystemConfig:           common.HexToAddress("0x3971EB866AA9b2b8aFEa8a7C816F3b7e8b195a35"),
+		L1CrossDomainMessenger: common.HexToAddress("0x46B8bB4C5dd27bB42807Db477af4d1a7C8A5B746"),
+		L1StandardBridge:       common.HexToAddress("0x827962404D7104202C5aaa6b929115C8211d9596"),
+		L1ERC721Bridge:         common.HexToAddress("0x46d07221dfC313afe1BF104F4bB1f185301D65B9"),
+		L2OutputOracle:         common.HexToAddress("0x180c77aE51a9c505a43A2C7D81f8CE70cacb93A6"),
+		Colosseum:              common.HexToAddress("0x713C2BEd44eB45D490afB8D4d1aA6F12290B829a"),
+		ValidatorPool:          common.HexToAddress("0xFdFF462845953D90719A78Fd12a2d103541d2103"),
+		SecurityCouncil:        common.HexToAddress("0x3de211088dF516da72efe68D386b561BEE256Ec4"),
+	},
+	"sepolia": {
+		KromaPortal:            common.HexToAddress("0x31ab8eD993A3BE9Aa2757C7D368Dc87101A868a4"),
+		SystemConfig:           common.HexToAddress("0x398C8eA789968893095D86CBA168378A4f452e33"),
+		L1CrossDomainMessenger: common.HexToAddress("0x69786A10c1A153191BF5A50B61e70F6934fcc0A2"),
+		L1StandardBridge:       common.HexToAddress("0x38C9a0a694AA0f92c05238484C3a9bdE1e85ddE4"),
+		L1ERC721Bridge:         common.HexToAddress("0xb1eEf5eC7932D1adC605C6537e4D9353f7Ba2DcB"),
+		L2OutputOracle:         common.HexToAddress("0x7291913342063fd10d31651735BAF3877D2F9645"),
+		Colosseum:              common.HexToAddress("0xbe15843D4335614a8fCfA7AFc83b7283cAB6E82C"),
+		ValidatorPool:          common.HexToAddress("0xbc171C51D9e3E0b24AA4606824e45F93DdE6E352"),
+		SecurityCouncil:        common.HexToAddress("0x7F0DECbef4FdD0193b7d5c8Cc918C1A69d3eF78C"),
+	},
+}
+
+// Network is a named network that services can select with the network flag.
+type Network struct {
+	Name      string
+	Rollup    *rollup.Config
+	Addresses Addresses
+	// DeployConfigPath is the path of the deploy config of the network,
+	// empty if the network does not come with one.
+	DeployConfigPath string
+}
+
+// networks holds the built-in networks and the networks loaded from a registry directory.
+var networks = func() map[string]*Network {
+	out := make(map[string]*Network)
+	for name, cfg := range NetworksByName {
+		n := &Network{Name: name, Rollup: cfg, Addresses: builtinAddresses[name]}
+		if err := n.fillAddresses(); err != nil {
+			panic(err)
+		}
+		out[name] = n
+	}
+	return out
+}()
+
+// fillAddresses sets the addresses that are also part of the rollup config,
+// and checks that the ones that are set agree with it.
+func (n *Network) fillAddresses() error {
+	for _, a := range []struct {
+		name     string
+		addr     *common.Address
+		expected common.Address
+	}{
+		{"KromaPortal", &n.Addresses.KromaPortal, n.Rollup.DepositContractAddress},
+		{"SystemConfig", &n.Addresses.SystemConfig, n.Rollup.L1SystemConfigAddress},
+	} {
+		if *a.addr == (common.Address{}) {
+			*a.addr = a.expected
+		} else if *a.addr != a.expected {
+			return fmt.Errorf("%s address %s of network %s does not match rollup config address %s", a.name, a.addr, n.Name, a.expected)
+		}
+	}
+	return nil
+}
+
+// Register adds the network, replacing the network of the same name if there is one.
+// It is not safe to call concurrently with the lookup of networks.
+func Register(n *Network) error {
+	if n.Name == "" {
+		return errors.New("network has no name")
+	}
+	if n.Rollup == nil {
+		return fmt.Errorf("network %s has no rollup config", n.Name)
+	}
+	if err := n.Rollup.Check(); err != nil {
+		return fmt.Errorf("invalid rollup config of network %s: %w", n.Name, err)
+	}
+	if err := n.fillAddresses(); err != nil {
+		return err
+	}
+	if prev, ok := networks[n.Name]; ok {
+		delete(L2ChainIDToNetworkDisplayName, prev.Rollup.L2ChainID.String())
+	}
+	networks[n.Name] = n
+	NetworksByName[n.Name] = n.Rollup
+	L2ChainIDToNetworkDisplayName[n.Rollup.L2ChainID.String()] = n.Name
+	return nil
+}
+
+// LoadNetworks registers the networks of a registry directory. Each subdirectory is a network
+// named after the directory, with the following files:
+//   - rollup.json: the rollup config
+//   - addresses.json: the L1 contract addresses, in the format of the L1 deployments file (optional)
+//   - deploy-config.json: the deploy config (optional)
+//
+// A network of the registry replaces the built-in network of the same name.
+func LoadNetworks(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read network registry: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		n, err := loadNetwork(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to load network %s: %w", entry.Name(), err)
+		}
+		if err := Register(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadNetwork(dir string) (*Network, error) {
+	n := &Network{Name: filepath.Base(dir), Rollup: new(rollup.Config)}
+	if err := readJSON(filepath.Join(dir, rollupConfigFile), n.Rollup); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, addressesFile), &n.Addresses); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	deployConfigPath := filepath.Join(dir, deployConfigFile)
+	if _, err := os.Stat(deployConfigPath); err == nil {
+		n.DeployConfigPath = deployConfigPath
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return n, nil
+}
+
+func readJSON(path string, out any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// GetNetwork returns the built-in or registered network of the given name.
+func GetNetwork(name string) (*Network, error) {
+	n, ok := networks[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid network %s", name)
+	}
+	return n, nil
+}
+
+// LoadNetwork loads the registry directory, if any, and returns the network of the given name.
+func LoadNetwork(registry string, name string) (*Network, error) {
+	if registry != "" {
+		if err := LoadNetworks(registry); err != nil {
+			return nil, err
+		}
+	}
+	return GetNetwork(name)
+}
@@ -0,0 +1,135 @@
+package chaincfg
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func writeJSON(t *testing.T, path string, v any) {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+// restoreNetworks undoes the registrations of a test.
+func restoreNetworks(t *testing.T) {
+	saved := make(map[string]*Network)
+	for name, n := range networks {
+		saved[name] = n
+	}
+	t.Cleanup(func() {
+		for name, n := range networks {
+			delete(networks, name)
+			delete(NetworksByName, name)
+			delete(L2ChainIDToNetworkDisplayName, n.Rollup.L2ChainID.String())
+		}
+		for name, n := range saved {
+			networks[name] = n
+			NetworksByName[name] = n.Rollup
+			L2ChainIDToNetworkDisplayName[n.Rollup.L2ChainID.String()] = name
+		}
+	})
+}
+
+func TestBuiltinNetworks(t *testing.T) {
+	n, err := GetNetwork("mainnet")
+	require.NoError(t, err)
+	require.Equal(t, Mainnet, n.Rollup)
+	require.Equal(t, Mainnet.DepositContractAddress, n.Addresses.KromaPortal)
+	require.Equal(t, Mainnet.L1SystemConfigAddress, n.Addresses.SystemConfig)
+
+	_, err = GetNetwork("unknown")
+	require.ErrorContains(t, err, "invalid network unknown")
+}
+
+func TestBuiltinNetworkAddresses(t *testing.T) {
+	for name := range NetworksByName {
+		n, err := GetNetwork(name)
+		require.NoError(t, err)
+		// the addresses match the deployments of the network
+		addresses := reflect.ValueOf(n.Addresses)
+		for i := 0; i < addresses.NumField(); i++ {
+			field := addresses.Type().Field(i)
+			data, err := os.ReadFile(filepath.Join("../../packages/contracts/deployments", name, field.Tag.Get("json")+".json"))
+			require.NoError(t, err)
+			var deployment struct {
+				Address common.Address `json:"address"`
+			}
+			require.NoError(t, json.Unmarshal(data, &deployment))
+			require.Equal(t, deployment.Address, addresses.Field(i).Interface(), "%s address of %s", field.Name, name)
+		}
+	}
+}
+
+func TestLoadNetworks(t *testing.T) {
+	restoreNetworks(t)
+
+	devnet := *Sepolia
+	devnet.L2ChainID = big.NewInt(901)
+	addresses := map[string]common.Address{
+		"L2OutputOracleProxy": common.HexToAddress("0x01"),
+		"ColosseumProxy":      common.HexToAddress("0x02"),
+		"ValidatorPoolProxy":  common.HexToAddress("0x03"),
+		// entries of the deployments file that are not network addresses are ignored
+		"ZKVerifier": common.HexToAddress("0x04"),
+	}
+
+	dir := t.TempDir()
+	writeJSON(t, filepath.Join(dir, "devnet", "rollup.json"), &devnet)
+	writeJSON(t, filepath.Join(dir, "devnet", "addresses.json"), addresses)
+	writeJSON(t, filepath.Join(dir, "devnet", "deploy-config.json"), map[string]any{})
+	// an override of a built-in network, without addresses
+	writeJSON(t, filepath.Join(dir, "sepolia", "rollup.json"), Sepolia)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), nil, 0o644))
+
+	n, err := LoadNetwork(dir, "devnet")
+	require.NoError(t, err)
+	require.Equal(t, "devnet", n.Name)
+	require.Equal(t, &devnet, n.Rollup)
+	require.Equal(t, Addresses{
+		KromaPortal:    devnet.DepositContractAddress,
+		SystemConfig:   devnet.L1SystemConfigAddress,
+		L2OutputOracle: common.HexToAddress("0x01"),
+		Colosseum:      common.HexToAddress("0x02"),
+		ValidatorPool:  common.HexToAddress("0x03"),
+	}, n.Addresses)
+	require.Equal(t, filepath.Join(dir, "devnet", "deploy-config.json"), n.DeployConfigPath)
+
+	cfg, err := GetRollupConfig("devnet")
+	require.NoError(t, err)
+	require.Equal(t, &devnet, cfg)
+	require.Contains(t, AvailableNetworks(), "devnet")
+	require.Equal(t, "devnet", L2ChainIDToNetworkDisplayName["901"])
+
+	n, err = GetNetwork("sepolia")
+	require.NoError(t, err)
+	require.Empty(t, n.DeployConfigPath)
+	require.Equal(t, Sepolia.DepositContractAddress, n.Addresses.KromaPortal)
+}
+
+func TestLoadNetworksInvalid(t *testing.T) {
+	restoreNetworks(t)
+
+	t.Run("missing rollup config", func(t *testing.T) {
+		dir := t.TempDir()
+		writeJSON(t, filepath.Join(dir, "devnet", "addresses.json"), map[string]any{})
+		require.ErrorContains(t, LoadNetworks(dir), "failed to load network devnet")
+	})
+
+	t.Run("mismatching portal", func(t *testing.T) {
+		dir := t.TempDir()
+		writeJSON(t, filepath.Join(dir, "devnet", "rollup.json"), Sepolia)
+		writeJSON(t, filepath.Join(dir, "devnet", "addresses.json"), map[string]common.Address{
+			"KromaPortalProxy": common.HexToAddress("0x01"),
+		})
+		require.ErrorContains(t, LoadNetworks(dir), "does not match rollup config address")
+	})
+}
@@ -0,0 +1,108 @@
+package blobarchiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/blobarchiver"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const envVarPrefix = flags.EnvVarPrefix + "_BLOB_ARCHIVER"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var (
+	L1Flag = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "Address of L1 User JSON-RPC endpoint to use (eth namespace required)",
+		Required: true,
+		EnvVars:  prefixEnvVars("L1_ETH_RPC"),
+	}
+	L1BeaconFlag = &cli.StringFlag{
+		Name:     "l1.beacon",
+		Usage:    "Address of L1 Beacon-node HTTP endpoint to archive the blobs from",
+		Required: true,
+		EnvVars:  prefixEnvVars("L1_BEACON"),
+	}
+	DataDirFlag = &cli.StringFlag{
+		Name:     "data-dir",
+		Usage:    "Directory to store the archived blob sidecars in",
+		Required: true,
+		EnvVars:  prefixEnvVars("DATA_DIR"),
+	}
+	ListenAddrFlag = &cli.StringFlag{
+		Name:    "addr",
+		Usage:   "Address to serve the beacon blob sidecars API on",
+		Value:   "127.0.0.1",
+		EnvVars: prefixEnvVars("ADDR"),
+	}
+	ListenPortFlag = &cli.IntFlag{
+		Name:    "port",
+		Usage:   "Port to serve the beacon blob sidecars API on",
+		Value:   8560,
+		EnvVars: prefixEnvVars("PORT"),
+	}
+	StartBlockFlag = &cli.Uint64Flag{
+		Name:    "start-block",
+		Usage:   "L1 block number to start archiving from, if nothing was archived yet. Defaults to the finalized L1 block",
+		EnvVars: prefixEnvVars("START_BLOCK"),
+	}
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "Interval to poll for newly finalized L1 blocks at",
+		Value:   12 * time.Second,
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+	}
+)
+
+func Command(version string) *cli.Command {
+	cmdFlags := []cli.Flag{L1Flag, L1BeaconFlag, DataDirFlag, ListenAddrFlag, ListenPortFlag, StartBlockFlag, PollIntervalFlag}
+	cmdFlags = append(cmdFlags, opflags.CLINetworkFlag(envVarPrefix, ""), opflags.CLINetworkRegistryFlag(envVarPrefix, ""), opflags.CLIRollupConfigFlag(envVarPrefix, ""))
+	return &cli.Command{
+		Name:  "blob-archiver",
+		Usage: "Archives the blobs of batch inbox transactions, and serves them over the beacon API",
+		Description: "Follows the finalized L1 chain, stores the blob sidecars of blocks with batch inbox transactions, " +
+			"and serves them over the beacon blob sidecars API. The API address can be used as l1.beacon-archiver of op-node, " +
+			"to resync from L1 blocks whose blobs expired from the beacon nodes.",
+		Flags:  cmdFlags,
+		Action: cliapp.LifecycleCmd(Main(version)),
+	}
+}
+
+func Main(version string) cliapp.LifecycleAction {
+	return func(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+		logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+		oplog.SetGlobalLogHandler(logger.Handler())
+
+		rollupCfg, err := opnode.NewRollupConfigFromCLI(logger, ctx)
+		if err != nil {
+			return nil, err
+		}
+		cfg := &blobarchiver.Config{
+			Rollup:       rollupCfg,
+			L1Addr:       ctx.String(L1Flag.Name),
+			L1BeaconAddr: ctx.String(L1BeaconFlag.Name),
+			DataDir:      ctx.String(DataDirFlag.Name),
+			ListenAddr:   ctx.String(ListenAddrFlag.Name),
+			ListenPort:   ctx.Int(ListenPortFlag.Name),
+			StartBlock:   ctx.Uint64(StartBlockFlag.Name),
+			PollInterval: ctx.Duration(PollIntervalFlag.Name),
+		}
+		svc, err := blobarchiver.NewService(ctx.Context, logger, cfg, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob archiver: %w", err)
+		}
+		return svc, nil
+	}
+}
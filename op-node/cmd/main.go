@@ -11,9 +11,11 @@ import (
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/blobarchiver"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/genesis"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/networks"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/replay"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/node"
@@ -62,6 +64,8 @@ func main() {
 			Name:        "networks",
 			Subcommands: networks.Subcommands,
 		},
+		blobarchiver.Command(VersionWithMeta),
+		replay.Command(),
 	}
 
 	ctx := opio.WithInterruptBlocker(context.Background())
@@ -19,6 +19,9 @@ var Subcommands = []*cli.Command{
 		Usage: "Dumps network configs",
 		Flags: []cli.Flag{
 			opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+			// [Kroma: START]
+			opflags.CLINetworkRegistryFlag(flags.EnvVarPrefix, ""),
+			// [Kroma: END]
 		},
 		Action: func(ctx *cli.Context) error {
 			logCfg := oplog.ReadCLIConfig(ctx)
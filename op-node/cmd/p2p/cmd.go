@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
@@ -8,9 +9,13 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 )
 
 func Priv2PeerID(r io.Reader) (string, error) {
@@ -50,6 +55,20 @@ func Pub2PeerID(r io.Reader) (string, error) {
 	return pid.String(), nil
 }
 
+// ExportPeerHistory fetches the reputation of the peers of an op-node, and writes it as JSON to the given path, or stdout for "-".
+func ExportPeerHistory(ctx context.Context, rpcAddr string, out string) error {
+	rpcClient, err := rpc.DialContext(ctx, rpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to dial op-node RPC %s: %w", rpcAddr, err)
+	}
+	defer rpcClient.Close()
+	reps, err := p2p.NewClient(rpcClient).PeerHistories(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch peer histories: %w", err)
+	}
+	return jsonutil.WriteJSON(out, reps, 0o644)
+}
+
 func readHexData(r io.Reader) ([]byte, error) {
 	data, err := io.ReadAll(r)
 	if err != nil {
@@ -102,4 +121,23 @@ var Subcommands = cli.Commands{
 			return nil
 		},
 	},
+	{
+		Name:  "export-peer-history",
+		Usage: "Exports the reputation of the peers of a running op-node, with the history behind their scores, as JSON",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "rpc",
+				Usage:    "RPC endpoint of the op-node, with the p2p API enabled",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "Path of the output file, or - for stdout",
+				Value: "-",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return ExportPeerHistory(ctx.Context, ctx.String("rpc"), ctx.String("out"))
+		},
+	},
 }
@@ -0,0 +1,180 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/replay"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+const envVarPrefix = flags.EnvVarPrefix + "_REPLAY"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var (
+	L1Flag = &cli.StringFlag{
+		Name:    "l1",
+		Usage:   "Address of L1 User JSON-RPC endpoint to derive from. May be omitted to only use the recorded L1 data of l1.record-dir",
+		EnvVars: prefixEnvVars("L1_ETH_RPC"),
+	}
+	L1BeaconFlag = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to fetch the blobs of batches from, required after Ecotone unless the blobs were recorded",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
+	L1RecordDirFlag = &cli.StringFlag{
+		Name:    "l1.record-dir",
+		Usage:   "Directory to record the fetched L1 data and blobs in, and to replay the recorded L1 data from",
+		EnvVars: prefixEnvVars("L1_RECORD_DIR"),
+	}
+	L1EndFlag = &cli.Uint64Flag{
+		Name:    "l1.end",
+		Usage:   "Last L1 block to derive from. Defaults to the L1 head",
+		EnvVars: prefixEnvVars("L1_END"),
+	}
+	L2Flag = &cli.StringFlag{
+		Name:     "l2",
+		Usage:    "Address of the JSON-RPC endpoint of the live L2 node to compare the derived blocks with (eth namespace required)",
+		Required: true,
+		EnvVars:  prefixEnvVars("L2_ETH_RPC"),
+	}
+	L2StartFlag = &cli.Uint64Flag{
+		Name:     "l2.start",
+		Usage:    "First L2 block to re-derive",
+		Required: true,
+		EnvVars:  prefixEnvVars("L2_START"),
+	}
+	L2EndFlag = &cli.Uint64Flag{
+		Name:    "l2.end",
+		Usage:   "Last L2 block to re-derive. Defaults to l2.start",
+		EnvVars: prefixEnvVars("L2_END"),
+	}
+	OutFlag = &cli.StringFlag{
+		Name:    "out",
+		Usage:   "Path to write the replay result to as JSON, or - for stdout",
+		EnvVars: prefixEnvVars("OUT"),
+	}
+)
+
+func Command() *cli.Command {
+	cmdFlags := []cli.Flag{L1Flag, L1BeaconFlag, L1RecordDirFlag, L1EndFlag, L2Flag, L2StartFlag, L2EndFlag, OutFlag}
+	cmdFlags = append(cmdFlags, opflags.CLINetworkFlag(envVarPrefix, ""), opflags.CLINetworkRegistryFlag(envVarPrefix, ""), opflags.CLIRollupConfigFlag(envVarPrefix, ""))
+	return &cli.Command{
+		Name:  "replay",
+		Usage: "Re-derives a range of L2 blocks from L1 and compares them with a live L2 node",
+		Description: "Runs the derivation pipeline from the L1 data of the L2 block range, and compares the derived " +
+			"payload attributes of every block with the transactions of the live L2 node. Reports the first divergence, " +
+			"with the differing fields decoded. The fetched L1 data can be recorded with l1.record-dir, to repeat the replay without an L1 node.",
+		Flags:  cmdFlags,
+		Action: Main,
+	}
+}
+
+func Main(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	oplog.SetGlobalLogHandler(logger.Handler())
+
+	rollupCfg, err := opnode.NewRollupConfigFromCLI(logger, ctx)
+	if err != nil {
+		return err
+	}
+	start := ctx.Uint64(L2StartFlag.Name)
+	end := ctx.Uint64(L2EndFlag.Name)
+	if !ctx.IsSet(L2EndFlag.Name) {
+		end = start
+	}
+
+	l1, l1Blobs, closeL1, err := newL1Sources(ctx, logger, rollupCfg)
+	if err != nil {
+		return err
+	}
+	defer closeL1()
+
+	l2RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(L2Flag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L2 address: %w", err)
+	}
+	defer l2RPC.Close()
+	l2, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(rollupCfg, false))
+	if err != nil {
+		return fmt.Errorf("failed to create L2 client: %w", err)
+	}
+
+	result, err := replay.NewReplayer(logger, rollupCfg, l1, l1Blobs, l2).Run(ctx.Context, start, end, ctx.Uint64(L1EndFlag.Name))
+	if err != nil {
+		return err
+	}
+	if err := jsonutil.WriteJSON(ctx.String(OutFlag.Name), result, 0o644); err != nil {
+		return err
+	}
+	logger.Info("Replay done", "matched", result.Matched, "last_matched", result.LastMatched, "l1_origin", result.L1Origin)
+	if result.L1Exhausted {
+		logger.Warn("L1 range ended before the end of the L2 range was derived", "next", result.LastMatched.Number+1)
+	}
+	if d := result.Divergence; d != nil {
+		logger.Error("Derived block does not match the live block", "block", d.Block, "reason", d.Reason)
+		for _, line := range d.Explanation {
+			logger.Error("Difference: " + line)
+		}
+		return fmt.Errorf("derivation diverged at L2 block %s", d.Block)
+	}
+	return nil
+}
+
+// newL1Sources creates the L1 sources to derive from: the live L1 node, the recorded L1 data, or both.
+func newL1Sources(ctx *cli.Context, logger log.Logger, rollupCfg *rollup.Config) (derive.L1Fetcher, derive.L1BlobsFetcher, func(), error) {
+	recordDir := ctx.String(L1RecordDirFlag.Name)
+	if !ctx.IsSet(L1Flag.Name) && recordDir == "" {
+		return nil, nil, nil, errors.New("either an L1 endpoint or an L1 record dir is required")
+	}
+	closeL1 := func() {}
+	var l1 derive.L1Fetcher
+	var l1Blobs derive.L1BlobsFetcher
+	if ctx.IsSet(L1Flag.Name) {
+		l1RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(L1Flag.Name))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to dial L1 address: %w", err)
+		}
+		closeL1 = l1RPC.Close
+		l1Client, err := sources.NewL1Client(l1RPC, logger, nil, sources.L1ClientDefaultConfig(rollupCfg, false, sources.RPCKindStandard))
+		if err != nil {
+			l1RPC.Close()
+			return nil, nil, nil, fmt.Errorf("failed to create L1 client: %w", err)
+		}
+		l1 = l1Client
+	}
+	if ctx.IsSet(L1BeaconFlag.Name) {
+		beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(ctx.String(L1BeaconFlag.Name), logger))
+		l1Blobs = sources.NewL1BeaconClient(beacon, sources.L1BeaconClientConfig{})
+	}
+	if recordDir == "" {
+		return l1, l1Blobs, closeL1, nil
+	}
+
+	recorder, err := replay.NewL1Recorder(recordDir, l1)
+	if err != nil {
+		closeL1()
+		return nil, nil, nil, err
+	}
+	blobRecorder, err := replay.NewBlobRecorder(recordDir, l1Blobs)
+	if err != nil {
+		closeL1()
+		return nil, nil, nil, err
+	}
+	return recorder, blobRecorder, closeL1, nil
+}
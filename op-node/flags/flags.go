@@ -10,6 +10,10 @@ import (
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	// [Kroma: START]
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
+	// [Kroma: END]
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/urfave/cli/v2"
 )
@@ -41,7 +45,7 @@ var (
 	/* Required Flags */
 	L1NodeAddr = &cli.StringFlag{
 		Name:     "l1",
-		Usage:    "Address of L1 User JSON-RPC endpoint to use (eth namespace required)",
+		Usage:    "Address of L1 User JSON-RPC endpoint to use (eth namespace required). Multiple comma-separated addresses enable failover between them",
 		Value:    "http://127.0.0.1:8545",
 		EnvVars:  prefixEnvVars("L1_ETH_RPC"),
 		Category: RollupCategory,
@@ -302,6 +306,65 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	// [Kroma: START]
+	CheckpointL2RPC = &cli.StringFlag{
+		Name:     "checkpoint.l2",
+		Usage:    "Address of an L2 execution engine RPC to fetch the checkpoint block from. Enables checkpoint sync: a node without finalized blocks syncs its execution engine to the checkpoint and derives from there, instead of from genesis. The fetched block is verified against the checkpoint output root, the RPC does not need to be trusted.",
+		EnvVars:  prefixEnvVars("CHECKPOINT_L2"),
+		Category: RollupCategory,
+	}
+	CheckpointL2OutputOracle = &cli.StringFlag{
+		Name:     "checkpoint.l2-output-oracle",
+		Usage:    "Address of the L1 L2OutputOracle contract. The latest finalized output is used as checkpoint.",
+		EnvVars:  prefixEnvVars("CHECKPOINT_L2_OUTPUT_ORACLE"),
+		Category: RollupCategory,
+	}
+	CheckpointFile = &cli.StringFlag{
+		Name:     "checkpoint.file",
+		Usage:    "Path to a signed checkpoint file, used as checkpoint instead of the L2OutputOracle.",
+		EnvVars:  prefixEnvVars("CHECKPOINT_FILE"),
+		Category: RollupCategory,
+	}
+	CheckpointSigner = &cli.StringFlag{
+		Name:     "checkpoint.signer",
+		Usage:    "Address the checkpoint file must be signed by.",
+		EnvVars:  prefixEnvVars("CHECKPOINT_SIGNER"),
+		Category: RollupCategory,
+	}
+	SequencerPriorityGasShareFlag = &cli.Uint64Flag{
+		Name:     "sequencer.priority-gas-share",
+		Usage:    "Maximum percentage of the block gas limit the priority txs submitted with admin_submitPriorityTx may use, after the deposits of the block. The tx pool fills the rest of the block. Disables the inclusion list if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_PRIORITY_GAS_SHARE"),
+		Value:    10,
+		Category: SequencerCategory,
+	}
+	SequencerInclusionListPolicyFlag = &cli.StringFlag{
+		Name:     "sequencer.inclusion-list-policy",
+		Usage:    "Ordering policy of the priority txs of different senders. Options: 'fifo' (arrival order), 'fee' (highest tip first).",
+		EnvVars:  prefixEnvVars("SEQUENCER_INCLUSION_LIST_POLICY"),
+		Value:    "fifo",
+		Category: SequencerCategory,
+	}
+	SequencerAdaptiveL1Confs = &cli.BoolFlag{
+		Name:     "sequencer.l1-confs-adaptive",
+		Usage:    "Adapt the L1 confirmation depth of the sequencer to the recent L1 reorgs and the participation of the L1 beacon chain, between sequencer.l1-confs and sequencer.l1-confs-max. The participation is read from the L1 beacon API if configured.",
+		EnvVars:  prefixEnvVars("SEQUENCER_L1_CONFS_ADAPTIVE"),
+		Category: SequencerCategory,
+	}
+	SequencerMaxL1Confs = &cli.Uint64Flag{
+		Name:     "sequencer.l1-confs-max",
+		Usage:    "Maximum number of L1 blocks to keep distance from the L1 head as a sequencer in the adaptive mode. It must leave time to adopt an L1 origin within the max sequencer drift.",
+		EnvVars:  prefixEnvVars("SEQUENCER_L1_CONFS_MAX"),
+		Value:    16,
+		Category: SequencerCategory,
+	}
+	RPCPolicy = &cli.StringFlag{
+		Name:     "rpc.policy",
+		Usage:    "Path to a JSON RPC policy with per-method allow/deny, auth and rate limit rules, e.g. to serve optimism_outputAtBlock publicly while admin_* and opp2p_* require a JWT.",
+		EnvVars:  prefixEnvVars("RPC_POLICY"),
+		Category: OperationsCategory,
+	}
+	// [Kroma: END]
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -413,6 +476,15 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	CheckpointL2RPC,
+	CheckpointL2OutputOracle,
+	CheckpointFile,
+	CheckpointSigner,
+	SequencerPriorityGasShareFlag,
+	SequencerInclusionListPolicyFlag,
+	SequencerAdaptiveL1Confs,
+	SequencerMaxL1Confs,
+	RPCPolicy,
 }
 
 var DeprecatedFlags = []cli.Flag{
@@ -435,6 +507,10 @@ func init() {
 	optionalFlags = append(optionalFlags, DeprecatedFlags...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix, RollupCategory)...)
 	optionalFlags = append(optionalFlags, plasma.CLIFlags(EnvVarPrefix, PlasmaCategory)...)
+	// [Kroma: START]
+	optionalFlags = append(optionalFlags, opsigner.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, tracing.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
+	// [Kroma: END]
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
@@ -54,6 +54,13 @@ var (
 	GossipFloodPublishName = "p2p.gossip.mesh.floodpublish"
 	SyncReqRespName        = "p2p.sync.req-resp"
 	P2PPingName            = "p2p.ping"
+	// OutputAttestationsValidatorPoolName enables gossip of validator output attestations
+	OutputAttestationsValidatorPoolName = "p2p.attestations.validator-pool"
+	// TxForwardingName enables forwarding txs to the sequencer peer
+	TxForwardingName = "p2p.tx-forwarding"
+	// SharedPeerListName loads a ban and trusted peer list shared by the nodes of an operator
+	SharedPeerListName        = "p2p.shared-list"
+	SharedPeerListRefreshName = "p2p.shared-list.refresh"
 )
 
 func deprecatedP2PFlags(envPrefix string) []cli.Flag {
@@ -401,5 +408,34 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVars:  p2pEnv(envPrefix, "PING"),
 		},
+		&cli.StringFlag{
+			Name:     OutputAttestationsValidatorPoolName,
+			Usage:    "Address of the L1 ValidatorPool contract. If set, the node joins the gossip topic of output roots attested by validators, and checks the attestation signers against the ValidatorPool.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "ATTESTATIONS_VALIDATOR_POOL"),
+			Category: P2PCategory,
+		},
+		&cli.BoolFlag{
+			Name:     TxForwardingName,
+			Usage:    "Enables forwarding txs to the sequencer peer, which answers with a signed preconfirmation. A sequencer with a p2p sequencer key serves the forwarded txs through its inclusion list, see --sequencer.priority-gas-share.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "TX_FORWARDING"),
+			Category: P2PCategory,
+		},
+		&cli.StringFlag{
+			Name:     SharedPeerListName,
+			Usage:    "File path or HTTP(S) URL of a JSON list of banned peers, IPs and subnets, and of trusted peer groups, shared by the nodes of an operator. The list is reloaded periodically and applied to the connection gater, the peer bans and the protected peers.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SHARED_LIST"),
+			Category: P2PCategory,
+		},
+		&cli.DurationFlag{
+			Name:     SharedPeerListRefreshName,
+			Usage:    "Interval to reload the shared peer list at.",
+			Required: false,
+			Value:    time.Minute,
+			EnvVars:  p2pEnv(envPrefix, "SHARED_LIST_REFRESH"),
+			Category: P2PCategory,
+		},
 	}
 }
@@ -36,6 +36,9 @@ type Metricer interface {
 	RecordRPCServerRequest(method string) func()
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	// [Kroma: START]
+	RecordRPCServerRejection(method string, reason string)
+	// [Kroma: END]
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
 	RecordSequencingError()
@@ -47,6 +50,10 @@ type Metricer interface {
 	RecordL2Ref(name string, ref eth.L2BlockRef)
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 	RecordDerivedBatches(batchType string)
+	// [Kroma: START]
+	RecordDerivationEvent(stage string, event string)
+	RecordSequencerAdaptiveConfDepth(depth uint64, reorgDepth uint64, participation float64)
+	// [Kroma: END]
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
@@ -95,6 +102,14 @@ type Metrics struct {
 
 	DerivedBatches metrics.EventVec
 
+	// [Kroma: START]
+	DerivationEvents metrics.EventVec
+
+	SequencerConfDepth       prometheus.Gauge
+	SequencerConfDepthReorg  prometheus.Gauge
+	SequencerL1Participation prometheus.Gauge
+	// [Kroma: END]
+
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
@@ -200,6 +215,26 @@ func NewMetrics(procName string) *Metrics {
 
 		DerivedBatches: metrics.NewEventVec(factory, ns, "", "derived_batches", "derived batches", []string{"type"}),
 
+		// [Kroma: START]
+		DerivationEvents: metrics.NewEventVec(factory, ns, "", "derivation_events", "derivation pipeline stage events", []string{"stage", "event"}),
+
+		SequencerConfDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_l1_conf_depth",
+			Help:      "Current L1 confirmation depth of the sequencer in the adaptive mode",
+		}),
+		SequencerConfDepthReorg: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_l1_conf_depth_reorg",
+			Help:      "Depth of the deepest recent L1 reorg the adaptive L1 confirmation depth covers",
+		}),
+		SequencerL1Participation: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_l1_participation",
+			Help:      "Sync committee participation of the L1 beacon chain head the adaptive L1 confirmation depth follows",
+		}),
+		// [Kroma: END]
+
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
 
@@ -443,6 +478,19 @@ func (m *Metrics) RecordDerivedBatches(batchType string) {
 	m.DerivedBatches.Record(batchType)
 }
 
+// [Kroma: START]
+func (m *Metrics) RecordDerivationEvent(stage string, event string) {
+	m.DerivationEvents.Record(stage, event)
+}
+
+func (m *Metrics) RecordSequencerAdaptiveConfDepth(depth uint64, reorgDepth uint64, participation float64) {
+	m.SequencerConfDepth.Set(float64(depth))
+	m.SequencerConfDepthReorg.Set(float64(reorgDepth))
+	m.SequencerL1Participation.Set(participation)
+}
+
+// [Kroma: END]
+
 func (m *Metrics) CountSequencedTxs(count int) {
 	m.TransactionsSequencedTotal.Add(float64(count))
 }
@@ -636,6 +684,15 @@ func (n *noopMetricer) RecordUnsafePayloadsBuffer(length uint64, memSize uint64,
 func (n *noopMetricer) RecordDerivedBatches(batchType string) {
 }
 
+// [Kroma: START]
+func (n *noopMetricer) RecordDerivationEvent(stage string, event string) {
+}
+
+func (n *noopMetricer) RecordSequencerAdaptiveConfDepth(depth uint64, reorgDepth uint64, participation float64) {
+}
+
+// [Kroma: END]
+
 func (n *noopMetricer) CountSequencedTxs(count int) {
 }
 
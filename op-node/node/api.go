@@ -44,6 +44,8 @@ type driverClient interface {
 
 	// [Kroma: START]
 	BlockRefsWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, eth.L2BlockRef, *eth.SyncStatus, error)
+	SubmitPriorityTx(ctx context.Context, tx hexutil.Bytes) (common.Hash, error)
+	PreconfirmTx(ctx context.Context, tx hexutil.Bytes) (uint64, error)
 	// [Kroma: END]
 }
 
@@ -103,6 +105,17 @@ func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.Executio
 	return n.dr.OnUnsafeL2Payload(ctx, envelope)
 }
 
+// [Kroma: START]
+// SubmitPriorityTx queues a signed tx in the inclusion list of the sequencer,
+// to be forced into one of the next blocks after the deposits.
+func (n *adminAPI) SubmitPriorityTx(ctx context.Context, tx hexutil.Bytes) (common.Hash, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_submitPriorityTx")
+	defer recordDur()
+	return n.dr.SubmitPriorityTx(ctx, tx)
+}
+
+// [Kroma: END]
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -0,0 +1,180 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+// CheckpointConfig configures checkpoint sync: a fresh node EL-syncs to a trusted L2 output
+// and starts deriving from there, instead of replaying the chain from genesis.
+type CheckpointConfig struct {
+	// L2RpcAddr is the RPC of an L2 execution engine to fetch the checkpoint block and its output from.
+	// It does not need to be trusted: the fetched data is verified against the trusted output root.
+	// Checkpoint sync is disabled if empty.
+	L2RpcAddr string
+
+	// L2OutputOracle is the L1 contract the latest finalized output is taken from as checkpoint.
+	L2OutputOracle common.Address
+
+	// File is the path to a checkpoint file, used instead of the L2OutputOracle.
+	File string
+	// Signer is the address the checkpoint file must be signed by.
+	Signer common.Address
+}
+
+func (c *CheckpointConfig) Enabled() bool {
+	return c.L2RpcAddr != ""
+}
+
+func (c *CheckpointConfig) Check() error {
+	if !c.Enabled() {
+		if c.File != "" || c.L2OutputOracle != (common.Address{}) {
+			return errors.New("checkpoint L2 RPC is required for checkpoint sync")
+		}
+		return nil
+	}
+	if (c.File == "") == (c.L2OutputOracle == (common.Address{})) {
+		return errors.New("exactly one of checkpoint file or L2OutputOracle must be set")
+	}
+	if c.File != "" && c.Signer == (common.Address{}) {
+		return errors.New("checkpoint file requires a checkpoint signer")
+	}
+	return nil
+}
+
+type checkpointL2Source interface {
+	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+// initCheckpoint loads and verifies the checkpoint to sync to, if the execution engine has no finalized block yet.
+func (n *OpNode) initCheckpoint(ctx context.Context, cfg *Config) error {
+	if !cfg.Checkpoint.Enabled() {
+		return nil
+	}
+	finalized, err := n.l2Source.L2BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("failed to fetch finalized L2 block: %w", err)
+	}
+	if err == nil && finalized.Hash != cfg.Rollup.Genesis.L2.Hash {
+		n.log.Info("Skipping checkpoint sync, execution engine already has a finalized block", "finalized", finalized)
+		return nil
+	}
+
+	var checkpoint *sync.Checkpoint
+	if cfg.Checkpoint.File != "" {
+		checkpoint, err = sync.LoadCheckpointFile(cfg.Checkpoint.File, cfg.Rollup.L2ChainID, cfg.Checkpoint.Signer)
+	} else {
+		checkpoint, err = latestFinalizedOutput(ctx, n.l1RPC, cfg.Checkpoint.L2OutputOracle)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	rpc, err := client.NewRPC(ctx, n.log, cfg.Checkpoint.L2RpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to dial checkpoint L2 RPC: %w", err)
+	}
+	defer rpc.Close()
+	l2, err := sources.NewL2Client(rpc, n.log, nil, sources.L2ClientDefaultConfig(&cfg.Rollup, false))
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint L2 client: %w", err)
+	}
+	envelope, err := fetchCheckpointPayload(ctx, l2, checkpoint)
+	if err != nil {
+		return err
+	}
+	n.log.Info("Loaded checkpoint", "number", checkpoint.L2BlockNumber, "hash", envelope.ExecutionPayload.BlockHash, "outputRoot", checkpoint.OutputRoot)
+	cfg.Sync.Checkpoint = envelope
+	return nil
+}
+
+// fetchCheckpointPayload fetches the payload of the checkpoint block, and verifies it against the checkpoint output root.
+// The output root commits to the block hash, so the payload is trusted once its output matches.
+func fetchCheckpointPayload(ctx context.Context, l2 checkpointL2Source, checkpoint *sync.Checkpoint) (*eth.ExecutionPayloadEnvelope, error) {
+	envelope, err := l2.PayloadByNumber(ctx, checkpoint.L2BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checkpoint block %d: %w", checkpoint.L2BlockNumber, err)
+	}
+	if actual, ok := envelope.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("checkpoint block %d has bad block hash %s, expected %s", checkpoint.L2BlockNumber, envelope.ExecutionPayload.BlockHash, actual)
+	}
+	// the Kroma output root commits to the hash of the next block as well
+	next, err := l2.PayloadByNumber(ctx, checkpoint.L2BlockNumber+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block %d after checkpoint: %w", checkpoint.L2BlockNumber+1, err)
+	}
+	if next.ExecutionPayload.ParentHash != envelope.ExecutionPayload.BlockHash {
+		return nil, fmt.Errorf("block after checkpoint has parent %s, expected %s", next.ExecutionPayload.ParentHash, envelope.ExecutionPayload.BlockHash)
+	}
+	output, err := l2.OutputV0AtBlock(ctx, envelope.ExecutionPayload.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checkpoint output: %w", err)
+	}
+	output.NextBlockHash = next.ExecutionPayload.BlockHash
+	if err := checkpoint.Verify(output); err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// latestFinalizedOutput returns the latest output of the L2OutputOracle that is finalized.
+func latestFinalizedOutput(ctx context.Context, rpc batching.EthRpc, addr common.Address) (*sync.Checkpoint, error) {
+	abi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L2OutputOracle ABI: %w", err)
+	}
+	caller := batching.NewMultiCaller(rpc, batching.DefaultBatchSize)
+	contract := batching.NewBoundContract(abi, addr)
+
+	result, err := caller.SingleCall(ctx, rpcblock.Latest, contract.Call("latestOutputIndex"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest output index: %w", err)
+	}
+	latest := result.GetBigInt(0).Uint64()
+
+	// Outputs are finalized in order, so search for the first output that is not finalized yet.
+	var searchErr error
+	idx := sort.Search(int(latest)+1, func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		result, err := caller.SingleCall(ctx, rpcblock.Latest, contract.Call("isFinalized", new(big.Int).SetUint64(uint64(i))))
+		if err != nil {
+			searchErr = fmt.Errorf("failed to check if output %d is finalized: %w", i, err)
+			return true
+		}
+		return !result.GetBool(0)
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if idx == 0 {
+		return nil, errors.New("no finalized output in L2OutputOracle")
+	}
+
+	result, err = caller.SingleCall(ctx, rpcblock.Latest, contract.Call("getL2Output", big.NewInt(int64(idx-1))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output %d: %w", idx-1, err)
+	}
+	var output bindings.TypesCheckpointOutput
+	result.GetStruct(0, &output)
+	return &sync.Checkpoint{
+		L2BlockNumber: output.L2BlockNumber.Uint64(),
+		OutputRoot:    output.OutputRoot,
+	}, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -59,6 +60,12 @@ type Config struct {
 
 	Sync sync.Config
 
+	// [Kroma: START]
+	Checkpoint CheckpointConfig
+
+	Tracing tracing.CLIConfig
+	// [Kroma: END]
+
 	/* [Kroma: START]
 	// To halt when detecting the node does not support a signaled protocol version
 	// change of the given severity (major/minor/patch). Disabled if empty.
@@ -84,6 +91,10 @@ type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// [Kroma: START]
+	// PolicyPath is the path of the RPC policy, see oprpc.Policy. All the methods are open if empty.
+	PolicyPath string
+	// [Kroma: END]
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -157,11 +168,24 @@ func (cfg *Config) Check() error {
 	if err := cfg.Pprof.Check(); err != nil {
 		return fmt.Errorf("pprof config error: %w", err)
 	}
+	// [Kroma: START]
+	if err := cfg.Tracing.Check(); err != nil {
+		return fmt.Errorf("tracing config error: %w", err)
+	}
+	// [Kroma: END]
 	if cfg.P2P != nil {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	// [Kroma: START]
+	if err := cfg.Checkpoint.Check(); err != nil {
+		return fmt.Errorf("checkpoint config error: %w", err)
+	}
+	if err := cfg.Driver.Check(); err != nil {
+		return fmt.Errorf("driver config error: %w", err)
+	}
+	// [Kroma: END]
 	/* [Kroma: START]
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
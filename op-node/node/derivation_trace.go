@@ -0,0 +1,38 @@
+package node
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type derivationTracer interface {
+	DerivationTrace() *derive.DerivationTrace
+}
+
+// derivationTraceAPI serves the state and recent events of the derivation stages,
+// to find out why the safe head does not progress.
+type derivationTraceAPI struct {
+	tracer derivationTracer
+	log    log.Logger
+	m      metrics.RPCMetricer
+}
+
+func NewDerivationTraceAPI(tracer derivationTracer, log log.Logger, m metrics.RPCMetricer) *derivationTraceAPI {
+	return &derivationTraceAPI{
+		tracer: tracer,
+		log:    log,
+		m:      m,
+	}
+}
+
+// DerivationTrace returns the origin, event counts and last event of every derivation stage,
+// and the most recent events of all stages.
+func (api *derivationTraceAPI) DerivationTrace(_ context.Context) (*derive.DerivationTrace, error) {
+	recordDur := api.m.RecordRPCServerRequest("kroma_derivationTrace")
+	defer recordDur()
+	return api.tracer.DerivationTrace(), nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 var ErrAlreadyClosed = errors.New("node is already closed")
@@ -52,6 +54,7 @@ type OpNode struct {
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
 	l1Source  *sources.L1Client     // L1 Client to fetch data from
+	l1RPC     client.RPC            // L1 RPC, shared with l1Source, used for contract calls
 	l2Driver  *driver.Driver        // L2 Engine to Sync
 	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
 	server    *rpcServer            // RPC server hosting the rollup-node API
@@ -68,6 +71,9 @@ type OpNode struct {
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	// [Kroma: START]
+	stopTracing func(ctx context.Context) error
+	// [Kroma: END]
 
 	beacon *sources.L1BeaconClient
 
@@ -128,6 +134,13 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initTracer(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the trace: %w", err)
 	}
+	// [Kroma: START]
+	stopTracing, err := tracing.Start(cfg.Tracing, "op-node", n.appVersion)
+	if err != nil {
+		return fmt.Errorf("failed to start tracing: %w", err)
+	}
+	n.stopTracing = stopTracing
+	// [Kroma: END]
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
@@ -177,11 +190,12 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("failed to get L1 RPC client: %w", err)
 	}
 
+	n.l1RPC = client.NewInstrumentedRPC(l1Node, n.metrics)
+
 	// Set the RethDB path in the EthClientConfig, if there is one configured.
 	rpcCfg.EthClientConfig.RethDBPath = cfg.RethDBPath
 
-	n.l1Source, err = sources.NewL1Client(
-		client.NewInstrumentedRPC(l1Node, n.metrics), n.log, n.metrics.L1SourceCache, rpcCfg)
+	n.l1Source, err = sources.NewL1Client(n.l1RPC, n.log, n.metrics.L1SourceCache, rpcCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
@@ -394,6 +408,10 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
+	if err := n.initCheckpoint(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init checkpoint sync: %w", err)
+	}
+
 	var sequencerConductor conductor.SequencerConductor = &conductor.NoOpConductor{}
 	if cfg.ConductorEnabled {
 		sequencerConductor = NewConductorClient(cfg, n.log, n.metrics)
@@ -416,6 +434,15 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		n.safeDB = safedb.Disabled
 	}
 	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, plasmaDA)
+	// [Kroma: START]
+	if cfg.Driver.SequencerAdaptiveConfDepth {
+		if n.beacon != nil {
+			n.l2Driver.SetL1ParticipationSource(n.beacon)
+		} else {
+			n.log.Warn("No L1 beacon API configured, the adaptive L1 confirmation depth only follows L1 reorgs")
+		}
+	}
+	// [Kroma: END]
 	return nil
 }
 
@@ -426,7 +453,14 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	}
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
+		if cfg.P2P.TxForwardingEnabled() {
+			server.EnableTxForwarding(NewTxForwardingAPI(n.p2pNode, n.log.New("rpc", "tx_forwarding"), n.metrics))
+		}
 	}
+	// [Kroma: START]
+	server.EnableDerivationTrace(NewDerivationTraceAPI(n.l2Driver, n.log.New("rpc", "derivation_trace"), n.metrics))
+	server.EnableSyncStatusEvents(NewSyncStatusEventsAPI(n.l2Driver, n.log.New("rpc", "sync_status_events"), n.metrics))
+	// [Kroma: END]
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
@@ -499,12 +533,30 @@ func (n *OpNode) initPProf(cfg *Config) error {
 
 func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
 	if cfg.P2P != nil {
+		var validators p2p.ValidatorSet
+		if addr := cfg.P2P.OutputAttestationsValidatorPool(); addr != (common.Address{}) {
+			valSet, err := NewValidatorPoolSet(n.l1RPC, addr)
+			if err != nil {
+				return fmt.Errorf("failed to set up output attestations validator set: %w", err)
+			}
+			validators = valSet
+		}
 		// TODO(protocol-quest/97): Use EL Sync instead of CL Alt sync for fetching missing blocks in the payload queue.
-		p2pNode, err := p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, n.l2Source, n.runCfg, n.metrics, false)
+		p2pNode, err := p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, n.l2Source, n.runCfg, validators, n.l2Driver, n.metrics, false)
 		if err != nil || p2pNode == nil {
 			return err
 		}
 		n.p2pNode = p2pNode
+		// [Kroma: START]
+		// the sequencer keeps its preconfirmations through its inclusion list, which needs a priority gas share
+		if cfg.P2P.TxForwardingEnabled() && cfg.Driver.SequencerEnabled && cfg.Driver.SequencerPriorityGasShare > 0 &&
+			n.p2pSigner != nil {
+			backend := &txForwardingBackend{dr: n.l2Driver}
+			if err := n.p2pNode.ServeTxForwarding(n.resourcesCtx, &cfg.Rollup, backend, n.p2pSigner); err != nil {
+				return fmt.Errorf("failed to serve tx forwarding: %w", err)
+			}
+		}
+		// [Kroma: END]
 		if n.p2pNode.Dv5Udp() != nil {
 			go n.p2pNode.DiscoveryProcess(n.resourcesCtx, n.log, &cfg.Rollup, cfg.P2P.TargetPeers())
 		}
@@ -520,6 +572,12 @@ func (n *OpNode) initP2PSigner(ctx context.Context, cfg *Config) error {
 	// p2pSigner may still be nil, the signer setup may not create any signer, the signer is optional
 	var err error
 	n.p2pSigner, err = cfg.P2PSigner.SetupSigner(ctx)
+	// [Kroma: START]
+	if remoteSigner, ok := n.p2pSigner.(*p2p.RemoteSigner); ok {
+		// sign with the unsafe block signer of the SystemConfig, so the key follows its rotations
+		remoteSigner.FollowSignerAddress(n.runCfg.P2PSequencerAddress)
+	}
+	// [Kroma: END]
 	return err
 }
 
@@ -732,6 +790,13 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	// [Kroma: START]
+	if n.stopTracing != nil {
+		if err := n.stopTracing(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to stop tracing: %w", err))
+		}
+	}
+	// [Kroma: END]
 
 	return result.ErrorOrNil()
 }
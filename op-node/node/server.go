@@ -15,7 +15,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 type rpcServer struct {
@@ -25,6 +27,12 @@ type rpcServer struct {
 	appVersion string
 	log        log.Logger
 	sources.L2Client
+	// [Kroma: START]
+	// wsApis are the subscription APIs, which are the only APIs served over websocket.
+	wsApis  []rpc.API
+	policy  *oprpc.Policy
+	metrics metrics.Metricer
+	// [Kroma: END]
 }
 
 func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, safedb SafeDBReader, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
@@ -44,7 +52,19 @@ func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthCli
 		}},
 		appVersion: appVersion,
 		log:        log,
+		// [Kroma: START]
+		metrics: m,
+		// [Kroma: END]
 	}
+	// [Kroma: START]
+	if rpcCfg.PolicyPath != "" {
+		policy, err := oprpc.LoadPolicy(rpcCfg.PolicyPath)
+		if err != nil {
+			return nil, err
+		}
+		r.policy = policy
+	}
+	// [Kroma: END]
 	return r, nil
 }
 
@@ -66,6 +86,45 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	})
 }
 
+// [Kroma: START]
+// EnableTxForwarding adds kroma_sendRawTransaction, which forwards txs to the sequencer over p2p.
+func (s *rpcServer) EnableTxForwarding(api *txForwardingAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "kroma",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+// EnableDerivationTrace adds kroma_derivationTrace, which returns the state of the derivation stages.
+func (s *rpcServer) EnableDerivationTrace(api *derivationTraceAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "kroma",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+// EnableSyncStatusEvents adds the syncStatus subscription to the optimism and kroma namespaces,
+// which streams the changes of the sync status over websocket.
+func (s *rpcServer) EnableSyncStatusEvents(api *syncStatusEventsAPI) {
+	s.wsApis = append(s.wsApis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	}, rpc.API{
+		Namespace:     "kroma",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+// [Kroma: END]
+
 func (s *rpcServer) Start() error {
 	srv := rpc.NewServer()
 	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
@@ -78,8 +137,34 @@ func (s *rpcServer) Start() error {
 	// calling into the opnode without an "invalid host" error.
 	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
 
+	/* [Kroma: START]
 	mux := http.NewServeMux()
 	mux.Handle("/", nodeHandler)
+	[Kroma: END] */
+	// [Kroma: START]
+	// Serve websocket on the same endpoint, for the subscriptions. The websocket calls bypass the checks of the
+	// policy on each call, so they are served by a server that has the subscription APIs only.
+	wsSrv := rpc.NewServer()
+	if err := node.RegisterApis(s.wsApis, nil, wsSrv); err != nil {
+		return err
+	}
+	wsHandler := node.NewWSHandlerStack(wsSrv.WebsocketHandler([]string{"*"}), nil)
+	var rpcHandler http.Handler
+	if s.policy != nil {
+		rpcHandler = oprpc.NewWebsocketPolicyHandler(nodeHandler, wsHandler, s.policy, nil, s.log, s.metrics, s.wsMethods())
+	} else {
+		rpcHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if oprpc.IsWebsocket(r) {
+				wsHandler.ServeHTTP(w, r)
+				return
+			}
+			nodeHandler.ServeHTTP(w, r)
+		})
+	}
+	rpcHandler = tracing.NewHTTPHandler(rpcHandler, tracing.Tracer("op-node/node"))
+	mux := http.NewServeMux()
+	mux.Handle("/", rpcHandler)
+	// [Kroma: END]
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
 
 	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
@@ -98,6 +183,18 @@ func (r *rpcServer) Addr() net.Addr {
 	return r.httpServer.Addr()
 }
 
+// [Kroma: START]
+// wsMethods returns the methods served over websocket, the subscribe and unsubscribe methods of the subscription APIs.
+func (s *rpcServer) wsMethods() []string {
+	methods := make([]string, 0, 2*len(s.wsApis))
+	for _, api := range s.wsApis {
+		methods = append(methods, api.Namespace+"_subscribe", api.Namespace+"_unsubscribe")
+	}
+	return methods
+}
+
+// [Kroma: END]
+
 func healthzHandler(appVersion string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(appVersion))
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -230,6 +233,55 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestWebsocketPolicy(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	startServer := func(policy string) *rpcServer {
+		policyPath := filepath.Join(t.TempDir(), "policy.json")
+		require.NoError(t, os.WriteFile(policyPath, []byte(policy), 0o600))
+		rpcCfg := &RPCConfig{
+			ListenAddr: "localhost",
+			ListenPort: 0,
+			PolicyPath: policyPath,
+		}
+		drClient := &mockDriverClient{}
+		server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, drClient, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+		require.NoError(t, err)
+		server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+		server.EnableSyncStatusEvents(NewSyncStatusEventsAPI(nil, log, metrics.NoopMetrics))
+		require.NoError(t, server.Start())
+		t.Cleanup(func() {
+			require.NoError(t, server.Stop(context.Background()))
+		})
+		return server
+	}
+
+	server := startServer(`{"rules": {"admin_*": {"deny": true}}}`)
+	client, err := rpc.Dial("ws://" + server.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	// the websocket serves the subscriptions only, so the denied methods cannot be called over it
+	err = client.Call(nil, "admin_sequencerActive")
+	require.ErrorContains(t, err, "does not exist")
+	err = client.Call(nil, "optimism_syncStatus")
+	require.ErrorContains(t, err, "does not exist")
+	// a POST that asks for an upgrade is not a websocket, so its calls are checked
+	for _, headers := range [][]rpc.ClientOption{
+		{rpc.WithHeader("Upgrade", "websocket")},
+		{rpc.WithHeader("Upgrade", "websocket"), rpc.WithHeader("Connection", "Upgrade")},
+	} {
+		httpClient, err := rpc.DialOptions(context.Background(), "http://"+server.Addr().String(), headers...)
+		require.NoError(t, err)
+		err = httpClient.Call(nil, "admin_sequencerActive")
+		require.ErrorContains(t, err, "is not available")
+		httpClient.Close()
+	}
+
+	// the upgrade is refused if the rules of the subscriptions reject the client
+	server = startServer(`{"rules": {"kroma_*": {"deny": true}}}`)
+	_, err = rpc.Dial("ws://" + server.Addr().String())
+	require.ErrorContains(t, err, "403")
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -293,6 +345,16 @@ func (c *mockDriverClient) BlockRefsWithStatus(ctx context.Context, num uint64)
 	return m[0].(eth.L2BlockRef), m[1].(eth.L2BlockRef), m[2].(*eth.SyncStatus), *m[3].(*error)
 }
 
+func (c *mockDriverClient) SubmitPriorityTx(ctx context.Context, tx hexutil.Bytes) (common.Hash, error) {
+	m := c.Mock.MethodCalled("SubmitPriorityTx", tx)
+	return m[0].(common.Hash), *m[1].(*error)
+}
+
+func (c *mockDriverClient) PreconfirmTx(ctx context.Context, tx hexutil.Bytes) (uint64, error) {
+	m := c.Mock.MethodCalled("PreconfirmTx", tx)
+	return m[0].(uint64), *m[1].(*error)
+}
+
 func (c *mockDriverClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return c.Mock.MethodCalled("SyncStatus").Get(0).(*eth.SyncStatus), nil
 }
@@ -0,0 +1,67 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type syncStatusSubscriber interface {
+	SubscribeSyncStatus() *driver.SyncStatusSubscription
+}
+
+// syncStatusEventsAPI streams the changes of the sync status over websocket subscriptions,
+// so clients can react to new heads, resets and derivation errors instead of polling the sync status.
+type syncStatusEventsAPI struct {
+	driver syncStatusSubscriber
+	log    log.Logger
+	m      metrics.RPCMetricer
+}
+
+func NewSyncStatusEventsAPI(driver syncStatusSubscriber, log log.Logger, m metrics.RPCMetricer) *syncStatusEventsAPI {
+	return &syncStatusEventsAPI{
+		driver: driver,
+		log:    log,
+		m:      m,
+	}
+}
+
+// SyncStatus is subscribed to with optimism_subscribe or kroma_subscribe, and the "syncStatus" parameter.
+// It streams the changes of the unsafe, safe and finalized heads and of the L1 origin, the pipeline resets and
+// the derivation errors, with the sync status after each of them. The stream stops if the client falls behind,
+// the client then has to fetch the sync status and subscribe again.
+func (api *syncStatusEventsAPI) SyncStatus(ctx context.Context) (*rpc.Subscription, error) {
+	recordDur := api.m.RecordRPCServerRequest("kroma_subscribeSyncStatus")
+	defer recordDur()
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	sub := api.driver.SubscribeSyncStatus()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-sub.Events():
+				if err := notifier.Notify(rpcSub.ID, ev); err != nil {
+					api.log.Debug("Failed to notify sync status event", "id", rpcSub.ID, "err", err)
+					return
+				}
+			case err := <-sub.Err():
+				if errors.Is(err, driver.ErrSyncStatusSubscriberBehind) {
+					api.log.Warn("Stopped sync status subscription of a slow client", "id", rpcSub.ID)
+				}
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// txForwardingBackend serves forwarded txs from the inclusion list of the sequencer.
+type txForwardingBackend struct {
+	dr driverClient
+}
+
+var _ p2p.TxForwardingBackend = (*txForwardingBackend)(nil)
+
+func (b *txForwardingBackend) SequencerActive(ctx context.Context) (bool, error) {
+	return b.dr.SequencerActive(ctx)
+}
+
+func (b *txForwardingBackend) PreconfirmTransaction(ctx context.Context, tx *types.Transaction) (uint64, error) {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode tx: %w", err)
+	}
+	return b.dr.PreconfirmTx(ctx, data)
+}
+
+type txForwarder interface {
+	ForwardTransaction(ctx context.Context, tx *types.Transaction) (*p2p.SignedPreconfirmation, error)
+}
+
+// txForwardingAPI accepts txs on a node that does not expose the sequencer,
+// and forwards them to the sequencer over p2p.
+type txForwardingAPI struct {
+	fwd txForwarder
+	log log.Logger
+	m   metrics.RPCMetricer
+}
+
+func NewTxForwardingAPI(fwd txForwarder, log log.Logger, m metrics.RPCMetricer) *txForwardingAPI {
+	return &txForwardingAPI{
+		fwd: fwd,
+		log: log,
+		m:   m,
+	}
+}
+
+// SendRawTransaction forwards the signed tx to the sequencer, and returns the preconfirmation of the sequencer.
+func (api *txForwardingAPI) SendRawTransaction(ctx context.Context, data hexutil.Bytes) (*p2p.SignedPreconfirmation, error) {
+	recordDur := api.m.RecordRPCServerRequest("kroma_sendRawTransaction")
+	defer recordDur()
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("failed to decode tx: %w", err)
+	}
+	if tx.Type() == types.DepositTxType {
+		return nil, fmt.Errorf("cannot send deposit tx %s", tx.Hash())
+	}
+	preconf, err := api.fwd.ForwardTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward tx %s: %w", tx.Hash(), err)
+	}
+	api.log.Debug("tx preconfirmed", "tx", tx.Hash(), "block", preconf.L2BlockNumber)
+	return preconf, nil
+}
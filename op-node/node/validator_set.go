@@ -0,0 +1,67 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/kroma-network/kroma/kroma-bindings/bindings"
+)
+
+const (
+	// validatorCacheSize limits the number of validator lookups that are cached.
+	validatorCacheSize = 1000
+	// validatorCacheTTL is how long a validator lookup is trusted before the ValidatorPool is called again.
+	validatorCacheTTL = time.Minute
+)
+
+type validatorLookup struct {
+	isValidator bool
+	expiry      time.Time
+}
+
+// ValidatorPoolSet checks output attestation signers against the L1 ValidatorPool contract.
+// Lookups are cached for a short time, since every validator attests once per submission interval.
+type ValidatorPoolSet struct {
+	caller   *batching.MultiCaller
+	contract *batching.BoundContract
+	cache    *lru.Cache[common.Address, validatorLookup]
+}
+
+var _ p2p.ValidatorSet = (*ValidatorPoolSet)(nil)
+
+func NewValidatorPoolSet(rpc batching.EthRpc, addr common.Address) (*ValidatorPoolSet, error) {
+	abi, err := bindings.ValidatorPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ValidatorPool ABI: %w", err)
+	}
+	cache, err := lru.New[common.Address, validatorLookup](validatorCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up validator cache: %w", err)
+	}
+	return &ValidatorPoolSet{
+		caller:   batching.NewMultiCaller(rpc, batching.DefaultBatchSize),
+		contract: batching.NewBoundContract(abi, addr),
+		cache:    cache,
+	}, nil
+}
+
+func (v *ValidatorPoolSet) IsValidator(ctx context.Context, addr common.Address) (bool, error) {
+	if lookup, ok := v.cache.Get(addr); ok && time.Now().Before(lookup.expiry) {
+		return lookup.isValidator, nil
+	}
+	result, err := v.caller.SingleCall(ctx, rpcblock.Latest, v.contract.Call("isValidator", addr))
+	if err != nil {
+		return false, fmt.Errorf("failed to call ValidatorPool isValidator: %w", err)
+	}
+	isValidator := result.GetBool(0)
+	v.cache.Add(addr, validatorLookup{isValidator: isValidator, expiry: time.Now().Add(validatorCacheTTL)})
+	return isValidator, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -19,7 +20,12 @@ type ApplicationScorer interface {
 	ApplicationScore(id peer.ID) float64
 	onValidResponse(id peer.ID)
 	onResponseError(id peer.ID)
+	/* [Kroma: START]
 	onRejectedPayload(id peer.ID)
+	[Kroma: END] */
+	// [Kroma: START]
+	onRejectedPayload(id peer.ID, hash common.Hash)
+	// [Kroma: END]
 	start()
 	stop()
 }
@@ -79,6 +85,7 @@ func (s *peerApplicationScorer) onResponseError(id peer.ID) {
 	}
 }
 
+/* [Kroma: START]
 func (s *peerApplicationScorer) onRejectedPayload(id peer.ID) {
 	_, err := s.scorebook.SetScore(id, store.IncrementRejectedPayloads{Cap: s.params.RejectedPayloadCap})
 	if err != nil {
@@ -86,6 +93,18 @@ func (s *peerApplicationScorer) onRejectedPayload(id peer.ID) {
 		return
 	}
 }
+[Kroma: END] */
+
+// [Kroma: START]
+func (s *peerApplicationScorer) onRejectedPayload(id peer.ID, _ common.Hash) {
+	_, err := s.scorebook.SetScore(id, store.IncrementRejectedPayloads{Cap: s.params.RejectedPayloadCap})
+	if err != nil {
+		s.log.Error("Unable to update peer score", "peer", id, "err", err)
+		return
+	}
+}
+
+// [Kroma: END]
 
 func (s *peerApplicationScorer) decayScores(id peer.ID) {
 	_, err := s.scorebook.SetScore(id, &store.DecayApplicationScores{
@@ -140,8 +159,16 @@ func (n *NoopApplicationScorer) onValidResponse(_ peer.ID) {
 func (n *NoopApplicationScorer) onResponseError(_ peer.ID) {
 }
 
+/* [Kroma: START]
 func (n *NoopApplicationScorer) onRejectedPayload(_ peer.ID) {
 }
+[Kroma: END] */
+
+// [Kroma: START]
+func (n *NoopApplicationScorer) onRejectedPayload(_ peer.ID, _ common.Hash) {
+}
+
+// [Kroma: END]
 
 func (n *NoopApplicationScorer) start() {
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
@@ -104,7 +105,7 @@ func TestIncrementRejectedPayloads(t *testing.T) {
 		RejectedPayloadCap: 10,
 	})
 
-	appScorer.onRejectedPayload("aaa")
+	appScorer.onRejectedPayload("aaa", common.Hash{0x01})
 	require.Len(t, data.scorebook.updates, 1)
 	update := <-data.scorebook.updates
 	require.Equal(t, stubScoreBookUpdate{peer.ID("aaa"), store.IncrementRejectedPayloads{Cap: 10}}, update)
@@ -0,0 +1,438 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	lru "github.com/hashicorp/golang-lru/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SigningDomainOutputAttestationsV1 separates output attestation signatures from block signatures,
+// so a signature over one can never be replayed as the other.
+var SigningDomainOutputAttestationsV1 = [32]byte{31: 1}
+
+const (
+	// outputAttestationSize is the size of an encoded attestation: L2 block number and output root.
+	outputAttestationSize = 8 + 32
+	// signedOutputAttestationSize is the size of an encoded attestation prefixed with its signature.
+	signedOutputAttestationSize = 65 + outputAttestationSize
+	// maxTrackedAttestationBlocks limits the number of L2 block numbers attestations are kept for.
+	maxTrackedAttestationBlocks = 1000
+	// attestationBlockWindow is how far, in L2 blocks, an attested block may be below the local safe head
+	// or above the local unsafe head. A day of 2 second blocks covers validators that lag behind.
+	attestationBlockWindow = 43200
+)
+
+var (
+	ErrOutputAttestationsDisabled = errors.New("output attestations are disabled")
+	ErrInvalidOutputAttestation   = errors.New("invalid output attestation")
+)
+
+func outputAttestationsTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/kroma/%s/0/output_attestations", cfg.L2ChainID.String())
+}
+
+// ValidatorSet checks whether an address is allowed to attest to output roots.
+type ValidatorSet interface {
+	IsValidator(ctx context.Context, addr common.Address) (bool, error)
+}
+
+// L2Heads reports the local L2 chain heads, which bound the L2 block numbers attestations are accepted for.
+type L2Heads interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// OutputAttestation is the output root a validator computed for an L2 block.
+type OutputAttestation struct {
+	L2BlockNumber uint64      `json:"l2BlockNumber"`
+	OutputRoot    eth.Bytes32 `json:"outputRoot"`
+}
+
+// MarshalBinary encodes the attestation as the big-endian L2 block number followed by the output root.
+func (a *OutputAttestation) MarshalBinary() ([]byte, error) {
+	out := make([]byte, outputAttestationSize)
+	binary.BigEndian.PutUint64(out[:8], a.L2BlockNumber)
+	copy(out[8:], a.OutputRoot[:])
+	return out, nil
+}
+
+func (a *OutputAttestation) UnmarshalBinary(data []byte) error {
+	if len(data) != outputAttestationSize {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidOutputAttestation, outputAttestationSize, len(data))
+	}
+	a.L2BlockNumber = binary.BigEndian.Uint64(data[:8])
+	copy(a.OutputRoot[:], data[8:])
+	return nil
+}
+
+// SignedOutputAttestation is an OutputAttestation signed by a validator.
+// The Validator field is not part of the encoding: it is recovered from the signature.
+type SignedOutputAttestation struct {
+	OutputAttestation
+	Signature hexutil.Bytes  `json:"signature"`
+	Validator common.Address `json:"validator"`
+}
+
+// RecoverValidator recovers the address of the validator that signed the attestation.
+func (a *SignedOutputAttestation) RecoverValidator(chainID *big.Int) (common.Address, error) {
+	if len(a.Signature) != 65 {
+		return common.Address{}, fmt.Errorf("%w: expected 65 signature bytes, got %d", ErrInvalidOutputAttestation, len(a.Signature))
+	}
+	payload, err := a.OutputAttestation.MarshalBinary()
+	if err != nil {
+		return common.Address{}, err
+	}
+	signingHash, err := SigningHash(SigningDomainOutputAttestationsV1, chainID, payload)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(signingHash[:], a.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidOutputAttestation, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// SignOutputAttestation signs the attestation with the given signer, which should hold a ValidatorPool key.
+func SignOutputAttestation(ctx context.Context, chainID *big.Int, signer Signer, att OutputAttestation) (*SignedOutputAttestation, error) {
+	payload, err := att.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(ctx, SigningDomainOutputAttestationsV1, chainID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign output attestation: %w", err)
+	}
+	signed := &SignedOutputAttestation{OutputAttestation: att, Signature: sig[:]}
+	if signed.Validator, err = signed.RecoverValidator(chainID); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+func encodeSignedOutputAttestation(att *SignedOutputAttestation) ([]byte, error) {
+	if len(att.Signature) != 65 {
+		return nil, fmt.Errorf("%w: expected 65 signature bytes, got %d", ErrInvalidOutputAttestation, len(att.Signature))
+	}
+	payload, err := att.OutputAttestation.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, signedOutputAttestationSize)
+	data = append(data, att.Signature...)
+	data = append(data, payload...)
+	return snappy.Encode(nil, data), nil
+}
+
+func decodeSignedOutputAttestation(data []byte) (*SignedOutputAttestation, error) {
+	outLen, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snappy compression length data: %w", err)
+	}
+	if outLen != signedOutputAttestationSize {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidOutputAttestation, signedOutputAttestationSize, outLen)
+	}
+	decoded, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snappy compression: %w", err)
+	}
+	var att SignedOutputAttestation
+	att.Signature = decoded[:65]
+	if err := att.OutputAttestation.UnmarshalBinary(decoded[65:]); err != nil {
+		return nil, err
+	}
+	return &att, nil
+}
+
+// OutputRootVotes lists the validators that attested to the same output root.
+type OutputRootVotes struct {
+	OutputRoot eth.Bytes32      `json:"outputRoot"`
+	Validators []common.Address `json:"validators"`
+}
+
+// OutputAttestationSummary shows how far validators agree on the output root of an L2 block.
+type OutputAttestationSummary struct {
+	L2BlockNumber uint64 `json:"l2BlockNumber"`
+	// Attestations is the number of validators that attested to any output root of the block.
+	Attestations uint64 `json:"attestations"`
+	// Roots is sorted by the number of votes, the most attested output root comes first.
+	Roots []OutputRootVotes `json:"roots"`
+	// Agreement is the share of attestations that voted for the most attested output root.
+	Agreement float64 `json:"agreement"`
+	// Equivocations lists the validators that attested to more than one output root of the block.
+	Equivocations []Equivocation `json:"equivocations"`
+}
+
+// Equivocation is a pair of attestations of a validator to different output roots of the same L2 block.
+type Equivocation struct {
+	Validator   common.Address           `json:"validator"`
+	First       *SignedOutputAttestation `json:"first"`
+	Conflicting *SignedOutputAttestation `json:"conflicting"`
+}
+
+// blockAttestations are the attestations to the output root of an L2 block.
+type blockAttestations struct {
+	// latest is the accepted attestation of every validator.
+	latest map[common.Address]*SignedOutputAttestation
+	// equivocations are the attestations that conflict with the accepted one, by validator.
+	equivocations map[common.Address][]*SignedOutputAttestation
+}
+
+// OutputAttestationBook keeps the latest attestation of every validator, per L2 block number,
+// and the attestations of validators that conflict with it.
+type OutputAttestationBook struct {
+	validators ValidatorSet
+	heads      L2Heads
+
+	mu      sync.Mutex
+	byBlock *lru.Cache[uint64, *blockAttestations]
+}
+
+func NewOutputAttestationBook(validators ValidatorSet, heads L2Heads) *OutputAttestationBook {
+	byBlock, err := lru.New[uint64, *blockAttestations](maxTrackedAttestationBlocks)
+	if err != nil {
+		panic(fmt.Errorf("failed to set up output attestation LRU cache: %w", err))
+	}
+	return &OutputAttestationBook{
+		validators: validators,
+		heads:      heads,
+		byBlock:    byBlock,
+	}
+}
+
+// check reports whether the validator already attested to the block,
+// and whether the previous attestation commits to a different output root.
+func (b *OutputAttestationBook) check(att *SignedOutputAttestation) (seen bool, conflict bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	atts, ok := b.byBlock.Get(att.L2BlockNumber)
+	if !ok {
+		return false, false
+	}
+	prev, ok := atts.latest[att.Validator]
+	if !ok {
+		return false, false
+	}
+	return true, prev.OutputRoot != att.OutputRoot
+}
+
+// block returns the attestations of the L2 block, and starts tracking the block if it is not tracked yet.
+func (b *OutputAttestationBook) block(l2BlockNumber uint64) *blockAttestations {
+	atts, ok := b.byBlock.Get(l2BlockNumber)
+	if !ok {
+		atts = &blockAttestations{
+			latest:        make(map[common.Address]*SignedOutputAttestation),
+			equivocations: make(map[common.Address][]*SignedOutputAttestation),
+		}
+		b.byBlock.Add(l2BlockNumber, atts)
+	}
+	return atts
+}
+
+// Add records an attestation that passed gossip validation.
+func (b *OutputAttestationBook) Add(att *SignedOutputAttestation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.block(att.L2BlockNumber).latest[att.Validator] = att
+}
+
+// AddEquivocation records an attestation that conflicts with the recorded attestation of the validator.
+// It returns false if the attestation was not recorded, because it does not conflict or is already known.
+func (b *OutputAttestationBook) AddEquivocation(att *SignedOutputAttestation) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	atts, ok := b.byBlock.Get(att.L2BlockNumber)
+	if !ok {
+		return false
+	}
+	prev, ok := atts.latest[att.Validator]
+	if !ok || prev.OutputRoot == att.OutputRoot {
+		return false
+	}
+	for _, known := range atts.equivocations[att.Validator] {
+		if known.OutputRoot == att.OutputRoot {
+			return false
+		}
+	}
+	atts.equivocations[att.Validator] = append(atts.equivocations[att.Validator], att)
+	return true
+}
+
+// Summary groups the attestations of the given L2 block by output root.
+func (b *OutputAttestationBook) Summary(l2BlockNumber uint64) *OutputAttestationSummary {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	summary := &OutputAttestationSummary{L2BlockNumber: l2BlockNumber, Roots: []OutputRootVotes{}, Equivocations: []Equivocation{}}
+	block, ok := b.byBlock.Peek(l2BlockNumber)
+	if !ok {
+		return summary
+	}
+	for validator, conflicting := range block.equivocations {
+		for _, att := range conflicting {
+			summary.Equivocations = append(summary.Equivocations, Equivocation{
+				Validator:   validator,
+				First:       block.latest[validator],
+				Conflicting: att,
+			})
+		}
+	}
+	sort.SliceStable(summary.Equivocations, func(i, j int) bool {
+		return bytes.Compare(summary.Equivocations[i].Validator[:], summary.Equivocations[j].Validator[:]) < 0
+	})
+	atts := block.latest
+	if len(atts) == 0 {
+		return summary
+	}
+	votes := make(map[eth.Bytes32][]common.Address)
+	for validator, att := range atts {
+		votes[att.OutputRoot] = append(votes[att.OutputRoot], validator)
+	}
+	for root, validators := range votes {
+		sort.Slice(validators, func(i, j int) bool {
+			return bytes.Compare(validators[i][:], validators[j][:]) < 0
+		})
+		summary.Roots = append(summary.Roots, OutputRootVotes{OutputRoot: root, Validators: validators})
+	}
+	sort.Slice(summary.Roots, func(i, j int) bool {
+		if len(summary.Roots[i].Validators) != len(summary.Roots[j].Validators) {
+			return len(summary.Roots[i].Validators) > len(summary.Roots[j].Validators)
+		}
+		return bytes.Compare(summary.Roots[i].OutputRoot[:], summary.Roots[j].OutputRoot[:]) < 0
+	})
+	summary.Attestations = uint64(len(atts))
+	summary.Agreement = float64(len(summary.Roots[0].Validators)) / float64(len(atts))
+	return summary
+}
+
+func BuildOutputAttestationsValidator(log log.Logger, cfg *rollup.Config, book *OutputAttestationBook) pubsub.ValidatorEx {
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		// [REJECT] if the message is not a snappy compressed signed attestation
+		att, err := decodeSignedOutputAttestation(message.Data)
+		if err != nil {
+			log.Warn("invalid output attestation encoding", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the signature is not valid
+		att.Validator, err = att.RecoverValidator(cfg.L2ChainID)
+		if err != nil {
+			log.Warn("invalid output attestation signature", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// [IGNORE] if the local L2 heads are not known right now
+		// [REJECT] if the block is more than attestationBlockWindow blocks below the local safe head
+		// [IGNORE] if the block is more than attestationBlockWindow blocks above the local unsafe head,
+		// the local node may be syncing
+		status, err := book.heads.SyncStatus(ctx)
+		if err != nil {
+			log.Warn("failed to get L2 heads to check output attestation", "err", err, "peer", id)
+			return pubsub.ValidationIgnore
+		}
+		if att.L2BlockNumber+attestationBlockWindow < status.SafeL2.Number {
+			log.Warn("output attestation is too old", "l2_block_number", att.L2BlockNumber, "safe", status.SafeL2.Number, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if att.L2BlockNumber > status.UnsafeL2.Number+attestationBlockWindow {
+			log.Warn("output attestation is too far ahead", "l2_block_number", att.L2BlockNumber, "unsafe", status.UnsafeL2.Number, "peer", id)
+			return pubsub.ValidationIgnore
+		}
+
+		// [IGNORE] if the validator already attested to the same output root
+		// [REJECT] if the validator already attested to a different output root of the same block.
+		// The signer attested before, so it is a validator: keep the attestation as proof of the equivocation.
+		if seen, conflict := book.check(att); conflict {
+			if book.AddEquivocation(att) {
+				log.Warn("validator attested to conflicting output roots", "validator", att.Validator,
+					"l2_block_number", att.L2BlockNumber, "output_root", att.OutputRoot, "peer", id)
+			}
+			return pubsub.ValidationReject
+		} else if seen {
+			return pubsub.ValidationIgnore
+		}
+
+		// [IGNORE] if the validator set cannot be checked right now
+		// [REJECT] if the signer is not a validator of the ValidatorPool
+		isValidator, err := book.validators.IsValidator(ctx, att.Validator)
+		if err != nil {
+			log.Warn("failed to check validator of output attestation", "err", err, "validator", att.Validator, "peer", id)
+			return pubsub.ValidationIgnore
+		}
+		if !isValidator {
+			log.Warn("output attestation signed by non-validator", "signer", att.Validator, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		message.ValidatorData = att
+		return pubsub.ValidationAccept
+	}
+}
+
+func OutputAttestationsHandler(onAttestation func(ctx context.Context, from peer.ID, att *SignedOutputAttestation) error) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		att, ok := msg.(*SignedOutputAttestation)
+		if !ok {
+			return fmt.Errorf("expected topic validator to parse and validate data into output attestation, but got %T", msg)
+		}
+		return onAttestation(ctx, from, att)
+	}
+}
+
+func newOutputAttestationsTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, book *OutputAttestationBook, validator pubsub.ValidatorEx) (*blockTopic, error) {
+	err := ps.RegisterTopicValidator(topicId,
+		validator,
+		pubsub.WithValidatorTimeout(3*time.Second),
+		pubsub.WithValidatorConcurrency(4))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register gossip topic: %w", err)
+	}
+
+	topic, err := ps.Join(topicId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join gossip topic: %w", err)
+	}
+
+	topicEvents, err := topic.EventHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output attestations gossip topic handler: %w", err)
+	}
+
+	go LogTopicEvents(ctx, log, topicEvents)
+
+	subscription, err := topic.Subscribe()
+	if err != nil {
+		err = errors.Join(err, topic.Close())
+		return nil, fmt.Errorf("failed to subscribe to output attestations gossip topic: %w", err)
+	}
+
+	subscriber := MakeSubscriber(log, OutputAttestationsHandler(func(ctx context.Context, from peer.ID, att *SignedOutputAttestation) error {
+		log.Debug("received output attestation", "validator", att.Validator, "l2_block_number", att.L2BlockNumber, "output_root", att.OutputRoot, "peer", from)
+		book.Add(att)
+		return nil
+	}))
+	go subscriber(ctx, subscription)
+
+	return &blockTopic{
+		topic:  topic,
+		events: topicEvents,
+		sub:    subscription,
+	}, nil
+}
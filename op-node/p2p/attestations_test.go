@@ -0,0 +1,189 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type mockValidatorSet struct {
+	validators map[common.Address]bool
+	err        error
+}
+
+func (m *mockValidatorSet) IsValidator(_ context.Context, addr common.Address) (bool, error) {
+	return m.validators[addr], m.err
+}
+
+type mockL2Heads struct {
+	safe, unsafe uint64
+	err          error
+}
+
+func (m *mockL2Heads) SyncStatus(_ context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: m.safe}, UnsafeL2: eth.L2BlockRef{Number: m.unsafe}}, m.err
+}
+
+func TestOutputAttestationEncoding(t *testing.T) {
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	chainID := big.NewInt(100)
+
+	att := OutputAttestation{L2BlockNumber: 1800, OutputRoot: eth.Bytes32{0xaa, 0xbb}}
+	signed, err := SignOutputAttestation(context.Background(), chainID, NewLocalSigner(secrets.TrustedValidator), att)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(secrets.TrustedValidator.PublicKey), signed.Validator)
+
+	data, err := encodeSignedOutputAttestation(signed)
+	require.NoError(t, err)
+	decoded, err := decodeSignedOutputAttestation(data)
+	require.NoError(t, err)
+	require.Equal(t, signed.OutputAttestation, decoded.OutputAttestation)
+	require.Equal(t, signed.Signature, decoded.Signature)
+
+	recovered, err := decoded.RecoverValidator(chainID)
+	require.NoError(t, err)
+	require.Equal(t, signed.Validator, recovered)
+
+	// the signature commits to the chain ID
+	recovered, err = decoded.RecoverValidator(big.NewInt(101))
+	require.NoError(t, err)
+	require.NotEqual(t, signed.Validator, recovered)
+}
+
+func TestOutputAttestationsValidator(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelCrit)
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100)}
+
+	validatorKey := secrets.TrustedValidator
+	validator := crypto.PubkeyToAddress(validatorKey.PublicKey)
+	valSet := &mockValidatorSet{validators: map[common.Address]bool{validator: true}}
+	heads := &mockL2Heads{safe: 10, unsafe: 20}
+	book := NewOutputAttestationBook(valSet, heads)
+	validate := BuildOutputAttestationsValidator(logger, cfg, book)
+
+	sign := func(t *testing.T, signer Signer, blockNumber uint64, root eth.Bytes32) *pubsub.Message {
+		att, err := SignOutputAttestation(context.Background(), cfg.L2ChainID, signer, OutputAttestation{L2BlockNumber: blockNumber, OutputRoot: root})
+		require.NoError(t, err)
+		data, err := encodeSignedOutputAttestation(att)
+		require.NoError(t, err)
+		return &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		msg := sign(t, NewLocalSigner(validatorKey), 10, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), "alice", msg))
+		att := msg.ValidatorData.(*SignedOutputAttestation)
+		require.Equal(t, validator, att.Validator)
+		book.Add(att)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		msg := sign(t, NewLocalSigner(validatorKey), 10, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), "alice", msg))
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		msg := sign(t, NewLocalSigner(validatorKey), 10, eth.Bytes32{2})
+		require.Equal(t, pubsub.ValidationReject, validate(context.Background(), "alice", msg))
+		// the conflicting attestation is kept as proof of the equivocation, once
+		require.Equal(t, pubsub.ValidationReject, validate(context.Background(), "bob", msg))
+		equivocations := book.Summary(10).Equivocations
+		require.Len(t, equivocations, 1)
+		require.Equal(t, validator, equivocations[0].Validator)
+		require.Equal(t, eth.Bytes32{1}, equivocations[0].First.OutputRoot)
+		require.Equal(t, eth.Bytes32{2}, equivocations[0].Conflicting.OutputRoot)
+		// the accepted attestation still counts
+		require.Equal(t, []OutputRootVotes{{OutputRoot: eth.Bytes32{1}, Validators: []common.Address{validator}}}, book.Summary(10).Roots)
+	})
+
+	t.Run("NotValidator", func(t *testing.T) {
+		msg := sign(t, NewLocalSigner(secrets.Alice), 10, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationReject, validate(context.Background(), "alice", msg))
+	})
+
+	t.Run("ValidatorSetUnavailable", func(t *testing.T) {
+		valSet.err = errors.New("l1 unavailable")
+		defer func() { valSet.err = nil }()
+		msg := sign(t, NewLocalSigner(validatorKey), 11, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), "alice", msg))
+	})
+
+	t.Run("TooOld", func(t *testing.T) {
+		heads.safe, heads.unsafe = 12+attestationBlockWindow, 20+attestationBlockWindow
+		defer func() { heads.safe, heads.unsafe = 10, 20 }()
+		msg := sign(t, NewLocalSigner(validatorKey), 11, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationReject, validate(context.Background(), "alice", msg))
+		// the oldest block within the window is still accepted
+		msg = sign(t, NewLocalSigner(validatorKey), 12, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), "alice", msg))
+	})
+
+	t.Run("TooFarAhead", func(t *testing.T) {
+		msg := sign(t, NewLocalSigner(validatorKey), 21+attestationBlockWindow, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), "alice", msg))
+		// the furthest block within the window is still accepted
+		msg = sign(t, NewLocalSigner(validatorKey), 20+attestationBlockWindow, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), "alice", msg))
+		require.Zero(t, book.Summary(21+attestationBlockWindow).Attestations)
+	})
+
+	t.Run("HeadsUnavailable", func(t *testing.T) {
+		heads.err = errors.New("driver stopped")
+		defer func() { heads.err = nil }()
+		msg := sign(t, NewLocalSigner(validatorKey), 11, eth.Bytes32{1})
+		require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), "alice", msg))
+	})
+
+	t.Run("BadEncoding", func(t *testing.T) {
+		msg := &pubsub.Message{Message: &pubsub_pb.Message{Data: []byte("not an attestation")}}
+		require.Equal(t, pubsub.ValidationReject, validate(context.Background(), "alice", msg))
+	})
+}
+
+func TestOutputAttestationBookSummary(t *testing.T) {
+	book := NewOutputAttestationBook(&mockValidatorSet{}, &mockL2Heads{})
+	valA, valB, valC := common.Address{0xa}, common.Address{0xb}, common.Address{0xc}
+	good, bad := eth.Bytes32{0x1}, eth.Bytes32{0x2}
+
+	summary := book.Summary(100)
+	require.Zero(t, summary.Attestations)
+	require.Empty(t, summary.Roots)
+
+	book.Add(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 100, OutputRoot: good}, Validator: valB})
+	book.Add(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 100, OutputRoot: bad}, Validator: valC})
+	book.Add(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 100, OutputRoot: good}, Validator: valA})
+	book.Add(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 200, OutputRoot: bad}, Validator: valA})
+
+	summary = book.Summary(100)
+	require.Equal(t, uint64(3), summary.Attestations)
+	require.Equal(t, []OutputRootVotes{
+		{OutputRoot: good, Validators: []common.Address{valA, valB}},
+		{OutputRoot: bad, Validators: []common.Address{valC}},
+	}, summary.Roots)
+	require.InDelta(t, 2.0/3.0, summary.Agreement, 1e-9)
+	require.Empty(t, summary.Equivocations)
+
+	// only attestations that conflict with the recorded one are equivocations
+	require.False(t, book.AddEquivocation(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 100, OutputRoot: good}, Validator: valA}))
+	require.False(t, book.AddEquivocation(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 300, OutputRoot: good}, Validator: valA}))
+	require.True(t, book.AddEquivocation(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 100, OutputRoot: bad}, Validator: valA}))
+	require.False(t, book.AddEquivocation(&SignedOutputAttestation{OutputAttestation: OutputAttestation{L2BlockNumber: 100, OutputRoot: bad}, Validator: valA}))
+	require.Len(t, book.Summary(100).Equivocations, 1)
+}
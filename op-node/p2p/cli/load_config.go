@@ -22,6 +22,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 )
@@ -66,10 +67,30 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
 	conf.EnablePingService = ctx.Bool(flags.P2PPingName)
+	conf.EnableTxForwarding = ctx.Bool(flags.TxForwardingName)
+	conf.SharedPeerListSource = ctx.String(flags.SharedPeerListName)
+	conf.SharedPeerListRefresh = ctx.Duration(flags.SharedPeerListRefreshName)
+
+	if err := loadOutputAttestationsOptions(conf, ctx); err != nil {
+		return nil, fmt.Errorf("failed to load output attestations options: %w", err)
+	}
 
 	return conf, nil
 }
 
+// loadOutputAttestationsOptions loads the ValidatorPool to check output attestations against, if any.
+func loadOutputAttestationsOptions(conf *p2p.Config, ctx *cli.Context) error {
+	addr := ctx.String(flags.OutputAttestationsValidatorPoolName)
+	if addr == "" {
+		return nil
+	}
+	if !common.IsHexAddress(addr) {
+		return fmt.Errorf("invalid ValidatorPool address: %q", addr)
+	}
+	conf.AttestationsValidatorPool = common.HexToAddress(addr)
+	return nil
+}
+
 func validatePort(p uint) (uint16, error) {
 	if p == 0 {
 		return 0, nil
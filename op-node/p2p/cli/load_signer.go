@@ -5,18 +5,35 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	// [Kroma: START]
+	"github.com/ethereum/go-ethereum/log"
+	// [Kroma: END]
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	// [Kroma: START]
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	// [Kroma: END]
 )
 
-// TODO: implement remote signer setup (config to authenticated endpoint)
-// and remote signer itself (e.g. a open http client to make signing requests)
-
 // LoadSignerSetup loads a configuration for a Signer to be set up later
+/* [Kroma: START]
 func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
+[Kroma: END] */
+// [Kroma: START]
+func LoadSignerSetup(ctx *cli.Context, l log.Logger) (p2p.SignerSetup, error) {
+	// [Kroma: END]
 	key := ctx.String(flags.SequencerP2PKeyName)
+	// [Kroma: START]
+	signerCfg := opsigner.ReadCLIConfig(ctx)
+	if err := signerCfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid signer config: %w", err)
+	}
+	if key != "" && signerCfg.Enabled() {
+		return nil, fmt.Errorf("cannot specify both %s and a remote signer", flags.SequencerP2PKeyName)
+	}
+	// [Kroma: END]
 	if key != "" {
 		// Mnemonics are bad because they leak *all* keys when they leak.
 		// Unencrypted keys from file are bad because they are easy to leak (and we are not checking file permissions).
@@ -28,7 +45,11 @@ func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
 		return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(priv)}, nil
 	}
 
-	// TODO: create remote signer
+	// [Kroma: START]
+	if signerCfg.Enabled() {
+		return &p2p.RemoteSignerSetup{Log: l, Config: signerCfg}, nil
+	}
+	// [Kroma: END]
 
 	return nil, nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -51,6 +52,15 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// OutputAttestationsValidatorPool returns the ValidatorPool that output attestation signers are checked against.
+	// The output attestations topic is not joined if this is the zero address.
+	OutputAttestationsValidatorPool() common.Address
+	// TxForwardingEnabled returns whether txs are forwarded to the sequencer peer over the forward-tx protocol.
+	// A sequencer with a p2p signer also serves the protocol.
+	TxForwardingEnabled() bool
+	// SharedPeerList returns the file or HTTP endpoint of the shared ban and trusted peer list, its reload interval,
+	// and the datastore it keeps the entries it applied in. The list is disabled if the source is empty.
+	SharedPeerList() (source string, refresh time.Duration, store ds.Datastore)
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
@@ -121,6 +131,17 @@ type Config struct {
 	EnableReqRespSync bool
 
 	EnablePingService bool
+
+	// AttestationsValidatorPool is the L1 ValidatorPool address to check output attestation signers against.
+	// Output attestations gossip is disabled if it is the zero address.
+	AttestationsValidatorPool common.Address
+
+	EnableTxForwarding bool
+
+	// SharedPeerListSource is the file or HTTP endpoint of the ban and trusted peer list shared by the nodes of an operator.
+	// The shared list is disabled if it is empty.
+	SharedPeerListSource  string
+	SharedPeerListRefresh time.Duration
 }
 
 func DefaultConnManager(conf *Config) (connmgr.ConnManager, error) {
@@ -163,6 +184,18 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) OutputAttestationsValidatorPool() common.Address {
+	return conf.AttestationsValidatorPool
+}
+
+func (conf *Config) TxForwardingEnabled() bool {
+	return conf.EnableTxForwarding
+}
+
+func (conf *Config) SharedPeerList() (string, time.Duration, ds.Datastore) {
+	return conf.SharedPeerListSource, conf.SharedPeerListRefresh, conf.Store
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
@@ -192,5 +225,8 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	if conf.SharedPeerListSource != "" && (conf.SharedPeerListRefresh <= 0 || conf.SharedPeerListRefresh >= sharedPeerListBanDuration) {
+		return fmt.Errorf("shared peer list refresh interval must be positive and less than %s, but got %s", sharedPeerListBanDuration, conf.SharedPeerListRefresh)
+	}
 	return nil
 }
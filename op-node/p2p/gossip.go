@@ -81,7 +81,7 @@ func blocksTopicV3(cfg *rollup.Config) string {
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg), outputAttestationsTopicV1(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -449,6 +449,9 @@ type GossipTopicInfo interface {
 type GossipOut interface {
 	GossipTopicInfo
 	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayloadEnvelope, signer Signer) error
+	// PublishOutputAttestation publishes a validator-signed output attestation,
+	// or returns ErrOutputAttestationsDisabled if the node did not join the attestations topic.
+	PublishOutputAttestation(ctx context.Context, att *SignedOutputAttestation) error
 	Close() error
 }
 
@@ -480,6 +483,9 @@ type publisher struct {
 	blocksV2 *blockTopic
 	blocksV3 *blockTopic
 
+	// outputAttestations is nil if output attestations are disabled.
+	outputAttestations *blockTopic
+
 	runCfg GossipRuntimeConfig
 }
 
@@ -557,18 +563,35 @@ func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.Executio
 	}
 }
 
+func (p *publisher) PublishOutputAttestation(ctx context.Context, att *SignedOutputAttestation) error {
+	if p.outputAttestations == nil {
+		return ErrOutputAttestationsDisabled
+	}
+	out, err := encodeSignedOutputAttestation(att)
+	if err != nil {
+		return fmt.Errorf("failed to encode output attestation to publish: %w", err)
+	}
+	return p.outputAttestations.topic.Publish(ctx, out)
+}
+
 func (p *publisher) Close() error {
 	p.p2pCancel()
 	e1 := p.blocksV1.Close()
 	e2 := p.blocksV2.Close()
-	return errors.Join(e1, e2)
+	var e3 error
+	if p.outputAttestations != nil {
+		e3 = p.outputAttestations.Close()
+	}
+	return errors.Join(e1, e2, e3)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+// JoinGossip joins the block topics, and the output attestations topic if an attestation book is provided.
+// The validation results of the messages of other peers are recorded in their history if a recorder is provided.
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, attestations *OutputAttestationBook, history PeerHistoryRecorder) (GossipOut, error) {
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
-	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1)))
+	blocksV1Validator := recordGossipHistory(self, log, history, "blocksV1", guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1))))
 	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator)
 	if err != nil {
 		p2pCancel()
@@ -576,7 +599,7 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v2Logger := log.New("topic", "blocksV2")
-	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2)))
+	blocksV2Validator := recordGossipHistory(self, log, history, "blocksV2", guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2))))
 	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator)
 	if err != nil {
 		p2pCancel()
@@ -584,21 +607,33 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v3Logger := log.New("topic", "blocksV3")
-	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3)))
+	blocksV3Validator := recordGossipHistory(self, log, history, "blocksV3", guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3))))
 	blocksV3, err := newBlockTopic(p2pCtx, blocksTopicV3(cfg), ps, v3Logger, gossipIn, blocksV3Validator)
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v3 p2p: %w", err)
 	}
 
+	var outputAttestations *blockTopic
+	if attestations != nil {
+		attLogger := log.New("topic", "outputAttestationsV1")
+		attValidator := recordGossipHistory(self, log, history, "outputAttestationsV1", guardGossipValidator(log, logValidationResult(self, "validated output attestation", attLogger, BuildOutputAttestationsValidator(attLogger, cfg, attestations))))
+		outputAttestations, err = newOutputAttestationsTopic(p2pCtx, outputAttestationsTopicV1(cfg), ps, attLogger, attestations, attValidator)
+		if err != nil {
+			p2pCancel()
+			return nil, fmt.Errorf("failed to setup output attestations p2p: %w", err)
+		}
+	}
+
 	return &publisher{
-		log:       log,
-		cfg:       cfg,
-		p2pCancel: p2pCancel,
-		blocksV1:  blocksV1,
-		blocksV2:  blocksV2,
-		blocksV3:  blocksV3,
-		runCfg:    runCfg,
+		log:                log,
+		cfg:                cfg,
+		p2pCancel:          p2pCancel,
+		blocksV1:           blocksV1,
+		blocksV2:           blocksV2,
+		blocksV3:           blocksV3,
+		outputAttestations: outputAttestations,
+		runCfg:             runCfg,
 	}, nil
 }
 
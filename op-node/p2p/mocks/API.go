@@ -459,6 +459,170 @@ func (_c *API_ListBlockedSubnets_Call) RunAndReturn(run func(context.Context) ([
 	return _c
 }
 
+// OutputAttestations provides a mock function with given fields: ctx, l2BlockNumber
+func (_m *API) OutputAttestations(ctx context.Context, l2BlockNumber uint64) (*p2p.OutputAttestationSummary, error) {
+	ret := _m.Called(ctx, l2BlockNumber)
+
+	var r0 *p2p.OutputAttestationSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*p2p.OutputAttestationSummary, error)); ok {
+		return rf(ctx, l2BlockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *p2p.OutputAttestationSummary); ok {
+		r0 = rf(ctx, l2BlockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*p2p.OutputAttestationSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, l2BlockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_OutputAttestations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OutputAttestations'
+type API_OutputAttestations_Call struct {
+	*mock.Call
+}
+
+// OutputAttestations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l2BlockNumber uint64
+func (_e *API_Expecter) OutputAttestations(ctx interface{}, l2BlockNumber interface{}) *API_OutputAttestations_Call {
+	return &API_OutputAttestations_Call{Call: _e.mock.On("OutputAttestations", ctx, l2BlockNumber)}
+}
+
+func (_c *API_OutputAttestations_Call) Run(run func(ctx context.Context, l2BlockNumber uint64)) *API_OutputAttestations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *API_OutputAttestations_Call) Return(_a0 *p2p.OutputAttestationSummary, _a1 error) *API_OutputAttestations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_OutputAttestations_Call) RunAndReturn(run func(context.Context, uint64) (*p2p.OutputAttestationSummary, error)) *API_OutputAttestations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PeerHistories provides a mock function with given fields: ctx
+func (_m *API) PeerHistories(ctx context.Context) ([]*p2p.PeerReputation, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*p2p.PeerReputation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*p2p.PeerReputation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*p2p.PeerReputation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*p2p.PeerReputation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_PeerHistories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PeerHistories'
+type API_PeerHistories_Call struct {
+	*mock.Call
+}
+
+// PeerHistories is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *API_Expecter) PeerHistories(ctx interface{}) *API_PeerHistories_Call {
+	return &API_PeerHistories_Call{Call: _e.mock.On("PeerHistories", ctx)}
+}
+
+func (_c *API_PeerHistories_Call) Run(run func(ctx context.Context)) *API_PeerHistories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *API_PeerHistories_Call) Return(_a0 []*p2p.PeerReputation, _a1 error) *API_PeerHistories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_PeerHistories_Call) RunAndReturn(run func(context.Context) ([]*p2p.PeerReputation, error)) *API_PeerHistories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PeerHistory provides a mock function with given fields: ctx, p
+func (_m *API) PeerHistory(ctx context.Context, p peer.ID) (*p2p.PeerReputation, error) {
+	ret := _m.Called(ctx, p)
+
+	var r0 *p2p.PeerReputation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, peer.ID) (*p2p.PeerReputation, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, peer.ID) *p2p.PeerReputation); ok {
+		r0 = rf(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*p2p.PeerReputation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, peer.ID) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_PeerHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PeerHistory'
+type API_PeerHistory_Call struct {
+	*mock.Call
+}
+
+// PeerHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p peer.ID
+func (_e *API_Expecter) PeerHistory(ctx interface{}, p interface{}) *API_PeerHistory_Call {
+	return &API_PeerHistory_Call{Call: _e.mock.On("PeerHistory", ctx, p)}
+}
+
+func (_c *API_PeerHistory_Call) Run(run func(ctx context.Context, p peer.ID)) *API_PeerHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(peer.ID))
+	})
+	return _c
+}
+
+func (_c *API_PeerHistory_Call) Return(_a0 *p2p.PeerReputation, _a1 error) *API_PeerHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_PeerHistory_Call) RunAndReturn(run func(context.Context, peer.ID) (*p2p.PeerReputation, error)) *API_PeerHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PeerStats provides a mock function with given fields: ctx
 func (_m *API) PeerStats(ctx context.Context) (*p2p.PeerStats, error) {
 	ret := _m.Called(ctx)
@@ -611,6 +775,49 @@ func (_c *API_ProtectPeer_Call) RunAndReturn(run func(context.Context, peer.ID)
 	return _c
 }
 
+// PublishOutputAttestation provides a mock function with given fields: ctx, att
+func (_m *API) PublishOutputAttestation(ctx context.Context, att *p2p.SignedOutputAttestation) error {
+	ret := _m.Called(ctx, att)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *p2p.SignedOutputAttestation) error); ok {
+		r0 = rf(ctx, att)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_PublishOutputAttestation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishOutputAttestation'
+type API_PublishOutputAttestation_Call struct {
+	*mock.Call
+}
+
+// PublishOutputAttestation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - att *p2p.SignedOutputAttestation
+func (_e *API_Expecter) PublishOutputAttestation(ctx interface{}, att interface{}) *API_PublishOutputAttestation_Call {
+	return &API_PublishOutputAttestation_Call{Call: _e.mock.On("PublishOutputAttestation", ctx, att)}
+}
+
+func (_c *API_PublishOutputAttestation_Call) Run(run func(ctx context.Context, att *p2p.SignedOutputAttestation)) *API_PublishOutputAttestation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*p2p.SignedOutputAttestation))
+	})
+	return _c
+}
+
+func (_c *API_PublishOutputAttestation_Call) Return(_a0 error) *API_PublishOutputAttestation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_PublishOutputAttestation_Call) RunAndReturn(run func(context.Context, *p2p.SignedOutputAttestation) error) *API_PublishOutputAttestation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Self provides a mock function with given fields: ctx
 func (_m *API) Self(ctx context.Context) (*p2p.PeerInfo, error) {
 	ret := _m.Called(ctx)
@@ -665,6 +872,60 @@ func (_c *API_Self_Call) RunAndReturn(run func(context.Context) (*p2p.PeerInfo,
 	return _c
 }
 
+// SharedPeerList provides a mock function with given fields: ctx
+func (_m *API) SharedPeerList(ctx context.Context) (*p2p.SharedPeerListStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *p2p.SharedPeerListStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*p2p.SharedPeerListStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *p2p.SharedPeerListStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*p2p.SharedPeerListStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_SharedPeerList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SharedPeerList'
+type API_SharedPeerList_Call struct {
+	*mock.Call
+}
+
+// SharedPeerList is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *API_Expecter) SharedPeerList(ctx interface{}) *API_SharedPeerList_Call {
+	return &API_SharedPeerList_Call{Call: _e.mock.On("SharedPeerList", ctx)}
+}
+
+func (_c *API_SharedPeerList_Call) Run(run func(ctx context.Context)) *API_SharedPeerList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *API_SharedPeerList_Call) Return(_a0 *p2p.SharedPeerListStatus, _a1 error) *API_SharedPeerList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_SharedPeerList_Call) RunAndReturn(run func(context.Context) (*p2p.SharedPeerListStatus, error)) *API_SharedPeerList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnblockAddr provides a mock function with given fields: ctx, ip
 func (_m *API) UnblockAddr(ctx context.Context, ip net.IP) error {
 	ret := _m.Called(ctx, ip)
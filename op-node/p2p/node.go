@@ -18,6 +18,7 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -48,16 +49,26 @@ type NodeP2P struct {
 	gsOut    GossipOut        // p2p gossip application interface for publishing
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
+	// attestations tracks the gossiped validator output attestations, nil if disabled.
+	attestations *OutputAttestationBook
+	// txFwdCl forwards txs to the sequencer peer, nil if tx forwarding is disabled.
+	txFwdCl *TxForwardingClient
+	// txFwdSrv serves forwarded txs, only set on a sequencer with tx forwarding enabled.
+	txFwdSrv *TxForwardingServer
+	// sharedList applies the ban and trusted peer list shared by the nodes of the operator, nil if disabled.
+	sharedList *SharedPeerListSync
 }
 
 // NewNodeP2P creates a new p2p node, and returns a reference to it. If the p2p is disabled, it returns nil.
 // If metrics are configured, a bandwidth monitor will be spawned in a goroutine.
-func NewNodeP2P(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.Logger, setup SetupP2P, gossipIn GossipIn, l2Chain L2Chain, runCfg GossipRuntimeConfig, metrics metrics.Metricer, elSyncEnabled bool) (*NodeP2P, error) {
+// The output attestations topic is joined only if a validator set is provided,
+// attestations are then checked against the local L2 heads.
+func NewNodeP2P(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.Logger, setup SetupP2P, gossipIn GossipIn, l2Chain L2Chain, runCfg GossipRuntimeConfig, validators ValidatorSet, l2Heads L2Heads, metrics metrics.Metricer, elSyncEnabled bool) (*NodeP2P, error) {
 	if setup == nil {
 		return nil, errors.New("p2p node cannot be created without setup")
 	}
 	var n NodeP2P
-	if err := n.init(resourcesCtx, rollupCfg, log, setup, gossipIn, l2Chain, runCfg, metrics, elSyncEnabled); err != nil {
+	if err := n.init(resourcesCtx, rollupCfg, log, setup, gossipIn, l2Chain, runCfg, validators, l2Heads, metrics, elSyncEnabled); err != nil {
 		closeErr := n.Close()
 		if closeErr != nil {
 			log.Error("failed to close p2p after starting with err", "closeErr", closeErr, "err", err)
@@ -70,7 +81,7 @@ func NewNodeP2P(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.
 	return &n, nil
 }
 
-func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.Logger, setup SetupP2P, gossipIn GossipIn, l2Chain L2Chain, runCfg GossipRuntimeConfig, metrics metrics.Metricer, elSyncEnabled bool) error {
+func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.Logger, setup SetupP2P, gossipIn GossipIn, l2Chain L2Chain, runCfg GossipRuntimeConfig, validators ValidatorSet, l2Heads L2Heads, metrics metrics.Metricer, elSyncEnabled bool) error {
 	bwc := p2pmetrics.NewBandwidthCounter()
 
 	n.log = log
@@ -104,6 +115,9 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		} else {
 			n.appScorer = &NoopApplicationScorer{}
 		}
+		// [Kroma: START]
+		n.appScorer = newHistoryAppScorer(n.appScorer, log, eps)
+		// [Kroma: END]
 		// Activate the P2P req-resp sync if enabled by feature-flag.
 		if setup.ReqRespSyncEnabled() && !elSyncEnabled {
 			n.syncCl = NewSyncClient(log, rollupCfg, n.host.NewStream, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer)
@@ -130,6 +144,9 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
 			}
 		}
+		if setup.TxForwardingEnabled() {
+			n.txFwdCl = NewTxForwardingClient(log.New("p2p", "tx_forwarding"), rollupCfg, n.host, runCfg)
+		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
 		// notify of any new connections/streams/etc.
 		n.host.Network().Notify(NewNetworkNotifier(log, metrics))
@@ -138,7 +155,10 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		if err != nil {
 			return fmt.Errorf("failed to start gossipsub router: %w", err)
 		}
-		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn)
+		if validators != nil {
+			n.attestations = NewOutputAttestationBook(validators, l2Heads)
+		}
+		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn, n.attestations, n.store)
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
@@ -159,6 +179,11 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 			go metrics.RecordBandwidth(resourcesCtx, bwc)
 		}
 
+		if source, refresh, store := setup.SharedPeerList(); source != "" {
+			n.sharedList = NewSharedPeerListSync(resourcesCtx, log.New("p2p", "shared_peer_list"), source, refresh, clock.SystemClock, n.host.Network(), n.gater, n.connMgr, eps, store)
+			n.sharedList.Start()
+		}
+
 		if setup.BanPeers() {
 			n.peerMonitor = monitor.NewPeerMonitor(resourcesCtx, log, clock.SystemClock, n, setup.BanThreshold(), setup.BanDuration())
 			n.peerMonitor.Start()
@@ -179,6 +204,30 @@ func (n *NodeP2P) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef)
 	return n.syncCl.RequestL2Range(ctx, start, end)
 }
 
+// ServeTxForwarding registers the forward-tx protocol, so other nodes can forward txs to this sequencer.
+// The preconfirmations are signed with the given signer, which must hold the sequencer p2p key.
+func (n *NodeP2P) ServeTxForwarding(resourcesCtx context.Context, rollupCfg *rollup.Config, backend TxForwardingBackend, signer Signer) error {
+	if n.txFwdCl == nil {
+		return ErrTxForwardingDisabled
+	}
+	n.txFwdSrv = NewTxForwardingServer(rollupCfg, backend, signer)
+	forwardTx := MakeStreamHandler(resourcesCtx, n.log.New("serve", "forward_tx"), n.txFwdSrv.HandleForwardTxRequest)
+	n.host.SetStreamHandler(ForwardTxProtocolID(rollupCfg.L2ChainID), forwardTx)
+	return nil
+}
+
+// ForwardTransaction forwards the tx to the sequencer and returns its preconfirmation.
+// If this node is the sequencer, the tx is preconfirmed locally.
+func (n *NodeP2P) ForwardTransaction(ctx context.Context, tx *types.Transaction) (*SignedPreconfirmation, error) {
+	if n.txFwdSrv != nil {
+		return n.txFwdSrv.Preconfirm(ctx, tx)
+	}
+	if n.txFwdCl == nil {
+		return nil, ErrTxForwardingDisabled
+	}
+	return n.txFwdCl.ForwardTransaction(ctx, tx)
+}
+
 func (n *NodeP2P) Host() host.Host {
 	return n.host
 }
@@ -199,6 +248,10 @@ func (n *NodeP2P) GossipOut() GossipOut {
 	return n.gsOut
 }
 
+func (n *NodeP2P) OutputAttestations() *OutputAttestationBook {
+	return n.attestations
+}
+
 func (n *NodeP2P) ConnectionGater() gating.BlockingConnectionGater {
 	return n.gater
 }
@@ -207,6 +260,10 @@ func (n *NodeP2P) ConnectionManager() connmgr.ConnManager {
 	return n.connMgr
 }
 
+func (n *NodeP2P) SharedPeerList() *SharedPeerListSync {
+	return n.sharedList
+}
+
 func (n *NodeP2P) Peers() []peer.ID {
 	return n.host.Network().Peers()
 }
@@ -215,7 +272,12 @@ func (n *NodeP2P) GetPeerScore(id peer.ID) (float64, error) {
 	return n.store.GetPeerScore(id)
 }
 
+// IsStatic returns whether the peer is a static peer, or a trusted peer of the shared peer list.
+// These peers are not banned for their scores.
 func (n *NodeP2P) IsStatic(id peer.ID) bool {
+	if n.sharedList != nil && n.sharedList.IsTrusted(id) {
+		return true
+	}
 	return n.connMgr != nil && n.connMgr.IsProtected(id, staticPeerTag)
 }
 
@@ -254,6 +316,9 @@ func (n *NodeP2P) Close() error {
 	if n.peerMonitor != nil {
 		n.peerMonitor.Stop()
 	}
+	if n.sharedList != nil {
+		n.sharedList.Close()
+	}
 	if n.dv5Udp != nil {
 		n.dv5Udp.Close()
 	}
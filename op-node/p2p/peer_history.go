@@ -0,0 +1,87 @@
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+)
+
+// PeerHistoryRecorder records the events that affect the reputation of peers.
+type PeerHistoryRecorder interface {
+	RecordPeerEvent(id peer.ID, ev store.PeerEvent) (store.PeerHistory, error)
+}
+
+// PeerReputation explains the reputation of a peer: its scores, the history of events behind them, and its ban.
+type PeerReputation struct {
+	PeerID  peer.ID           `json:"peerID"`
+	Scores  store.PeerScores  `json:"scores"`
+	History store.PeerHistory `json:"history"`
+	// BannedUntil is the expiration of the ban of the peer, nil if the peer is not banned.
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+// historyAppScorer records the req-resp events of the peers in their history, independently of the application scoring.
+type historyAppScorer struct {
+	ApplicationScorer
+	log     log.Logger
+	history PeerHistoryRecorder
+}
+
+func newHistoryAppScorer(scorer ApplicationScorer, log log.Logger, history PeerHistoryRecorder) *historyAppScorer {
+	return &historyAppScorer{ApplicationScorer: scorer, log: log, history: history}
+}
+
+func (s *historyAppScorer) record(id peer.ID, ev store.PeerEvent) {
+	if _, err := s.history.RecordPeerEvent(id, ev); err != nil {
+		s.log.Error("Unable to update peer history", "peer", id, "err", err)
+	}
+}
+
+func (s *historyAppScorer) onValidResponse(id peer.ID) {
+	s.ApplicationScorer.onValidResponse(id)
+	s.record(id, store.PeerEvent{Kind: store.PeerEventValidResponse})
+}
+
+func (s *historyAppScorer) onResponseError(id peer.ID) {
+	s.ApplicationScorer.onResponseError(id)
+	s.record(id, store.PeerEvent{Kind: store.PeerEventErrorResponse})
+}
+
+func (s *historyAppScorer) onRejectedPayload(id peer.ID, hash common.Hash) {
+	s.ApplicationScorer.onRejectedPayload(id, hash)
+	s.record(id, store.PeerEvent{Kind: store.PeerEventRejectedPayload, Hash: &hash})
+}
+
+var _ ApplicationScorer = (*historyAppScorer)(nil)
+
+// recordGossipHistory records the validation results of the gossip messages of other peers in their history.
+func recordGossipHistory(self peer.ID, log log.Logger, history PeerHistoryRecorder, topic string, fn pubsub.ValidatorEx) pubsub.ValidatorEx {
+	if history == nil {
+		return fn
+	}
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		res := fn(ctx, id, message)
+		if id == self {
+			return res
+		}
+		var kind store.PeerEventKind
+		switch res {
+		case pubsub.ValidationAccept:
+			kind = store.PeerEventValidGossip
+		case pubsub.ValidationIgnore:
+			kind = store.PeerEventIgnoredGossip
+		default:
+			kind = store.PeerEventInvalidGossip
+		}
+		if _, err := history.RecordPeerEvent(id, store.PeerEvent{Kind: kind, Topic: topic}); err != nil {
+			log.Error("Unable to update peer history", "peer", id, "err", err)
+		}
+		return res
+	}
+}
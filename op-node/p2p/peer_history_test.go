@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type peerEventsRecorder map[peer.ID][]store.PeerEvent
+
+func (r peerEventsRecorder) RecordPeerEvent(id peer.ID, ev store.PeerEvent) (store.PeerHistory, error) {
+	r[id] = append(r[id], ev)
+	return store.PeerHistory{}, nil
+}
+
+func TestRecordGossipHistory(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	self := peer.ID("self")
+	recorder := make(peerEventsRecorder)
+	result := pubsub.ValidationAccept
+	validator := recordGossipHistory(self, logger, recorder, "blocksV3", func(context.Context, peer.ID, *pubsub.Message) pubsub.ValidationResult {
+		return result
+	})
+
+	for _, result = range []pubsub.ValidationResult{pubsub.ValidationAccept, pubsub.ValidationIgnore, pubsub.ValidationReject} {
+		require.Equal(t, result, validator(context.Background(), "aaa", &pubsub.Message{}))
+	}
+	// the messages of this node are not recorded
+	require.Equal(t, result, validator(context.Background(), self, &pubsub.Message{}))
+
+	require.Equal(t, []store.PeerEvent{
+		{Kind: store.PeerEventValidGossip, Topic: "blocksV3"},
+		{Kind: store.PeerEventIgnoredGossip, Topic: "blocksV3"},
+		{Kind: store.PeerEventInvalidGossip, Topic: "blocksV3"},
+	}, recorder["aaa"])
+	require.NotContains(t, recorder, self)
+}
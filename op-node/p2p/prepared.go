@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/metrics"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -25,6 +27,10 @@ type Prepared struct {
 	UDPv5     *discover.UDPv5
 
 	EnableReqRespSync bool
+
+	AttestationsValidatorPool common.Address
+
+	EnableTxForwarding bool
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -92,3 +98,15 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) OutputAttestationsValidatorPool() common.Address {
+	return p.AttestationsValidatorPool
+}
+
+func (p *Prepared) TxForwardingEnabled() bool {
+	return p.EnableTxForwarding
+}
+
+func (p *Prepared) SharedPeerList() (string, time.Duration, ds.Datastore) {
+	return "", 0, nil
+}
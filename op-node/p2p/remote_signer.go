@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+)
+
+// BlockPayloadSigner signs p2p payloads with the key of a sender address.
+type BlockPayloadSigner interface {
+	SignBlockPayload(ctx context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error)
+}
+
+// RemoteSigner signs p2p messages through a signer service, so the sequencer p2p key
+// does not need to be on the sequencer host.
+type RemoteSigner struct {
+	log     log.Logger
+	client  BlockPayloadSigner
+	address common.Address
+
+	mu            sync.Mutex
+	addressSource func() common.Address
+	current       common.Address
+	closed        bool
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+func NewRemoteSigner(l log.Logger, client BlockPayloadSigner, address common.Address) *RemoteSigner {
+	return &RemoteSigner{log: l, client: client, address: address, current: address}
+}
+
+// FollowSignerAddress makes the signer sign with the key of the address returned by the source,
+// e.g. the unsafe block signer of the SystemConfig, so the signing key is rotated as soon as it changes there.
+// The configured address is used while the source returns the zero address.
+func (s *RemoteSigner) FollowSignerAddress(source func() common.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addressSource = source
+}
+
+// sender returns the address to sign with.
+func (s *RemoteSigner) sender() (common.Address, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return common.Address{}, errors.New("signer is closed")
+	}
+	sender := s.address
+	if s.addressSource != nil {
+		if addr := s.addressSource(); addr != (common.Address{}) {
+			sender = addr
+		}
+	}
+	if sender != s.current {
+		s.log.Info("Rotating p2p signer key to the new unsafe block signer", "old", s.current, "new", sender)
+		s.current = sender
+	}
+	return sender, nil
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (*[65]byte, error) {
+	sender, err := s.sender()
+	if err != nil {
+		return nil, err
+	}
+	signingHash, err := SigningHash(domain, chainID, encodedMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := s.client.SignBlockPayload(ctx, &opsigner.BlockPayloadArgs{
+		Domain:        domain,
+		ChainID:       (*hexutil.Big)(chainID),
+		PayloadHash:   crypto.Keccak256Hash(encodedMsg),
+		SenderAddress: &sender,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payload with remote signer: %w", err)
+	}
+
+	// peers drop messages that are not signed by the unsafe block signer, so do not publish them at all
+	pub, err := crypto.SigToPub(signingHash[:], sig[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from remote signer: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != sender {
+		return nil, fmt.Errorf("remote signer signed with %s instead of %s", signer, sender)
+	}
+	return &sig, nil
+}
+
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// RemoteSignerSetup sets up a RemoteSigner that connects to the signer service of the config.
+type RemoteSignerSetup struct {
+	Log    log.Logger
+	Config opsigner.CLIConfig
+}
+
+func (r *RemoteSignerSetup) SetupSigner(ctx context.Context) (Signer, error) {
+	client, err := opsigner.NewSignerClientFromConfig(r.Log, r.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer client: %w", err)
+	}
+	return NewRemoteSigner(r.Log, client, common.HexToAddress(r.Config.Address)), nil
+}
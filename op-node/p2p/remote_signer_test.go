@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubSignerService signs with the keys it holds, like a signer service would.
+type stubSignerService struct {
+	keys map[common.Address]*ecdsa.PrivateKey
+}
+
+func (s *stubSignerService) SignBlockPayload(_ context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error) {
+	key, ok := s.keys[*args.SenderAddress]
+	if !ok {
+		return [65]byte{}, errors.New("unknown sender")
+	}
+	var msg [96]byte
+	copy(msg[:32], args.Domain[:])
+	args.ChainID.ToInt().FillBytes(msg[32:64])
+	copy(msg[64:], args.PayloadHash[:])
+	sig, err := crypto.Sign(crypto.Keccak256(msg[:]), key)
+	if err != nil {
+		return [65]byte{}, err
+	}
+	return [65]byte(sig), nil
+}
+
+func TestRemoteSigner(t *testing.T) {
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	oldAddr, newAddr := crypto.PubkeyToAddress(oldKey.PublicKey), crypto.PubkeyToAddress(newKey.PublicKey)
+	service := &stubSignerService{keys: map[common.Address]*ecdsa.PrivateKey{oldAddr: oldKey, newAddr: newKey}}
+
+	chainID := big.NewInt(255)
+	msg := []byte("payload")
+	signingHash, err := SigningHash(SigningDomainBlocksV1, chainID, msg)
+	require.NoError(t, err)
+	requireSignedBy := func(signer *RemoteSigner, addr common.Address) {
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.NoError(t, err)
+		pub, err := crypto.SigToPub(signingHash[:], sig[:])
+		require.NoError(t, err)
+		require.Equal(t, addr, crypto.PubkeyToAddress(*pub))
+	}
+
+	signer := NewRemoteSigner(testlog.Logger(t, log.LevelInfo), service, oldAddr)
+	requireSignedBy(signer, oldAddr)
+
+	// the configured address is used until the unsafe block signer is known
+	unsafeBlockSigner := common.Address{}
+	signer.FollowSignerAddress(func() common.Address { return unsafeBlockSigner })
+	requireSignedBy(signer, oldAddr)
+
+	unsafeBlockSigner = newAddr
+	requireSignedBy(signer, newAddr)
+
+	// a key the service does not hold fails the signing
+	unsafeBlockSigner = common.Address{0x01}
+	_, err = signer.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+	require.ErrorContains(t, err, "unknown sender")
+
+	require.NoError(t, signer.Close())
+	_, err = signer.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+	require.ErrorContains(t, err, "signer is closed")
+}
+
+type wrongKeySignerService struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *wrongKeySignerService) SignBlockPayload(_ context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error) {
+	sig, err := crypto.Sign(args.PayloadHash[:], s.key)
+	return [65]byte(sig), err
+}
+
+func TestRemoteSignerRejectsWrongSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewRemoteSigner(testlog.Logger(t, log.LevelInfo), &wrongKeySignerService{key: key}, crypto.PubkeyToAddress(key.PublicKey))
+	_, err = signer.Sign(context.Background(), SigningDomainBlocksV1, big.NewInt(255), []byte("payload"))
+	require.ErrorContains(t, err, "remote signer signed with")
+}
@@ -60,4 +60,9 @@ type API interface {
 	UnprotectPeer(ctx context.Context, p peer.ID) error
 	ConnectPeer(ctx context.Context, addr string) error
 	DisconnectPeer(ctx context.Context, id peer.ID) error
+	PublishOutputAttestation(ctx context.Context, att *SignedOutputAttestation) error
+	OutputAttestations(ctx context.Context, l2BlockNumber uint64) (*OutputAttestationSummary, error)
+	PeerHistory(ctx context.Context, p peer.ID) (*PeerReputation, error)
+	PeerHistories(ctx context.Context) ([]*PeerReputation, error)
+	SharedPeerList(ctx context.Context) (*SharedPeerListStatus, error)
 }
@@ -107,3 +107,31 @@ func (c *Client) ConnectPeer(ctx context.Context, addr string) error {
 func (c *Client) DisconnectPeer(ctx context.Context, id peer.ID) error {
 	return c.c.CallContext(ctx, nil, prefixRPC("disconnectPeer"), id)
 }
+
+func (c *Client) PublishOutputAttestation(ctx context.Context, att *SignedOutputAttestation) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("publishOutputAttestation"), att)
+}
+
+func (c *Client) OutputAttestations(ctx context.Context, l2BlockNumber uint64) (*OutputAttestationSummary, error) {
+	var out *OutputAttestationSummary
+	err := c.c.CallContext(ctx, &out, prefixRPC("outputAttestations"), l2BlockNumber)
+	return out, err
+}
+
+func (c *Client) PeerHistory(ctx context.Context, p peer.ID) (*PeerReputation, error) {
+	var out *PeerReputation
+	err := c.c.CallContext(ctx, &out, prefixRPC("peerHistory"), p)
+	return out, err
+}
+
+func (c *Client) PeerHistories(ctx context.Context) ([]*PeerReputation, error) {
+	var out []*PeerReputation
+	err := c.c.CallContext(ctx, &out, prefixRPC("peerHistories"))
+	return out, err
+}
+
+func (c *Client) SharedPeerList(ctx context.Context) (*SharedPeerListStatus, error) {
+	var out *SharedPeerListStatus
+	err := c.c.CallContext(ctx, &out, prefixRPC("sharedPeerList"))
+	return out, err
+}
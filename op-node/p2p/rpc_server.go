@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
@@ -50,10 +51,14 @@ type Node interface {
 	GossipSub() *pubsub.PubSub
 	// GossipOut returns the gossip output/info control
 	GossipOut() GossipOut
+	// OutputAttestations returns the gossiped validator output attestations, nil if disabled
+	OutputAttestations() *OutputAttestationBook
 	// ConnectionGater returns the connection gater, to ban/unban peers with, may be nil
 	ConnectionGater() gating.BlockingConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// SharedPeerList returns the ban and trusted peer list shared by the nodes of the operator, nil if disabled
+	SharedPeerList() *SharedPeerListSync
 }
 
 type APIBackend struct {
@@ -428,3 +433,99 @@ func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	}
 	return nil
 }
+
+// PublishOutputAttestation gossips an output attestation signed by a validator.
+func (s *APIBackend) PublishOutputAttestation(ctx context.Context, att *SignedOutputAttestation) error {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_publishOutputAttestation")
+	defer recordDur()
+	if att == nil {
+		log.Warn("invalid output attestation", "method", "PublishOutputAttestation")
+		return ErrInvalidRequest
+	}
+	return s.node.GossipOut().PublishOutputAttestation(ctx, att)
+}
+
+// OutputAttestations shows how far validators agree on the output root of the given L2 block.
+func (s *APIBackend) OutputAttestations(_ context.Context, l2BlockNumber uint64) (*OutputAttestationSummary, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_outputAttestations")
+	defer recordDur()
+	if book := s.node.OutputAttestations(); book == nil {
+		return nil, ErrOutputAttestationsDisabled
+	} else {
+		return book.Summary(l2BlockNumber), nil
+	}
+}
+
+// ErrNoExtendedPeerstore is returned when the peerstore of the host does not keep the reputation of peers.
+var ErrNoExtendedPeerstore = errors.New("peerstore does not keep peer reputations")
+
+func (s *APIBackend) extendedPeerstore() (store.ExtendedPeerstore, error) {
+	if eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore); ok {
+		return eps, nil
+	}
+	return nil, ErrNoExtendedPeerstore
+}
+
+func peerReputation(eps store.ExtendedPeerstore, id peer.ID, history store.PeerHistory) (*PeerReputation, error) {
+	scores, err := eps.GetPeerScores(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scores of peer %s: %w", id, err)
+	}
+	rep := &PeerReputation{PeerID: id, Scores: scores, History: history}
+	if expiry, err := eps.GetPeerBanExpiration(id); err == nil {
+		rep.BannedUntil = &expiry
+	} else if !errors.Is(err, store.UnknownBanErr) {
+		return nil, fmt.Errorf("failed to get ban of peer %s: %w", id, err)
+	}
+	return rep, nil
+}
+
+// PeerHistory explains the reputation of a peer with the history of the events behind its scores.
+func (s *APIBackend) PeerHistory(_ context.Context, id peer.ID) (*PeerReputation, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_peerHistory")
+	defer recordDur()
+	eps, err := s.extendedPeerstore()
+	if err != nil {
+		return nil, err
+	}
+	history, err := eps.GetPeerHistory(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of peer %s: %w", id, err)
+	}
+	return peerReputation(eps, id, history)
+}
+
+// PeerHistories returns the reputation of all peers with recent events, to export them.
+func (s *APIBackend) PeerHistories(_ context.Context) ([]*PeerReputation, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_peerHistories")
+	defer recordDur()
+	eps, err := s.extendedPeerstore()
+	if err != nil {
+		return nil, err
+	}
+	histories, err := eps.PeerHistories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer histories: %w", err)
+	}
+	out := make([]*PeerReputation, 0, len(histories))
+	for id, history := range histories {
+		rep, err := peerReputation(eps, id, history)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rep)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PeerID < out[j].PeerID })
+	return out, nil
+}
+
+// SharedPeerList returns the effective ban and trusted peer list shared by the nodes of the operator.
+func (s *APIBackend) SharedPeerList(_ context.Context) (*SharedPeerListStatus, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_sharedPeerList")
+	defer recordDur()
+	if list := s.node.SharedPeerList(); list == nil {
+		return nil, ErrNoSharedPeerList
+	} else {
+		return list.Status(), nil
+	}
+}
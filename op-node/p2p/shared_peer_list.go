@@ -0,0 +1,612 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	manet "github.com/multiformats/go-multiaddr/net"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// sharedPeerListBanDuration is the expiration of the bans of the shared list in the peerstore ban books.
+	// The bans are renewed on every reload, so the ban books follow the list without keeping removed entries for long.
+	sharedPeerListBanDuration = 24 * time.Hour
+	// sharedPeerListTimeout is the timeout of a load of the shared list.
+	sharedPeerListTimeout = 10 * time.Second
+	// maxSharedPeerListSize is the maximum size of the shared list, in bytes.
+	maxSharedPeerListSize = 10 * 1024 * 1024
+	// trustedGroupTagPrefix prefixes the name of a trusted group to make the tag its peers are protected with.
+	trustedGroupTagPrefix = "trusted-"
+)
+
+var ErrNoSharedPeerList = errors.New("shared peer list is not configured")
+
+// sharedPeerListStateKey is the datastore key of the entries the shared list applied.
+var sharedPeerListStateKey = ds.NewKey("/shared_peer_list/state")
+
+// SharedPeerList is a list of banned and trusted peers, shared by the op-nodes of an operator.
+type SharedPeerList struct {
+	BannedPeers []peer.ID `json:"bannedPeers,omitempty"`
+	BannedIPs   []net.IP  `json:"bannedIPs,omitempty"`
+	// BannedSubnets are the banned IP subnets, in CIDR notation.
+	BannedSubnets []string `json:"bannedSubnets,omitempty"`
+	// TrustedGroups are named groups of peers, e.g. the other nodes of the fleet, that are protected from
+	// being pruned by the connection manager and banned for their scores.
+	TrustedGroups map[string][]peer.ID `json:"trustedGroups,omitempty"`
+}
+
+func (l *SharedPeerList) Check() error {
+	for _, id := range l.BannedPeers {
+		if err := id.Validate(); err != nil {
+			return fmt.Errorf("invalid banned peer %q: %w", id, err)
+		}
+	}
+	for i, ip := range l.BannedIPs {
+		if ip == nil {
+			return fmt.Errorf("invalid banned IP at index %d", i)
+		}
+	}
+	for _, subnet := range l.BannedSubnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			return fmt.Errorf("invalid banned subnet %q: %w", subnet, err)
+		}
+	}
+	for group, ids := range l.TrustedGroups {
+		if group == "" {
+			return errors.New("trusted group without name")
+		}
+		for _, id := range ids {
+			if err := id.Validate(); err != nil {
+				return fmt.Errorf("invalid peer %q in trusted group %q: %w", id, group, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SharedPeerListStatus is the state of the shared peer list of a node.
+type SharedPeerListStatus struct {
+	Source string `json:"source"`
+	// List is the effective list: the last list that was loaded successfully, nil if none was loaded yet.
+	List     *SharedPeerList `json:"list"`
+	LastLoad time.Time       `json:"lastLoad"`
+	// LastError is the error of the last load, empty if it succeeded. The effective list is kept on errors.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// sharedPeerListState is what the shared list applied to the gater and the ban books. It is kept in the datastore,
+// so the entries the list applied are lifted when they are removed from it, also after a restart.
+type sharedPeerListState struct {
+	BlockedPeers   []peer.ID `json:"blockedPeers,omitempty"`
+	BlockedIPs     []net.IP  `json:"blockedIPs,omitempty"`
+	BlockedSubnets []string  `json:"blockedSubnets,omitempty"`
+	// PeerBans and IPBans are the ban book entries set by the list, with the expirations they were set with.
+	PeerBans map[peer.ID]time.Time `json:"peerBans,omitempty"`
+	IPBans   map[string]time.Time  `json:"ipBans,omitempty"`
+}
+
+// peerConns closes the connections of banned peers.
+type peerConns interface {
+	Conns() []network.Conn
+	ClosePeer(peer.ID) error
+}
+
+// SharedPeerListSync loads the shared peer list from a file or an HTTP endpoint, reloads it periodically,
+// and applies the changes to the connection gater, the peerstore ban books and the connection manager.
+// The blocks and bans the list adds are lifted when their entries are removed from the list, but the blocks and bans
+// added before or since, e.g. through the RPC or by the peer scoring, are left as they are. What the list added is
+// kept in the datastore, so the entries removed while the node is not running are lifted on the first load.
+type SharedPeerListSync struct {
+	log     log.Logger
+	source  string
+	refresh time.Duration
+	clock   clock.Clock
+	conns   peerConns
+	gater   gating.BlockingConnectionGater // may be nil
+	connMgr connmgr.ConnManager            // may be nil
+	bans    gating.ExpiryStore
+	store   ds.Datastore // may be nil
+
+	ctx      context.Context
+	cancelFn context.CancelFunc
+	bgTasks  sync.WaitGroup
+
+	mu       sync.Mutex
+	list     *SharedPeerList
+	raw      []byte
+	lastLoad time.Time
+	lastErr  error
+	// the gater blocks added by the list
+	blockedPeers   map[peer.ID]struct{}
+	blockedIPs     map[string]net.IP
+	blockedSubnets map[string]*net.IPNet
+	// the ban book entries set by the list, with their expirations
+	peerBans map[peer.ID]time.Time
+	ipBans   map[string]time.Time
+	// the trusted peers by group
+	trusted map[string]map[peer.ID]struct{}
+}
+
+// NewSharedPeerListSync creates the sync of the list from the source. The entries the list applied before
+// are read from the store, if any.
+func NewSharedPeerListSync(ctx context.Context, log log.Logger, source string, refresh time.Duration, clock clock.Clock, conns peerConns, gater gating.BlockingConnectionGater, connMgr connmgr.ConnManager, bans gating.ExpiryStore, datastore ds.Datastore) *SharedPeerListSync {
+	ctx, cancelFn := context.WithCancel(ctx)
+	s := &SharedPeerListSync{
+		log:            log,
+		source:         source,
+		refresh:        refresh,
+		clock:          clock,
+		conns:          conns,
+		gater:          gater,
+		connMgr:        connMgr,
+		bans:           bans,
+		store:          datastore,
+		ctx:            ctx,
+		cancelFn:       cancelFn,
+		blockedPeers:   make(map[peer.ID]struct{}),
+		blockedIPs:     make(map[string]net.IP),
+		blockedSubnets: make(map[string]*net.IPNet),
+		peerBans:       make(map[peer.ID]time.Time),
+		ipBans:         make(map[string]time.Time),
+		trusted:        make(map[string]map[peer.ID]struct{}),
+	}
+	if err := s.loadState(ctx); err != nil {
+		log.Warn("Failed to read the entries the shared peer list applied, they are not lifted if removed", "err", err)
+	}
+	return s
+}
+
+func (s *SharedPeerListSync) loadState(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+	data, err := s.store.Get(ctx, sharedPeerListStateKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var state sharedPeerListState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to decode shared peer list state: %w", err)
+	}
+	for _, id := range state.BlockedPeers {
+		s.blockedPeers[id] = struct{}{}
+	}
+	for _, ip := range state.BlockedIPs {
+		s.blockedIPs[ip.String()] = ip
+	}
+	for _, subnet := range state.BlockedSubnets {
+		if _, ipnet, err := net.ParseCIDR(subnet); err == nil {
+			s.blockedSubnets[ipnet.String()] = ipnet
+		}
+	}
+	for id, expiry := range state.PeerBans {
+		s.peerBans[id] = expiry
+	}
+	for ip, expiry := range state.IPBans {
+		s.ipBans[ip] = expiry
+	}
+	return nil
+}
+
+func (s *SharedPeerListSync) saveStateLocked(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+	state := sharedPeerListState{PeerBans: s.peerBans, IPBans: s.ipBans}
+	for id := range s.blockedPeers {
+		state.BlockedPeers = append(state.BlockedPeers, id)
+	}
+	for _, ip := range s.blockedIPs {
+		state.BlockedIPs = append(state.BlockedIPs, ip)
+	}
+	for key := range s.blockedSubnets {
+		state.BlockedSubnets = append(state.BlockedSubnets, key)
+	}
+	data, err := json.Marshal(&state)
+	if err != nil {
+		return err
+	}
+	return s.store.Put(ctx, sharedPeerListStateKey, data)
+}
+
+func (s *SharedPeerListSync) Start() {
+	s.bgTasks.Add(1)
+	go func() {
+		defer s.bgTasks.Done()
+		ticker := s.clock.NewTicker(s.refresh)
+		defer ticker.Stop()
+		for {
+			s.Reload(s.ctx)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.Ch():
+			}
+		}
+	}()
+}
+
+func (s *SharedPeerListSync) Close() {
+	s.cancelFn()
+	s.bgTasks.Wait()
+}
+
+// Reload loads the list and applies it. The last list is kept if the load fails.
+func (s *SharedPeerListSync) Reload(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, sharedPeerListTimeout)
+	defer cancel()
+	raw, list, err := s.load(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.log.Warn("Failed to load shared peer list, keeping the last list", "source", s.source, "err", err)
+		s.lastErr = err
+		return
+	}
+	if !bytes.Equal(raw, s.raw) {
+		s.log.Info("Loaded shared peer list", "source", s.source, "banned_peers", len(list.BannedPeers),
+			"banned_ips", len(list.BannedIPs), "banned_subnets", len(list.BannedSubnets), "trusted_groups", len(list.TrustedGroups))
+	}
+	s.applyLocked(list)
+	if err := s.saveStateLocked(ctx); err != nil {
+		s.log.Warn("Failed to save the entries the shared peer list applied", "err", err)
+	}
+	s.list = list
+	s.raw = raw
+	s.lastLoad = s.clock.Now()
+	s.lastErr = nil
+}
+
+func (s *SharedPeerListSync) load(ctx context.Context) ([]byte, *SharedPeerList, error) {
+	var raw []byte
+	var err error
+	if strings.HasPrefix(s.source, "http://") || strings.HasPrefix(s.source, "https://") {
+		raw, err = fetchSharedPeerList(ctx, s.source)
+	} else {
+		raw, err = os.ReadFile(s.source)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var list SharedPeerList
+	if err := dec.Decode(&list); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode shared peer list: %w", err)
+	}
+	if err := list.Check(); err != nil {
+		return nil, nil, err
+	}
+	return raw, &list, nil
+}
+
+func fetchSharedPeerList(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSharedPeerListSize))
+}
+
+func (s *SharedPeerListSync) applyLocked(list *SharedPeerList) {
+	s.applyPeerBansLocked(list.BannedPeers)
+	s.applyIPBansLocked(list.BannedIPs)
+	s.applySubnetBansLocked(list.BannedSubnets)
+	s.applyTrustedGroupsLocked(list.TrustedGroups)
+}
+
+func (s *SharedPeerListSync) applyPeerBansLocked(ids []peer.ID) {
+	banned := make(map[peer.ID]struct{}, len(ids))
+	for _, id := range ids {
+		banned[id] = struct{}{}
+	}
+	for id, expiry := range s.peerBans {
+		if _, ok := banned[id]; ok {
+			continue
+		}
+		delete(s.peerBans, id)
+		// a ban renewed since by the peer scoring or through the RPC is not the list's anymore
+		if current, err := s.bans.GetPeerBanExpiration(id); err == nil && current.Unix() == expiry.Unix() {
+			if err := s.bans.SetPeerBanExpiration(id, time.Time{}); err != nil {
+				s.log.Warn("Failed to lift peer ban of shared peer list", "peer", id, "err", err)
+				continue
+			}
+		}
+		s.log.Info("Unbanned peer removed from shared peer list", "peer", id)
+	}
+	if s.gater != nil {
+		for id := range s.blockedPeers {
+			if _, ok := banned[id]; ok {
+				continue
+			}
+			if err := s.gater.UnblockPeer(id); err != nil {
+				s.log.Warn("Failed to unblock peer of shared peer list", "peer", id, "err", err)
+				continue
+			}
+			delete(s.blockedPeers, id)
+			s.log.Info("Unblocked peer removed from shared peer list", "peer", id)
+		}
+	}
+
+	var alreadyBlocked map[peer.ID]struct{}
+	if s.gater != nil {
+		alreadyBlocked = make(map[peer.ID]struct{})
+		for _, id := range s.gater.ListBlockedPeers() {
+			alreadyBlocked[id] = struct{}{}
+		}
+	}
+	expiry := s.clock.Now().Add(sharedPeerListBanDuration)
+	for id := range banned {
+		s.banPeerLocked(id, expiry)
+		if s.gater != nil {
+			_, owned := s.blockedPeers[id]
+			if _, ok := alreadyBlocked[id]; !ok && !owned {
+				if err := s.gater.BlockPeer(id); err != nil {
+					s.log.Warn("Failed to block peer of shared peer list", "peer", id, "err", err)
+					continue
+				}
+				s.blockedPeers[id] = struct{}{}
+				s.log.Info("Banned peer of shared peer list", "peer", id)
+			}
+		}
+		if err := s.conns.ClosePeer(id); err != nil {
+			s.log.Warn("Failed to close connections of banned peer", "peer", id, "err", err)
+		}
+	}
+}
+
+// banPeerLocked sets or renews the ban of the peer in the ban book, unless it is banned by someone else.
+func (s *SharedPeerListSync) banPeerLocked(id peer.ID, expiry time.Time) {
+	current, err := s.bans.GetPeerBanExpiration(id)
+	if err != nil && !errors.Is(err, store.UnknownBanErr) {
+		s.log.Warn("Failed to read peer ban", "peer", id, "err", err)
+		return
+	}
+	owned, ok := s.peerBans[id]
+	if err == nil && current.After(s.clock.Now()) && !(ok && current.Unix() == owned.Unix()) {
+		// left to whoever banned the peer, the list takes the ban over once it expires
+		delete(s.peerBans, id)
+		return
+	}
+	if err := s.bans.SetPeerBanExpiration(id, expiry); err != nil {
+		s.log.Warn("Failed to ban peer of shared peer list", "peer", id, "err", err)
+		return
+	}
+	s.peerBans[id] = expiry
+}
+
+func (s *SharedPeerListSync) applyIPBansLocked(ips []net.IP) {
+	banned := make(map[string]net.IP, len(ips))
+	for _, ip := range ips {
+		banned[ip.String()] = ip
+	}
+	for key, expiry := range s.ipBans {
+		if _, ok := banned[key]; ok {
+			continue
+		}
+		delete(s.ipBans, key)
+		ip := net.ParseIP(key)
+		if current, err := s.bans.GetIPBanExpiration(ip); err == nil && current.Unix() == expiry.Unix() {
+			if err := s.bans.SetIPBanExpiration(ip, time.Time{}); err != nil {
+				s.log.Warn("Failed to lift IP ban of shared peer list", "ip", ip, "err", err)
+				continue
+			}
+		}
+		s.log.Info("Unbanned IP removed from shared peer list", "ip", ip)
+	}
+	if s.gater != nil {
+		for key, ip := range s.blockedIPs {
+			if _, ok := banned[key]; ok {
+				continue
+			}
+			if err := s.gater.UnblockAddr(ip); err != nil {
+				s.log.Warn("Failed to unblock IP of shared peer list", "ip", ip, "err", err)
+				continue
+			}
+			delete(s.blockedIPs, key)
+			s.log.Info("Unblocked IP removed from shared peer list", "ip", ip)
+		}
+	}
+
+	var alreadyBlocked map[string]struct{}
+	if s.gater != nil {
+		alreadyBlocked = make(map[string]struct{})
+		for _, ip := range s.gater.ListBlockedAddrs() {
+			alreadyBlocked[ip.String()] = struct{}{}
+		}
+	}
+	expiry := s.clock.Now().Add(sharedPeerListBanDuration)
+	for key, ip := range banned {
+		s.banIPLocked(ip, expiry)
+		if s.gater != nil {
+			_, owned := s.blockedIPs[key]
+			if _, ok := alreadyBlocked[key]; !ok && !owned {
+				if err := s.gater.BlockAddr(ip); err != nil {
+					s.log.Warn("Failed to block IP of shared peer list", "ip", ip, "err", err)
+					continue
+				}
+				s.blockedIPs[key] = ip
+				s.log.Info("Banned IP of shared peer list", "ip", ip)
+			}
+		}
+	}
+	s.closeConnsLocked(func(ip net.IP) bool {
+		_, ok := banned[ip.String()]
+		return ok
+	})
+}
+
+// banIPLocked sets or renews the ban of the IP in the ban book, unless it is banned by someone else.
+func (s *SharedPeerListSync) banIPLocked(ip net.IP, expiry time.Time) {
+	current, err := s.bans.GetIPBanExpiration(ip)
+	if err != nil && !errors.Is(err, store.UnknownBanErr) {
+		s.log.Warn("Failed to read IP ban", "ip", ip, "err", err)
+		return
+	}
+	owned, ok := s.ipBans[ip.String()]
+	if err == nil && current.After(s.clock.Now()) && !(ok && current.Unix() == owned.Unix()) {
+		delete(s.ipBans, ip.String())
+		return
+	}
+	if err := s.bans.SetIPBanExpiration(ip, expiry); err != nil {
+		s.log.Warn("Failed to ban IP of shared peer list", "ip", ip, "err", err)
+		return
+	}
+	s.ipBans[ip.String()] = expiry
+}
+
+func (s *SharedPeerListSync) applySubnetBansLocked(subnets []string) {
+	banned := make(map[string]*net.IPNet, len(subnets))
+	for _, subnet := range subnets {
+		_, ipnet, _ := net.ParseCIDR(subnet) // checked when loaded
+		banned[ipnet.String()] = ipnet
+	}
+	if s.gater == nil {
+		return
+	}
+	for key, ipnet := range s.blockedSubnets {
+		if _, ok := banned[key]; ok {
+			continue
+		}
+		if err := s.gater.UnblockSubnet(ipnet); err != nil {
+			s.log.Warn("Failed to unblock subnet of shared peer list", "subnet", ipnet, "err", err)
+			continue
+		}
+		delete(s.blockedSubnets, key)
+		s.log.Info("Unbanned subnet removed from shared peer list", "subnet", ipnet)
+	}
+
+	alreadyBlocked := make(map[string]struct{})
+	for _, ipnet := range s.gater.ListBlockedSubnets() {
+		alreadyBlocked[ipnet.String()] = struct{}{}
+	}
+	for key, ipnet := range banned {
+		_, owned := s.blockedSubnets[key]
+		if _, ok := alreadyBlocked[key]; ok || owned {
+			continue
+		}
+		if err := s.gater.BlockSubnet(ipnet); err != nil {
+			s.log.Warn("Failed to block subnet of shared peer list", "subnet", ipnet, "err", err)
+			continue
+		}
+		s.blockedSubnets[key] = ipnet
+		s.log.Info("Banned subnet of shared peer list", "subnet", ipnet)
+	}
+	s.closeConnsLocked(func(ip net.IP) bool {
+		for _, ipnet := range banned {
+			if ipnet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// closeConnsLocked closes the connections to the remote IPs that match.
+func (s *SharedPeerListSync) closeConnsLocked(match func(ip net.IP) bool) {
+	for _, conn := range s.conns.Conns() {
+		ip, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil || !match(ip) {
+			continue
+		}
+		if err := conn.Close(); err != nil {
+			s.log.Warn("Failed to close connection to banned IP", "peer", conn.RemotePeer(), "ip", ip, "err", err)
+		}
+	}
+}
+
+func (s *SharedPeerListSync) applyTrustedGroupsLocked(groups map[string][]peer.ID) {
+	for group, members := range s.trusted {
+		ids := make(map[peer.ID]struct{}, len(groups[group]))
+		for _, id := range groups[group] {
+			ids[id] = struct{}{}
+		}
+		for id := range members {
+			if _, ok := ids[id]; ok {
+				continue
+			}
+			if s.connMgr != nil {
+				s.connMgr.Unprotect(id, trustedGroupTagPrefix+group)
+			}
+			delete(members, id)
+			s.log.Info("Removed peer from trusted group", "group", group, "peer", id)
+		}
+		if len(members) == 0 {
+			delete(s.trusted, group)
+		}
+	}
+	for group, ids := range groups {
+		members, ok := s.trusted[group]
+		if !ok {
+			members = make(map[peer.ID]struct{}, len(ids))
+			s.trusted[group] = members
+		}
+		for _, id := range ids {
+			if _, ok := members[id]; ok {
+				continue
+			}
+			if s.connMgr != nil {
+				s.connMgr.Protect(id, trustedGroupTagPrefix+group)
+			}
+			members[id] = struct{}{}
+			s.log.Info("Added peer to trusted group", "group", group, "peer", id)
+		}
+	}
+}
+
+// IsTrusted returns whether the peer is in a trusted group of the list.
+func (s *SharedPeerListSync) IsTrusted(id peer.ID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, members := range s.trusted {
+		if _, ok := members[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Status returns the effective list and the state of its loads.
+func (s *SharedPeerListSync) Status() *SharedPeerListStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := &SharedPeerListStatus{
+		Source:   s.source,
+		List:     s.list,
+		LastLoad: s.lastLoad,
+	}
+	if s.lastErr != nil {
+		status.LastError = s.lastErr.Error()
+	}
+	return status
+}
@@ -0,0 +1,204 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	cmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testBanStore struct {
+	peers map[peer.ID]time.Time
+	ips   map[string]time.Time
+}
+
+func (s *testBanStore) SetPeerBanExpiration(id peer.ID, expiry time.Time) error {
+	if expiry == (time.Time{}) {
+		delete(s.peers, id)
+	} else {
+		s.peers[id] = expiry
+	}
+	return nil
+}
+
+func (s *testBanStore) GetPeerBanExpiration(id peer.ID) (time.Time, error) {
+	if expiry, ok := s.peers[id]; ok {
+		return expiry, nil
+	}
+	return time.Time{}, store.UnknownBanErr
+}
+
+func (s *testBanStore) SetIPBanExpiration(ip net.IP, expiry time.Time) error {
+	if expiry == (time.Time{}) {
+		delete(s.ips, ip.String())
+	} else {
+		s.ips[ip.String()] = expiry
+	}
+	return nil
+}
+
+func (s *testBanStore) GetIPBanExpiration(ip net.IP) (time.Time, error) {
+	if expiry, ok := s.ips[ip.String()]; ok {
+		return expiry, nil
+	}
+	return time.Time{}, store.UnknownBanErr
+}
+
+type testPeerConns struct {
+	closed []peer.ID
+}
+
+func (c *testPeerConns) Conns() []network.Conn {
+	return nil
+}
+
+func (c *testPeerConns) ClosePeer(id peer.ID) error {
+	c.closed = append(c.closed, id)
+	return nil
+}
+
+func randomPeerID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateSecp256k1Key(nil)
+	require.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return id
+}
+
+func writeSharedPeerList(t *testing.T, path string, list *SharedPeerList) {
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+func TestSharedPeerListSync(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	datastore := sync.MutexWrap(ds.NewMapDatastore())
+	gater, err := gating.NewBlockingConnectionGater(datastore)
+	require.NoError(t, err)
+	connMgr, err := cmgr.NewConnManager(1, 10)
+	require.NoError(t, err)
+	bans := &testBanStore{peers: make(map[peer.ID]time.Time), ips: make(map[string]time.Time)}
+	conns := &testPeerConns{}
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+
+	mallory, eve, manual, friend := randomPeerID(t), randomPeerID(t), randomPeerID(t), randomPeerID(t)
+	require.NoError(t, gater.BlockPeer(manual))
+
+	path := filepath.Join(t.TempDir(), "peers.json")
+	writeSharedPeerList(t, path, &SharedPeerList{
+		BannedPeers:   []peer.ID{mallory, eve, manual},
+		BannedIPs:     []net.IP{net.IPv4(10, 0, 0, 1)},
+		BannedSubnets: []string{"192.168.0.0/16"},
+		TrustedGroups: map[string][]peer.ID{"fleet": {friend}},
+	})
+	s := NewSharedPeerListSync(context.Background(), logger, path, time.Minute, clk, conns, gater, connMgr, bans, datastore)
+	s.Reload(context.Background())
+
+	status := s.Status()
+	require.Empty(t, status.LastError)
+	require.NotNil(t, status.List)
+	require.ElementsMatch(t, []peer.ID{mallory, eve, manual}, gater.ListBlockedPeers())
+	require.Len(t, gater.ListBlockedAddrs(), 1)
+	require.Len(t, gater.ListBlockedSubnets(), 1)
+	require.Equal(t, clk.Now().Add(sharedPeerListBanDuration), bans.peers[mallory])
+	require.Contains(t, bans.ips, "10.0.0.1")
+	require.ElementsMatch(t, []peer.ID{mallory, eve, manual}, conns.closed)
+	require.True(t, connMgr.IsProtected(friend, trustedGroupTagPrefix+"fleet"))
+	require.True(t, s.IsTrusted(friend))
+
+	// removed entries are lifted, except the block that was added before the list
+	writeSharedPeerList(t, path, &SharedPeerList{BannedPeers: []peer.ID{eve}})
+	s.Reload(context.Background())
+	require.ElementsMatch(t, []peer.ID{eve, manual}, gater.ListBlockedPeers())
+	require.Empty(t, gater.ListBlockedAddrs())
+	require.Empty(t, gater.ListBlockedSubnets())
+	require.NotContains(t, bans.peers, mallory)
+	require.NotContains(t, bans.ips, "10.0.0.1")
+	require.False(t, connMgr.IsProtected(friend, trustedGroupTagPrefix+"fleet"))
+	require.False(t, s.IsTrusted(friend))
+
+	// an invalid list is reported, and the last list is kept
+	require.NoError(t, os.WriteFile(path, []byte(`{"bannedSubnets": ["nope"]}`), 0o644))
+	s.Reload(context.Background())
+	status = s.Status()
+	require.NotEmpty(t, status.LastError)
+	require.Equal(t, []peer.ID{eve}, status.List.BannedPeers)
+	require.ElementsMatch(t, []peer.ID{eve, manual}, gater.ListBlockedPeers())
+}
+
+func TestSharedPeerListSyncRestart(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	datastore := sync.MutexWrap(ds.NewMapDatastore())
+	bans := &testBanStore{peers: make(map[peer.ID]time.Time), ips: make(map[string]time.Time)}
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	start := func(source string) (*SharedPeerListSync, gating.BlockingConnectionGater) {
+		gater, err := gating.NewBlockingConnectionGater(datastore)
+		require.NoError(t, err)
+		s := NewSharedPeerListSync(context.Background(), logger, source, time.Minute, clk, &testPeerConns{}, gater, nil, bans, datastore)
+		s.Reload(context.Background())
+		require.Empty(t, s.Status().LastError)
+		return s, gater
+	}
+
+	mallory, eve, admin := randomPeerID(t), randomPeerID(t), randomPeerID(t)
+	adminBan := clk.Now().Add(48 * time.Hour)
+	bans.peers[admin] = adminBan
+	path := filepath.Join(t.TempDir(), "peers.json")
+	writeSharedPeerList(t, path, &SharedPeerList{
+		BannedPeers:   []peer.ID{mallory, eve, admin},
+		BannedIPs:     []net.IP{net.IPv4(10, 0, 0, 1)},
+		BannedSubnets: []string{"192.168.0.0/16"},
+	})
+	s, _ := start(path)
+	// the ban of eve is renewed by the peer scoring
+	eveBan := clk.Now().Add(72 * time.Hour)
+	bans.peers[eve] = eveBan
+	s.Close()
+
+	// the entries are removed while the node is not running
+	writeSharedPeerList(t, path, &SharedPeerList{})
+	_, gater := start(path)
+	require.Empty(t, gater.ListBlockedPeers())
+	require.Empty(t, gater.ListBlockedAddrs())
+	require.Empty(t, gater.ListBlockedSubnets())
+	require.NotContains(t, bans.peers, mallory)
+	require.NotContains(t, bans.ips, "10.0.0.1")
+	// the bans the list did not set are kept
+	require.Equal(t, eveBan, bans.peers[eve])
+	require.Equal(t, adminBan, bans.peers[admin])
+}
+
+func TestSharedPeerListSyncHTTP(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	mallory := randomPeerID(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&SharedPeerList{BannedPeers: []peer.ID{mallory}}))
+	}))
+	defer srv.Close()
+
+	bans := &testBanStore{peers: make(map[peer.ID]time.Time), ips: make(map[string]time.Time)}
+	s := NewSharedPeerListSync(context.Background(), logger, srv.URL, time.Minute, clock.NewDeterministicClock(time.Unix(1000, 0)), &testPeerConns{}, nil, nil, bans, nil)
+	s.Reload(context.Background())
+	require.Empty(t, s.Status().LastError)
+	require.Contains(t, bans.peers, mallory)
+}
@@ -19,6 +19,9 @@ type extendedStore struct {
 	*peerBanBook
 	*ipBanBook
 	*metadataBook
+	// [Kroma: START]
+	*historyBook
+	// [Kroma: END]
 }
 
 func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Clock, ps peerstore.Peerstore, store ds.Batching, scoreRetention time.Duration) (ExtendedPeerstore, error) {
@@ -46,6 +49,13 @@ func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Cl
 		return nil, fmt.Errorf("create metadata book: %w", err)
 	}
 	md.startGC()
+	// [Kroma: START]
+	hb, err := newHistoryBook(ctx, logger, clock, store)
+	if err != nil {
+		return nil, fmt.Errorf("create peer history book: %w", err)
+	}
+	hb.startGC()
+	// [Kroma: END]
 	return &extendedStore{
 		Peerstore:         ps,
 		CertifiedAddrBook: cab,
@@ -53,6 +63,9 @@ func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Cl
 		peerBanBook:       pb,
 		ipBanBook:         ib,
 		metadataBook:      md,
+		// [Kroma: START]
+		historyBook: hb,
+		// [Kroma: END]
 	}, nil
 }
 
@@ -61,6 +74,9 @@ func (s *extendedStore) Close() error {
 	s.peerBanBook.Close()
 	s.ipBanBook.Close()
 	s.metadataBook.Close()
+	// [Kroma: START]
+	s.historyBook.Close()
+	// [Kroma: END]
 	return s.Peerstore.Close()
 }
 
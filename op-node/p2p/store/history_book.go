@@ -0,0 +1,192 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-base32"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	historyCacheSize        = 100
+	historyRecordExpiration = time.Hour * 24 * 7
+	// maxPeerHistoryEvents is the number of recent events kept per peer.
+	maxPeerHistoryEvents = 64
+	// maxRejectedPayloads is the number of recent rejected payload hashes kept per peer.
+	maxRejectedPayloads = 32
+)
+
+var historyBase = ds.NewKey("/peers/history")
+
+type PeerEventKind string
+
+const (
+	PeerEventValidGossip     PeerEventKind = "validGossip"
+	PeerEventIgnoredGossip   PeerEventKind = "ignoredGossip"
+	PeerEventInvalidGossip   PeerEventKind = "invalidGossip"
+	PeerEventValidResponse   PeerEventKind = "validResponse"
+	PeerEventErrorResponse   PeerEventKind = "errorResponse"
+	PeerEventRejectedPayload PeerEventKind = "rejectedPayload"
+)
+
+// PeerEvent is an event that affects the reputation of a peer.
+type PeerEvent struct {
+	Time int64         `json:"time"` // unix timestamp in seconds
+	Kind PeerEventKind `json:"kind"`
+	// Topic is the gossip topic of gossip events.
+	Topic string `json:"topic,omitempty"`
+	// Hash is the hash of the payload of rejected payload events.
+	Hash *common.Hash `json:"hash,omitempty"`
+}
+
+// PeerHistory counts the events of a peer, and keeps the recent events other than valid gossip and responses,
+// to explain the scores of the peer.
+type PeerHistory struct {
+	ValidGossip      uint64 `json:"validGossip"`
+	IgnoredGossip    uint64 `json:"ignoredGossip"`
+	InvalidGossip    uint64 `json:"invalidGossip"`
+	ValidResponses   uint64 `json:"validResponses"`
+	ErrorResponses   uint64 `json:"errorResponses"`
+	RejectedPayloads uint64 `json:"rejectedPayloads"`
+	// RejectedPayloadHashes are the hashes of the recent payloads of the peer that never became canonical.
+	RejectedPayloadHashes []common.Hash `json:"rejectedPayloadHashes"`
+	// Events are the recent events, oldest first.
+	Events []PeerEvent `json:"events"`
+}
+
+// Apply adds the event to the history of the peer.
+func (e PeerEvent) Apply(rec *historyRecord) {
+	h := &rec.PeerHistory
+	switch e.Kind {
+	case PeerEventValidGossip:
+		h.ValidGossip++
+		return
+	case PeerEventValidResponse:
+		h.ValidResponses++
+		return
+	case PeerEventIgnoredGossip:
+		h.IgnoredGossip++
+	case PeerEventInvalidGossip:
+		h.InvalidGossip++
+	case PeerEventErrorResponse:
+		h.ErrorResponses++
+	case PeerEventRejectedPayload:
+		h.RejectedPayloads++
+		if e.Hash != nil {
+			h.RejectedPayloadHashes = appendCapped(h.RejectedPayloadHashes, *e.Hash, maxRejectedPayloads)
+		}
+	}
+	h.Events = appendCapped(h.Events, e, maxPeerHistoryEvents)
+}
+
+func appendCapped[T any](s []T, v T, max int) []T {
+	s = append(s, v)
+	if len(s) > max {
+		s = s[len(s)-max:]
+	}
+	return s
+}
+
+// LastUpdate requires atomic update operations. Use the helper functions SetLastUpdated and LastUpdated to modify and access this field.
+type historyRecord struct {
+	LastUpdate  int64       `json:"lastUpdate"` // unix timestamp in seconds
+	PeerHistory PeerHistory `json:"peerHistory"`
+}
+
+func (r *historyRecord) SetLastUpdated(t time.Time) {
+	atomic.StoreInt64(&r.LastUpdate, t.Unix())
+}
+
+func (r *historyRecord) LastUpdated() time.Time {
+	return time.Unix(atomic.LoadInt64(&r.LastUpdate), 0)
+}
+
+func (r *historyRecord) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(r)
+}
+
+func (r *historyRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+type historyBook struct {
+	book *recordsBook[peer.ID, *historyRecord]
+}
+
+func newHistoryRecord() *historyRecord {
+	return new(historyRecord)
+}
+
+func newHistoryBook(ctx context.Context, logger log.Logger, clock clock.Clock, store ds.Batching) (*historyBook, error) {
+	book, err := newRecordsBook[peer.ID, *historyRecord](ctx, logger, clock, store, historyCacheSize, historyRecordExpiration, historyBase, newHistoryRecord, peerIDKey)
+	if err != nil {
+		return nil, err
+	}
+	return &historyBook{book: book}, nil
+}
+
+func (h *historyBook) startGC() {
+	h.book.startGC()
+}
+
+func (h *historyBook) RecordPeerEvent(id peer.ID, ev PeerEvent) (PeerHistory, error) {
+	if ev.Time == 0 {
+		ev.Time = h.book.clock.Now().Unix()
+	}
+	v, err := h.book.SetRecord(id, ev)
+	return v.PeerHistory, err
+}
+
+func (h *historyBook) GetPeerHistory(id peer.ID) (PeerHistory, error) {
+	record, err := h.book.GetRecord(id)
+	if err == UnknownRecordErr {
+		return PeerHistory{}, nil // return an empty history by default
+	}
+	if err != nil {
+		return PeerHistory{}, err
+	}
+	return record.PeerHistory, nil
+}
+
+func (h *historyBook) PeerHistories() (map[peer.ID]PeerHistory, error) {
+	h.book.RLock()
+	defer h.book.RUnlock()
+	results, err := h.book.store.Query(h.book.ctx, query.Query{Prefix: historyBase.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	out := make(map[peer.ID]PeerHistory)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		rawID, err := base32.RawStdEncoding.DecodeString(ds.RawKey(result.Key).BaseNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer history key %s: %w", result.Key, err)
+		}
+		rec := newHistoryRecord()
+		if err := rec.UnmarshalBinary(result.Value); err != nil {
+			return nil, fmt.Errorf("invalid peer history of key %s: %w", result.Key, err)
+		}
+		if h.book.hasExpired(rec) {
+			continue
+		}
+		out[peer.ID(rawID)] = rec.PeerHistory
+	}
+	return out, nil
+}
+
+func (h *historyBook) Close() {
+	h.book.Close()
+}
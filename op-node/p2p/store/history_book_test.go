@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestGetEmptyPeerHistory(t *testing.T) {
+	book, _ := createMemoryHistoryBook(t, ds.NewMapDatastore())
+	defer book.Close()
+	history, err := book.GetPeerHistory("a")
+	require.NoError(t, err)
+	require.Equal(t, PeerHistory{}, history)
+}
+
+func TestRecordPeerEvents(t *testing.T) {
+	book, _ := createMemoryHistoryBook(t, ds.NewMapDatastore())
+	defer book.Close()
+	id := peer.ID("aaaa")
+	hash := common.Hash{0x01}
+
+	_, err := book.RecordPeerEvent(id, PeerEvent{Kind: PeerEventValidGossip, Topic: "blocksV3"})
+	require.NoError(t, err)
+	_, err = book.RecordPeerEvent(id, PeerEvent{Kind: PeerEventInvalidGossip, Topic: "blocksV3"})
+	require.NoError(t, err)
+	history, err := book.RecordPeerEvent(id, PeerEvent{Kind: PeerEventRejectedPayload, Hash: &hash})
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(1), history.ValidGossip)
+	require.Equal(t, uint64(1), history.InvalidGossip)
+	require.Equal(t, uint64(1), history.RejectedPayloads)
+	require.Equal(t, []common.Hash{hash}, history.RejectedPayloadHashes)
+	// valid events are only counted
+	require.Len(t, history.Events, 2)
+	require.Equal(t, PeerEventInvalidGossip, history.Events[0].Kind)
+	require.Equal(t, int64(100), history.Events[0].Time)
+
+	stored, err := book.GetPeerHistory(id)
+	require.NoError(t, err)
+	require.Equal(t, history, stored)
+}
+
+func TestPeerHistoryEventsCapped(t *testing.T) {
+	book, _ := createMemoryHistoryBook(t, ds.NewMapDatastore())
+	defer book.Close()
+	id := peer.ID("aaaa")
+	for i := 0; i < maxPeerHistoryEvents+10; i++ {
+		_, err := book.RecordPeerEvent(id, PeerEvent{Time: int64(i + 1), Kind: PeerEventErrorResponse})
+		require.NoError(t, err)
+	}
+	history, err := book.GetPeerHistory(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(maxPeerHistoryEvents+10), history.ErrorResponses)
+	require.Len(t, history.Events, maxPeerHistoryEvents)
+	require.Equal(t, int64(11), history.Events[0].Time)
+}
+
+func TestPeerHistoriesPersisted(t *testing.T) {
+	store := ds.NewMapDatastore()
+	book, _ := createMemoryHistoryBook(t, store)
+	_, err := book.RecordPeerEvent("aaaa", PeerEvent{Kind: PeerEventIgnoredGossip})
+	require.NoError(t, err)
+	_, err = book.RecordPeerEvent("bbbb", PeerEvent{Kind: PeerEventErrorResponse})
+	require.NoError(t, err)
+	book.Close()
+
+	// the histories survive a restart
+	book, c := createMemoryHistoryBook(t, store)
+	defer book.Close()
+	histories, err := book.PeerHistories()
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	require.Equal(t, uint64(1), histories["aaaa"].IgnoredGossip)
+	require.Equal(t, uint64(1), histories["bbbb"].ErrorResponses)
+
+	// expired histories are not exported
+	c.AdvanceTime(historyRecordExpiration + time.Second)
+	histories, err = book.PeerHistories()
+	require.NoError(t, err)
+	require.Empty(t, histories)
+}
+
+func createMemoryHistoryBook(t *testing.T, store ds.Datastore) (*historyBook, *clock.DeterministicClock) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	c := clock.NewDeterministicClock(time.Unix(100, 0))
+	book, err := newHistoryBook(context.Background(), logger, c, sync.MutexWrap(store))
+	require.NoError(t, err)
+	return book, c
+}
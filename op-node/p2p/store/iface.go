@@ -127,6 +127,18 @@ type MetadataStore interface {
 	GetPeerMetadata(id peer.ID) (PeerMetadata, error)
 }
 
+// [Kroma: START]
+type PeerHistoryStore interface {
+	// RecordPeerEvent adds the event to the history of the specified peer
+	RecordPeerEvent(id peer.ID, ev PeerEvent) (PeerHistory, error)
+	// GetPeerHistory returns the history of the specified peer
+	GetPeerHistory(id peer.ID) (PeerHistory, error)
+	// PeerHistories returns the histories of all peers with recent events
+	PeerHistories() (map[peer.ID]PeerHistory, error)
+}
+
+// [Kroma: END]
+
 // ExtendedPeerstore defines a type-safe API to work with additional peer metadata based on a libp2p peerstore.Peerstore
 type ExtendedPeerstore interface {
 	peerstore.Peerstore
@@ -135,4 +147,7 @@ type ExtendedPeerstore interface {
 	PeerBanStore
 	IPBanStore
 	MetadataStore
+	// [Kroma: START]
+	PeerHistoryStore
+	// [Kroma: END]
 }
@@ -114,7 +114,12 @@ type SyncClientMetrics interface {
 type SyncPeerScorer interface {
 	onValidResponse(id peer.ID)
 	onResponseError(id peer.ID)
+	/* [Kroma: START]
 	onRejectedPayload(id peer.ID)
+	[Kroma: END] */
+	// [Kroma: START]
+	onRejectedPayload(id peer.ID, hash common.Hash)
+	// [Kroma: END]
 }
 
 // SyncClient implements a reverse chain sync with a minimal interface:
@@ -437,7 +442,12 @@ func (s *SyncClient) onQuarantineEvict(key common.Hash, value syncResult) {
 	if !s.trusted.Contains(key) {
 		s.log.Debug("evicting untrusted payload from quarantine", "id", value.payload.ExecutionPayload.ID(), "peer", value.peer)
 		// Down-score peer for having provided us a bad block that never turned out to be canonical
+		/* [Kroma: START]
 		s.appScorer.onRejectedPayload(value.peer)
+		[Kroma: END] */
+		// [Kroma: START]
+		s.appScorer.onRejectedPayload(value.peer, key)
+		// [Kroma: END]
 	} else {
 		s.log.Debug("evicting trusted payload from quarantine", "id", value.payload.ExecutionPayload.ID(), "peer", value.peer)
 	}
@@ -0,0 +1,401 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// SigningDomainPreconfirmationsV1 separates preconfirmation signatures from block and attestation signatures.
+var SigningDomainPreconfirmationsV1 = [32]byte{31: 2}
+
+const (
+	// preconfirmationSize is the size of an encoded preconfirmation: tx hash and L2 block number.
+	preconfirmationSize = 32 + 8
+	// signedPreconfirmationSize is the size of an encoded preconfirmation prefixed with its signature.
+	signedPreconfirmationSize = 65 + preconfirmationSize
+	// maxForwardedTxSize matches the max tx size the geth tx pool accepts.
+	maxForwardedTxSize = 128 * 1024
+	// maxForwardErrReasonSize limits the error reason a server writes back after a non-zero result code.
+	maxForwardErrReasonSize = 256
+	// Do not forward more than 100 txs per second into the tx pool
+	globalServerTxRateLimit rate.Limit = 100
+	// Allows a burst of 2x our rate limit
+	globalServerTxBurst = 200
+	// Do not accept more than 10 txs per second from the same peer, so a single RPC node cannot flood the tx pool
+	peerServerTxRateLimit rate.Limit = 10
+	// Allows a peer to forward a burst of 20 txs at once
+	peerServerTxBurst = 20
+)
+
+// Result codes of the forward-tx protocol. A non-zero result code is followed by a short error reason.
+const (
+	forwardTxResultOK byte = iota
+	forwardTxResultInvalidRequest
+	forwardTxResultRejected
+	forwardTxResultNotSequencing
+	forwardTxResultServerError
+)
+
+var (
+	ErrTxForwardingDisabled   = errors.New("tx forwarding is disabled")
+	ErrNoSequencerPeer        = errors.New("no sequencer peer to forward tx to")
+	ErrInvalidPreconfirmation = errors.New("invalid preconfirmation")
+
+	errNotSequencing = errors.New("sequencer is not active")
+)
+
+func ForwardTxProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/kroma/req/forward_tx/%d/0", l2ChainID))
+}
+
+// Preconfirmation is the promise of the sequencer to include a tx in the unsafe chain by an L2 block number.
+// The sequencer keeps the promise by forcing the tx into its blocks with the priority txs of its inclusion list.
+type Preconfirmation struct {
+	TxHash common.Hash `json:"txHash"`
+	// L2BlockNumber is the number of the block the tx is promised to be included by.
+	L2BlockNumber uint64 `json:"l2BlockNumber"`
+}
+
+// MarshalBinary encodes the preconfirmation as the tx hash and the big-endian L2 block number.
+func (p *Preconfirmation) MarshalBinary() ([]byte, error) {
+	out := make([]byte, preconfirmationSize)
+	copy(out[:32], p.TxHash[:])
+	binary.BigEndian.PutUint64(out[32:40], p.L2BlockNumber)
+	return out, nil
+}
+
+func (p *Preconfirmation) UnmarshalBinary(data []byte) error {
+	if len(data) != preconfirmationSize {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidPreconfirmation, preconfirmationSize, len(data))
+	}
+	copy(p.TxHash[:], data[:32])
+	p.L2BlockNumber = binary.BigEndian.Uint64(data[32:40])
+	return nil
+}
+
+// SignedPreconfirmation is a Preconfirmation signed with the p2p key of the sequencer.
+// The Sequencer field is not part of the encoding: it is recovered from the signature.
+type SignedPreconfirmation struct {
+	Preconfirmation
+	Signature hexutil.Bytes  `json:"signature"`
+	Sequencer common.Address `json:"sequencer"`
+}
+
+// RecoverSequencer recovers the address of the sequencer that signed the preconfirmation.
+func (p *SignedPreconfirmation) RecoverSequencer(chainID *big.Int) (common.Address, error) {
+	if len(p.Signature) != 65 {
+		return common.Address{}, fmt.Errorf("%w: expected 65 signature bytes, got %d", ErrInvalidPreconfirmation, len(p.Signature))
+	}
+	payload, err := p.Preconfirmation.MarshalBinary()
+	if err != nil {
+		return common.Address{}, err
+	}
+	signingHash, err := SigningHash(SigningDomainPreconfirmationsV1, chainID, payload)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(signingHash[:], p.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidPreconfirmation, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// SignPreconfirmation signs the preconfirmation with the given signer, which should hold the sequencer p2p key.
+func SignPreconfirmation(ctx context.Context, chainID *big.Int, signer Signer, preconf Preconfirmation) (*SignedPreconfirmation, error) {
+	payload, err := preconf.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(ctx, SigningDomainPreconfirmationsV1, chainID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign preconfirmation: %w", err)
+	}
+	signed := &SignedPreconfirmation{Preconfirmation: preconf, Signature: sig[:]}
+	if signed.Sequencer, err = signed.RecoverSequencer(chainID); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// forwardTxErr is the error a serving peer reports with a non-zero result code.
+type forwardTxErr struct {
+	code   byte
+	reason string
+}
+
+func (e *forwardTxErr) Error() string {
+	return fmt.Sprintf("peer failed to forward tx with code %d: %s", e.code, e.reason)
+}
+
+func (e *forwardTxErr) ResultCode() byte {
+	return e.code
+}
+
+// TxForwardingBackend is the sequencer side of tx forwarding: it checks the sequencer is active,
+// and queues forwarded txs to be forced into the blocks of the sequencer.
+type TxForwardingBackend interface {
+	SequencerActive(ctx context.Context) (bool, error)
+	// PreconfirmTransaction queues the tx and returns the number of the block it is promised to be included by.
+	PreconfirmTransaction(ctx context.Context, tx *types.Transaction) (uint64, error)
+}
+
+// TxForwardingServer accepts txs forwarded by other nodes, queues them in the inclusion list of the sequencer,
+// and answers with a preconfirmation signed with the p2p key of the sequencer.
+type TxForwardingServer struct {
+	cfg *rollup.Config
+
+	backend TxForwardingBackend
+	signer  Signer
+
+	peerRateLimits *simplelru.LRU[peer.ID, *peerStat]
+	peerStatsLock  sync.Mutex
+
+	globalRequestsRL *rate.Limiter
+}
+
+func NewTxForwardingServer(cfg *rollup.Config, backend TxForwardingBackend, signer Signer) *TxForwardingServer {
+	peerRateLimits, _ := simplelru.NewLRU[peer.ID, *peerStat](1000, nil)
+	globalRequestsRL := rate.NewLimiter(globalServerTxRateLimit, globalServerTxBurst)
+
+	return &TxForwardingServer{
+		cfg:              cfg,
+		backend:          backend,
+		signer:           signer,
+		peerRateLimits:   peerRateLimits,
+		globalRequestsRL: globalRequestsRL,
+	}
+}
+
+// Preconfirm queues the tx in the inclusion list of the sequencer and signs the promise
+// to include it by the block number the inclusion list commits to.
+func (srv *TxForwardingServer) Preconfirm(ctx context.Context, tx *types.Transaction) (*SignedPreconfirmation, error) {
+	active, err := srv.backend.SequencerActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check sequencer status: %w", err)
+	}
+	if !active {
+		return nil, errNotSequencing
+	}
+	number, err := srv.backend.PreconfirmTransaction(ctx, tx)
+	if err != nil {
+		return nil, &forwardTxErr{code: forwardTxResultRejected, reason: err.Error()}
+	}
+	return SignPreconfirmation(ctx, srv.cfg.L2ChainID, srv.signer, Preconfirmation{
+		TxHash:        tx.Hash(),
+		L2BlockNumber: number,
+	})
+}
+
+// HandleForwardTxRequest is a stream handler function to register the forward-tx protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// The caller must Close the stream.
+func (srv *TxForwardingServer) HandleForwardTxRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	defer cancel()
+	txHash, err := srv.handleForwardTxRequest(ctx, stream)
+	if err != nil {
+		log.Warn("failed to serve forwarded tx", "tx", txHash, "err", err)
+		resultCode := forwardTxResultServerError
+		var fwdErr *forwardTxErr
+		if errors.As(err, &fwdErr) {
+			resultCode = fwdErr.code
+		} else if errors.Is(err, invalidRequestErr) {
+			resultCode = forwardTxResultInvalidRequest
+		} else if errors.Is(err, errNotSequencing) {
+			resultCode = forwardTxResultNotSequencing
+		}
+		reason := err.Error()
+		if len(reason) > maxForwardErrReasonSize {
+			reason = reason[:maxForwardErrReasonSize]
+		}
+		// try to write error code and reason, so the other peer can understand the reason for failure.
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		_, _ = stream.Write(append([]byte{resultCode}, reason...))
+	} else {
+		log.Debug("successfully served forwarded tx", "tx", txHash)
+	}
+}
+
+func (srv *TxForwardingServer) handleForwardTxRequest(ctx context.Context, stream network.Stream) (common.Hash, error) {
+	peerId := stream.Conn().RemotePeer()
+
+	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
+		return common.Hash{}, fmt.Errorf("timed out waiting for global tx forwarding rate limit: %w", err)
+	}
+
+	srv.peerStatsLock.Lock()
+	ps, _ := srv.peerRateLimits.Get(peerId)
+	if ps == nil {
+		ps = &peerStat{
+			Requests: rate.NewLimiter(peerServerTxRateLimit, peerServerTxBurst),
+		}
+		srv.peerRateLimits.Add(peerId, ps)
+		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
+	}
+	srv.peerStatsLock.Unlock()
+	if err := ps.Requests.Wait(ctx); err != nil {
+		return common.Hash{}, fmt.Errorf("timed out waiting for peer tx forwarding rate limit: %w", err)
+	}
+
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	data, err := io.ReadAll(io.LimitReader(stream, maxForwardedTxSize+1))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read forwarded tx: %w", err)
+	}
+	if len(data) > maxForwardedTxSize {
+		return common.Hash{}, fmt.Errorf("forwarded tx exceeds %d bytes: %w", maxForwardedTxSize, invalidRequestErr)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to close reading-side of a tx forwarding call: %w", err)
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to decode forwarded tx: %w: %w", err, invalidRequestErr)
+	}
+	if tx.Type() == types.DepositTxType {
+		return tx.Hash(), fmt.Errorf("cannot forward deposit tx: %w", invalidRequestErr)
+	}
+
+	preconf, err := srv.Preconfirm(ctx, &tx)
+	if err != nil {
+		return tx.Hash(), err
+	}
+
+	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+	payload, err := preconf.Preconfirmation.MarshalBinary()
+	if err != nil {
+		return tx.Hash(), err
+	}
+	// 0 - resultCode: success = 0
+	// 1:66 - signature
+	// 66: - preconfirmation
+	out := make([]byte, 0, 1+signedPreconfirmationSize)
+	out = append(out, forwardTxResultOK)
+	out = append(out, preconf.Signature...)
+	out = append(out, payload...)
+	if _, err := stream.Write(out); err != nil {
+		return tx.Hash(), fmt.Errorf("failed to write preconfirmation: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// TxForwardingClient forwards txs to a connected sequencer peer,
+// and verifies the returned preconfirmation is signed by the sequencer of the rollup.
+type TxForwardingClient struct {
+	log    log.Logger
+	cfg    *rollup.Config
+	host   host.Host
+	runCfg GossipRuntimeConfig
+
+	forwardTx protocol.ID
+}
+
+func NewTxForwardingClient(log log.Logger, cfg *rollup.Config, h host.Host, runCfg GossipRuntimeConfig) *TxForwardingClient {
+	return &TxForwardingClient{
+		log:       log,
+		cfg:       cfg,
+		host:      h,
+		runCfg:    runCfg,
+		forwardTx: ForwardTxProtocolID(cfg.L2ChainID),
+	}
+}
+
+// ForwardTransaction forwards the tx to the connected peers that serve the forward-tx protocol, one at a time,
+// until one of them returns a valid preconfirmation. A tx rejected by the sequencer is not retried.
+func (c *TxForwardingClient) ForwardTransaction(ctx context.Context, tx *types.Transaction) (*SignedPreconfirmation, error) {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tx: %w", err)
+	}
+	var lastErr error
+	for _, id := range c.host.Network().Peers() {
+		if supported, err := c.host.Peerstore().SupportsProtocols(id, c.forwardTx); err != nil || len(supported) == 0 {
+			continue
+		}
+		preconf, err := c.forward(ctx, id, data, tx.Hash())
+		if err == nil {
+			return preconf, nil
+		}
+		var fwdErr *forwardTxErr
+		if errors.As(err, &fwdErr) && fwdErr.code == forwardTxResultRejected {
+			return nil, fmt.Errorf("sequencer rejected tx: %s", fwdErr.reason)
+		}
+		c.log.Warn("failed to forward tx to peer", "peer", id, "tx", tx.Hash(), "err", err)
+		lastErr = err
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoSequencerPeer, lastErr)
+	}
+	return nil, ErrNoSequencerPeer
+}
+
+func (c *TxForwardingClient) forward(ctx context.Context, id peer.ID, data []byte, txHash common.Hash) (*SignedPreconfirmation, error) {
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	defer reqCancel()
+	str, err := c.host.NewStream(reqCtx, id, c.forwardTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	if _, err := str.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write tx: %w", err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return nil, fmt.Errorf("failed to close writer side while forwarding tx: %w", err)
+	}
+
+	_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+	resp, err := io.ReadAll(io.LimitReader(str, 1+max(signedPreconfirmationSize, maxForwardErrReasonSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(resp) == 0 {
+		return nil, errors.New("empty response")
+	}
+	if resp[0] != forwardTxResultOK {
+		return nil, &forwardTxErr{code: resp[0], reason: string(resp[1:])}
+	}
+	if len(resp) != 1+signedPreconfirmationSize {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidPreconfirmation, signedPreconfirmationSize, len(resp)-1)
+	}
+	preconf := &SignedPreconfirmation{Signature: bytes.Clone(resp[1:66])}
+	if err := preconf.Preconfirmation.UnmarshalBinary(resp[66:]); err != nil {
+		return nil, err
+	}
+	if preconf.TxHash != txHash {
+		return nil, fmt.Errorf("%w: preconfirmed tx %s, expected %s", ErrInvalidPreconfirmation, preconf.TxHash, txHash)
+	}
+	if preconf.Sequencer, err = preconf.RecoverSequencer(c.cfg.L2ChainID); err != nil {
+		return nil, err
+	}
+	if expected := c.runCfg.P2PSequencerAddress(); preconf.Sequencer != expected {
+		return nil, fmt.Errorf("%w: signed by %s, expected sequencer %s", ErrInvalidPreconfirmation, preconf.Sequencer, expected)
+	}
+	return preconf, nil
+}
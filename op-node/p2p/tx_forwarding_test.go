@@ -0,0 +1,129 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type mockTxForwardingBackend struct {
+	active  bool
+	number  uint64
+	sendErr error
+	sent    []*types.Transaction
+}
+
+func (m *mockTxForwardingBackend) SequencerActive(_ context.Context) (bool, error) {
+	return m.active, nil
+}
+
+func (m *mockTxForwardingBackend) PreconfirmTransaction(_ context.Context, tx *types.Transaction) (uint64, error) {
+	if m.sendErr != nil {
+		return 0, m.sendErr
+	}
+	m.sent = append(m.sent, tx)
+	return m.number, nil
+}
+
+func TestPreconfirmationEncoding(t *testing.T) {
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	chainID := big.NewInt(100)
+
+	preconf := Preconfirmation{TxHash: common.Hash{0xaa}, L2BlockNumber: 42}
+	signed, err := SignPreconfirmation(context.Background(), chainID, NewLocalSigner(secrets.SequencerP2P), preconf)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey), signed.Sequencer)
+
+	data, err := preconf.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Preconfirmation
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, preconf, decoded)
+
+	// the signature commits to the chain ID
+	recovered, err := (&SignedPreconfirmation{Preconfirmation: decoded, Signature: signed.Signature}).RecoverSequencer(big.NewInt(101))
+	require.NoError(t, err)
+	require.NotEqual(t, signed.Sequencer, recovered)
+}
+
+func TestTxForwarding(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100)}
+	sequencer := crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the sequencer
+	backend := &mockTxForwardingBackend{active: true, number: 10}
+	srv := NewTxForwardingServer(cfg, backend, NewLocalSigner(secrets.SequencerP2P))
+	hostA.SetStreamHandler(ForwardTxProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, logger.New("role", "server"), srv.HandleForwardTxRequest))
+	// mocknet does not run the identify protocol, so register the supported protocol of the sequencer manually
+	require.NoError(t, hostB.Peerstore().AddProtocols(hostA.ID(), ForwardTxProtocolID(cfg.L2ChainID)))
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: cfg.L2ChainID, Nonce: 1, Gas: 21000})
+
+	t.Run("Preconfirmed", func(t *testing.T) {
+		cl := NewTxForwardingClient(logger.New("role", "client"), cfg, hostB, &testutils.MockRuntimeConfig{P2PSeqAddress: sequencer})
+		preconf, err := cl.ForwardTransaction(ctx, tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), preconf.TxHash)
+		require.Equal(t, uint64(10), preconf.L2BlockNumber)
+		require.Equal(t, sequencer, preconf.Sequencer)
+		require.Len(t, backend.sent, 1)
+		require.Equal(t, tx.Hash(), backend.sent[0].Hash())
+	})
+
+	t.Run("UnexpectedSequencer", func(t *testing.T) {
+		cl := NewTxForwardingClient(logger.New("role", "client"), cfg, hostB, &testutils.MockRuntimeConfig{P2PSeqAddress: common.Address{0x42}})
+		_, err := cl.ForwardTransaction(ctx, tx)
+		require.ErrorIs(t, err, ErrNoSequencerPeer)
+		require.ErrorIs(t, err, ErrInvalidPreconfirmation)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		backend.sendErr = errors.New("nonce too low")
+		defer func() { backend.sendErr = nil }()
+		cl := NewTxForwardingClient(logger.New("role", "client"), cfg, hostB, &testutils.MockRuntimeConfig{P2PSeqAddress: sequencer})
+		_, err := cl.ForwardTransaction(ctx, tx)
+		require.ErrorContains(t, err, "nonce too low")
+		require.NotErrorIs(t, err, ErrNoSequencerPeer)
+	})
+
+	t.Run("NotSequencing", func(t *testing.T) {
+		backend.active = false
+		defer func() { backend.active = true }()
+		cl := NewTxForwardingClient(logger.New("role", "client"), cfg, hostB, &testutils.MockRuntimeConfig{P2PSeqAddress: sequencer})
+		_, err := cl.ForwardTransaction(ctx, tx)
+		require.ErrorIs(t, err, ErrNoSequencerPeer)
+	})
+
+	t.Run("NoSequencerPeer", func(t *testing.T) {
+		cl := NewTxForwardingClient(logger.New("role", "client"), cfg, hostA, &testutils.MockRuntimeConfig{P2PSeqAddress: sequencer})
+		_, err := cl.ForwardTransaction(ctx, tx)
+		require.ErrorIs(t, err, ErrNoSequencerPeer)
+	})
+}
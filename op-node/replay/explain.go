@@ -0,0 +1,136 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Explain decodes the differences between the attributes derived from L1 and the live L2 block.
+// Every line names a field, with the derived value first and the live value second.
+func Explain(cfg *rollup.Config, attrs *eth.PayloadAttributes, envelope *eth.ExecutionPayloadEnvelope) []string {
+	block := envelope.ExecutionPayload
+	var out []string
+	diff := func(field string, derived, live any) {
+		if d, l := fmt.Sprint(derived), fmt.Sprint(live); d != l {
+			out = append(out, fmt.Sprintf("%s: derived %s, live %s", field, d, l))
+		}
+	}
+	diff("timestamp", uint64(attrs.Timestamp), uint64(block.Timestamp))
+	diff("prev randao", attrs.PrevRandao, block.PrevRandao)
+	diff("fee recipient", attrs.SuggestedFeeRecipient, block.FeeRecipient)
+	if attrs.GasLimit != nil {
+		diff("gas limit", uint64(*attrs.GasLimit), uint64(block.GasLimit))
+	}
+	if attrs.ParentBeaconBlockRoot != nil || envelope.ParentBeaconBlockRoot != nil {
+		diff("parent beacon block root", attrs.ParentBeaconBlockRoot, envelope.ParentBeaconBlockRoot)
+	}
+	if len(attrs.Transactions) != len(block.Transactions) {
+		diff("transaction count", len(attrs.Transactions), len(block.Transactions))
+		diff("deposit count", countDeposits(attrs.Transactions), countDeposits(block.Transactions))
+	}
+	for i := 0; i < len(attrs.Transactions) && i < len(block.Transactions); i++ {
+		if !bytes.Equal(attrs.Transactions[i], block.Transactions[i]) {
+			out = append(out, explainTx(cfg, i, uint64(block.Timestamp), attrs.Transactions[i], block.Transactions[i])...)
+			// later txs usually differ as a consequence of the first difference
+			break
+		}
+	}
+	return out
+}
+
+func countDeposits(txs []eth.Data) int {
+	count := 0
+	for _, tx := range txs {
+		if len(tx) > 0 && tx[0] == types.DepositTxType {
+			count++
+		}
+	}
+	return count
+}
+
+func explainTx(cfg *rollup.Config, i int, l2Time uint64, derived, live eth.Data) []string {
+	prefix := fmt.Sprintf("transaction %d", i)
+	var derivedTx, liveTx types.Transaction
+	if err := derivedTx.UnmarshalBinary(derived); err != nil {
+		return []string{fmt.Sprintf("%s: failed to decode derived tx: %v", prefix, err)}
+	}
+	if err := liveTx.UnmarshalBinary(live); err != nil {
+		return []string{fmt.Sprintf("%s: failed to decode live tx: %v", prefix, err)}
+	}
+	if derivedTx.Type() != liveTx.Type() {
+		return []string{fmt.Sprintf("%s type: derived %d, live %d", prefix, derivedTx.Type(), liveTx.Type())}
+	}
+	if derivedTx.Type() != types.DepositTxType {
+		signer := types.LatestSignerForChainID(cfg.L2ChainID)
+		derivedFrom, _ := types.Sender(signer, &derivedTx)
+		liveFrom, _ := types.Sender(signer, &liveTx)
+		return []string{fmt.Sprintf("%s: derived %s (from %s, nonce %d), live %s (from %s, nonce %d)", prefix,
+			derivedTx.Hash(), derivedFrom, derivedTx.Nonce(), liveTx.Hash(), liveFrom, liveTx.Nonce())}
+	}
+	if i == 0 {
+		return explainL1InfoTx(cfg, l2Time, derivedTx.Data(), liveTx.Data())
+	}
+
+	var derivedDep, liveDep types.DepositTx
+	if err := rlp.DecodeBytes(derived[1:], &derivedDep); err != nil {
+		return []string{fmt.Sprintf("%s: failed to decode derived deposit: %v", prefix, err)}
+	}
+	if err := rlp.DecodeBytes(live[1:], &liveDep); err != nil {
+		return []string{fmt.Sprintf("%s: failed to decode live deposit: %v", prefix, err)}
+	}
+	return diffFields(prefix+" deposit", derivedDep, liveDep)
+}
+
+// explainL1InfoTx decodes the L1 info deposits, the first tx of every L2 block.
+func explainL1InfoTx(cfg *rollup.Config, l2Time uint64, derived, live []byte) []string {
+	if len(derived) < 4 || len(live) < 4 {
+		return []string{fmt.Sprintf("L1 info tx data length: derived %d, live %d", len(derived), len(live))}
+	}
+	if !bytes.Equal(derived[:4], live[:4]) {
+		return []string{fmt.Sprintf("L1 info tx encoding: derived %s, live %s", l1InfoEncoding(derived), l1InfoEncoding(live))}
+	}
+	derivedInfo, err := derive.L1BlockInfoFromBytes(cfg, l2Time, derived)
+	if err != nil {
+		return []string{fmt.Sprintf("L1 info tx: failed to decode derived %s encoding: %v", l1InfoEncoding(derived), err)}
+	}
+	liveInfo, err := derive.L1BlockInfoFromBytes(cfg, l2Time, live)
+	if err != nil {
+		return []string{fmt.Sprintf("L1 info tx: failed to decode live %s encoding: %v", l1InfoEncoding(live), err)}
+	}
+	if out := diffFields("L1 info", *derivedInfo, *liveInfo); len(out) > 0 {
+		return out
+	}
+	return []string{fmt.Sprintf("L1 info tx: same %s encoded values, but different tx data", l1InfoEncoding(derived))}
+}
+
+func l1InfoEncoding(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, derive.L1InfoFuncBedrockBytes4):
+		return "bedrock"
+	case bytes.HasPrefix(data, derive.L1InfoFuncEcotoneBytes4):
+		return "ecotone"
+	default:
+		return fmt.Sprintf("unknown (selector %x)", data[:4])
+	}
+}
+
+// diffFields lists the fields of the two structs of the same type that differ.
+func diffFields(prefix string, derived, live any) []string {
+	var out []string
+	dv, lv := reflect.ValueOf(derived), reflect.ValueOf(live)
+	for i := 0; i < dv.NumField(); i++ {
+		d, l := fmt.Sprint(dv.Field(i).Interface()), fmt.Sprint(lv.Field(i).Interface())
+		if d != l {
+			out = append(out, fmt.Sprintf("%s %s: derived %s, live %s", prefix, dv.Type().Field(i).Name, d, l))
+		}
+	}
+	return out
+}
@@ -0,0 +1,298 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const blockFileSuffix = ".json"
+
+// blockRecord is the recorded L1 data of a block that derivation uses.
+type blockRecord struct {
+	Header       hexutil.Bytes   `json:"header"`
+	Transactions []hexutil.Bytes `json:"transactions"`
+	// Receipts is nil if the receipts were not fetched yet.
+	Receipts types.Receipts `json:"receipts"`
+}
+
+func (r *blockRecord) info() (eth.BlockInfo, error) {
+	var header types.Header
+	if err := rlp.DecodeBytes(r.Header, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode header: %w", err)
+	}
+	return eth.HeaderBlockInfo(&header), nil
+}
+
+func (r *blockRecord) txs() (types.Transactions, error) {
+	txs := make(types.Transactions, len(r.Transactions))
+	for i, data := range r.Transactions {
+		txs[i] = new(types.Transaction)
+		if err := txs[i].UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("failed to decode tx %d: %w", i, err)
+		}
+	}
+	return txs, nil
+}
+
+// L1Recorder serves the L1 data of derivation from a directory of recorded L1 blocks.
+// With a live L1 source, it fetches the blocks that were not recorded yet and records them,
+// so a replay can be repeated without an L1 node.
+type L1Recorder struct {
+	dir  string
+	live derive.L1Fetcher
+
+	mu       sync.Mutex
+	byNumber map[uint64]common.Hash
+	byHash   map[common.Hash]uint64
+}
+
+var _ derive.L1Fetcher = (*L1Recorder)(nil)
+
+// NewL1Recorder opens the recorded L1 blocks in dir. The live source may be nil, to only use the recorded blocks.
+func NewL1Recorder(dir string, live derive.L1Fetcher) (*L1Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create L1 record dir: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read L1 record dir: %w", err)
+	}
+	r := &L1Recorder{
+		dir:      dir,
+		live:     live,
+		byNumber: make(map[uint64]common.Hash),
+		byHash:   make(map[common.Hash]uint64),
+	}
+	for _, entry := range entries {
+		// block files are named <number>-<hash>.json
+		name, ok := strings.CutSuffix(entry.Name(), blockFileSuffix)
+		if !ok {
+			continue
+		}
+		numStr, hashStr, ok := strings.Cut(name, "-")
+		if !ok {
+			continue
+		}
+		num, err := strconv.ParseUint(numStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		hash := common.HexToHash(hashStr)
+		r.byNumber[num] = hash
+		r.byHash[hash] = num
+	}
+	return r, nil
+}
+
+func (r *L1Recorder) blockPath(num uint64, hash common.Hash) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%d-%s%s", num, hash, blockFileSuffix))
+}
+
+// block returns the record of the block, and records it from the live source if it was not recorded yet.
+func (r *L1Recorder) block(ctx context.Context, hash common.Hash) (*blockRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if num, ok := r.byHash[hash]; ok {
+		return jsonutil.LoadJSON[blockRecord](r.blockPath(num, hash))
+	}
+	if r.live == nil {
+		return nil, fmt.Errorf("L1 block %s was not recorded: %w", hash, ethereum.NotFound)
+	}
+	info, txs, err := r.live.InfoAndTxsByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := info.HeaderRLP()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode header of L1 block %s: %w", hash, err)
+	}
+	rec := &blockRecord{Header: header, Transactions: make([]hexutil.Bytes, len(txs))}
+	for i, tx := range txs {
+		if rec.Transactions[i], err = tx.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("failed to encode tx %d of L1 block %s: %w", i, hash, err)
+		}
+	}
+	if err := r.store(info.NumberU64(), hash, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// store writes the record. The caller must hold the lock.
+func (r *L1Recorder) store(num uint64, hash common.Hash, rec *blockRecord) error {
+	if err := jsonutil.WriteJSON(r.blockPath(num, hash), rec, 0o644); err != nil {
+		return fmt.Errorf("failed to record L1 block %s: %w", hash, err)
+	}
+	r.byNumber[num] = hash
+	r.byHash[hash] = num
+	return nil
+}
+
+func (r *L1Recorder) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	if r.live != nil {
+		return r.live.L1BlockRefByLabel(ctx, label)
+	}
+	// without live source, every recorded block is final
+	r.mu.Lock()
+	var head uint64
+	found := false
+	for num := range r.byNumber {
+		if num >= head {
+			head, found = num, true
+		}
+	}
+	r.mu.Unlock()
+	if !found {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return r.L1BlockRefByNumber(ctx, head)
+}
+
+func (r *L1Recorder) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	r.mu.Lock()
+	hash, ok := r.byNumber[num]
+	r.mu.Unlock()
+	if !ok {
+		if r.live == nil {
+			return eth.L1BlockRef{}, ethereum.NotFound
+		}
+		ref, err := r.live.L1BlockRefByNumber(ctx, num)
+		if err != nil {
+			return eth.L1BlockRef{}, err
+		}
+		hash = ref.Hash
+	}
+	return r.L1BlockRefByHash(ctx, hash)
+}
+
+func (r *L1Recorder) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	info, err := r.InfoByHash(ctx, hash)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	return eth.InfoToL1BlockRef(info), nil
+}
+
+func (r *L1Recorder) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	rec, err := r.block(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return rec.info()
+}
+
+func (r *L1Recorder) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	rec, err := r.block(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := rec.info()
+	if err != nil {
+		return nil, nil, err
+	}
+	txs, err := rec.txs()
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, txs, nil
+}
+
+func (r *L1Recorder) FetchReceipts(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	rec, err := r.block(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := rec.info()
+	if err != nil {
+		return nil, nil, err
+	}
+	if rec.Receipts != nil {
+		return info, rec.Receipts, nil
+	}
+	if r.live == nil {
+		return nil, nil, fmt.Errorf("receipts of L1 block %s were not recorded: %w", hash, ethereum.NotFound)
+	}
+	_, receipts, err := r.live.FetchReceipts(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	rec.Receipts = receipts
+	if rec.Receipts == nil {
+		rec.Receipts = types.Receipts{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.store(info.NumberU64(), hash, rec); err != nil {
+		return nil, nil, err
+	}
+	return info, receipts, nil
+}
+
+// BlobRecorder serves blobs from a directory of recorded blobs, and records the blobs it fetches
+// from the live source, if any.
+type BlobRecorder struct {
+	dir  string
+	live derive.L1BlobsFetcher
+}
+
+var _ derive.L1BlobsFetcher = (*BlobRecorder)(nil)
+
+// NewBlobRecorder opens the recorded blobs in dir. The live source may be nil, to only use the recorded blobs.
+func NewBlobRecorder(dir string, live derive.L1BlobsFetcher) (*BlobRecorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob record dir: %w", err)
+	}
+	return &BlobRecorder{dir: dir, live: live}, nil
+}
+
+func (r *BlobRecorder) blobPath(hash common.Hash) string {
+	return filepath.Join(r.dir, hash.String()+".blob")
+}
+
+func (r *BlobRecorder) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, len(hashes))
+	recorded := true
+	for i, h := range hashes {
+		data, err := os.ReadFile(r.blobPath(h.Hash))
+		if err != nil {
+			recorded = false
+			break
+		}
+		blobs[i] = new(eth.Blob)
+		if len(data) != len(blobs[i]) {
+			return nil, fmt.Errorf("invalid recorded blob %s: length %d", h.Hash, len(data))
+		}
+		copy(blobs[i][:], data)
+	}
+	if recorded {
+		return blobs, nil
+	}
+	if r.live == nil {
+		return nil, fmt.Errorf("blobs of L1 block %s were not recorded: %w", ref, ethereum.NotFound)
+	}
+	blobs, err := r.live.GetBlobs(ctx, ref, hashes)
+	if err != nil {
+		return nil, err
+	}
+	for i, blob := range blobs {
+		if err := os.WriteFile(r.blobPath(hashes[i].Hash), blob[:], 0o644); err != nil {
+			return nil, fmt.Errorf("failed to record blob %s: %w", hashes[i].Hash, err)
+		}
+	}
+	return blobs, nil
+}
@@ -0,0 +1,234 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// maxTemporaryErrors is the number of consecutive temporary derivation errors after which the replay gives up.
+const maxTemporaryErrors = 10
+
+// errL1Exhausted is returned when the pipeline needs L1 data past the end of the replayed L1 range.
+var errL1Exhausted = errors.New("L1 range exhausted")
+
+// Divergence is the first L2 block that does not match the attributes derived from L1.
+type Divergence struct {
+	// Block is the live L2 block that does not match.
+	Block eth.L2BlockRef `json:"block"`
+	// Reason is the first mismatch between the derived attributes and the live block.
+	Reason string `json:"reason"`
+	// Explanation decodes the differences between the derived attributes and the live block.
+	Explanation []string `json:"explanation"`
+}
+
+// Result is the outcome of a replay.
+type Result struct {
+	// Matched is the number of L2 blocks that match the derived attributes.
+	Matched uint64 `json:"matched"`
+	// LastMatched is the last L2 block that matches the derived attributes, or the parent of the range if none matched.
+	LastMatched eth.L2BlockRef `json:"lastMatched"`
+	// L1Origin is the L1 block the pipeline traversed to.
+	L1Origin eth.L1BlockRef `json:"l1Origin"`
+	// L1Exhausted is true if the L1 range ended before all L2 blocks of the range could be derived.
+	L1Exhausted bool `json:"l1Exhausted"`
+	// Divergence is nil if all derived L2 blocks match.
+	Divergence *Divergence `json:"divergence,omitempty"`
+}
+
+// Replayer re-derives a range of L2 blocks from L1, without an execution engine:
+// the parent of each derived block is the live L2 block, so every block is checked on its own
+// against the attributes the derivation pipeline produces.
+type Replayer struct {
+	log     log.Logger
+	cfg     *rollup.Config
+	l1      derive.L1Fetcher
+	l1Blobs derive.L1BlobsFetcher
+	l2      derive.L2Source
+}
+
+func NewReplayer(log log.Logger, cfg *rollup.Config, l1 derive.L1Fetcher, l1Blobs derive.L1BlobsFetcher, l2 derive.L2Source) *Replayer {
+	return &Replayer{
+		log:     log,
+		cfg:     cfg,
+		l1:      l1,
+		l1Blobs: l1Blobs,
+		l2:      l2,
+	}
+}
+
+// pipeline is the derivation pipeline up to the attributes queue.
+type pipeline struct {
+	traversal  *derive.L1Traversal
+	attributes *derive.AttributesQueue
+	stages     []derive.ResettableStage
+}
+
+func (r *Replayer) newPipeline(l1 *boundedL1) *pipeline {
+	l1Traversal := derive.NewL1Traversal(r.log, r.cfg, l1)
+	dataSrc := derive.NewDataSourceFactory(r.log, r.cfg, l1, r.l1Blobs, plasma.Disabled)
+	l1Src := derive.NewL1Retrieval(r.log, dataSrc, l1Traversal)
+	frameQueue := derive.NewFrameQueue(r.log, l1Src)
+	bank := derive.NewChannelBank(r.log, r.cfg, frameQueue, l1, metrics.NoopMetrics)
+	chInReader := derive.NewChannelInReader(r.cfg, r.log, bank, metrics.NoopMetrics)
+	batchQueue := derive.NewBatchQueue(r.log, r.cfg, chInReader, r.l2)
+	attrBuilder := derive.NewFetchingAttributesBuilder(r.cfg, l1, r.l2)
+	attributesQueue := derive.NewAttributesQueue(r.log, r.cfg, attrBuilder, batchQueue)
+	return &pipeline{
+		traversal:  l1Traversal,
+		attributes: attributesQueue,
+		stages:     []derive.ResettableStage{l1Traversal, l1Src, frameQueue, bank, chInReader, batchQueue, attributesQueue},
+	}
+}
+
+// Run re-derives the L2 blocks start to end (inclusive), and compares them with the live L2 blocks.
+// It stops at the first divergence. If l1End is not zero, the pipeline does not traverse past L1 block l1End.
+func (r *Replayer) Run(ctx context.Context, start, end, l1End uint64) (*Result, error) {
+	if r.cfg.UsePlasma {
+		return nil, errors.New("replay of plasma chains is not supported")
+	}
+	if start <= r.cfg.Genesis.L2.Number {
+		return nil, fmt.Errorf("start block %d must be after the L2 genesis block %d", start, r.cfg.Genesis.L2.Number)
+	}
+	if end < start {
+		return nil, fmt.Errorf("end block %d is before start block %d", end, start)
+	}
+	parent, err := r.l2.L2BlockRefByNumber(ctx, start-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch parent L2 block %d: %w", start-1, err)
+	}
+	origin, sysCfg, err := r.pipelineOrigin(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	if l1End != 0 && origin.Number > l1End {
+		return nil, fmt.Errorf("derivation of L2 block %d starts at L1 block %d, after the end of the L1 range %d", start, origin.Number, l1End)
+	}
+	r.log.Info("Replaying derivation", "start", start, "end", end, "parent", parent, "l1_origin", origin)
+
+	p := r.newPipeline(&boundedL1{L1Fetcher: r.l1, end: l1End})
+	for _, stage := range p.stages {
+		if err := stage.Reset(ctx, origin, sysCfg); err != io.EOF {
+			return nil, fmt.Errorf("failed to reset derivation stage: %w", err)
+		}
+	}
+
+	result := &Result{LastMatched: parent}
+	for num := start; num <= end; num++ {
+		attrs, err := r.nextAttributes(ctx, p, parent)
+		result.L1Origin = p.traversal.Origin()
+		if errors.Is(err, errL1Exhausted) {
+			result.L1Exhausted = true
+			return result, nil
+		} else if err != nil {
+			return result, fmt.Errorf("failed to derive L2 block %d: %w", num, err)
+		}
+		envelope, err := r.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			return result, fmt.Errorf("failed to fetch live L2 block %d: %w", num, err)
+		}
+		ref, err := derive.PayloadToBlockRef(r.cfg, envelope.ExecutionPayload)
+		if err != nil {
+			return result, fmt.Errorf("failed to decode live L2 block %d: %w", num, err)
+		}
+		if err := derive.AttributesMatchBlock(r.cfg, attrs.Attributes(), parent.Hash, envelope, r.log); err != nil {
+			result.Divergence = &Divergence{
+				Block:       ref,
+				Reason:      err.Error(),
+				Explanation: Explain(r.cfg, attrs.Attributes(), envelope),
+			}
+			return result, nil
+		}
+		r.log.Debug("Derived block matches", "block", ref)
+		result.Matched++
+		result.LastMatched = ref
+		parent = ref
+	}
+	return result, nil
+}
+
+// pipelineOrigin walks back the L2 chain from the parent to the L1 block that is old enough to
+// start buffering channel data from, like the engine queue does when the pipeline is reset.
+func (r *Replayer) pipelineOrigin(ctx context.Context, parent eth.L2BlockRef) (eth.L1BlockRef, eth.SystemConfig, error) {
+	l1Origin, err := r.l1.L1BlockRefByHash(ctx, parent.L1Origin.Hash)
+	if err != nil {
+		return eth.L1BlockRef{}, eth.SystemConfig{}, fmt.Errorf("failed to fetch L1 origin %s of parent block: %w", parent.L1Origin, err)
+	}
+	pipelineL2 := parent
+	for {
+		afterL2Genesis := pipelineL2.Number > r.cfg.Genesis.L2.Number
+		afterL1Genesis := pipelineL2.L1Origin.Number > r.cfg.Genesis.L1.Number
+		afterChannelTimeout := pipelineL2.L1Origin.Number+r.cfg.ChannelTimeout > l1Origin.Number
+		if !afterL2Genesis || !afterL1Genesis || !afterChannelTimeout {
+			break
+		}
+		pipelineL2, err = r.l2.L2BlockRefByHash(ctx, pipelineL2.ParentHash)
+		if err != nil {
+			return eth.L1BlockRef{}, eth.SystemConfig{}, fmt.Errorf("failed to fetch L2 block %s: %w", pipelineL2.ParentID(), err)
+		}
+	}
+	origin, err := r.l1.L1BlockRefByHash(ctx, pipelineL2.L1Origin.Hash)
+	if err != nil {
+		return eth.L1BlockRef{}, eth.SystemConfig{}, fmt.Errorf("failed to fetch pipeline origin %s: %w", pipelineL2.L1Origin, err)
+	}
+	sysCfg, err := r.l2.SystemConfigByL2Hash(ctx, pipelineL2.Hash)
+	if err != nil {
+		return eth.L1BlockRef{}, eth.SystemConfig{}, fmt.Errorf("failed to fetch system config of L2 block %s: %w", pipelineL2.ID(), err)
+	}
+	return origin, sysCfg, nil
+}
+
+// nextAttributes steps the pipeline until it produces the attributes of the next L2 block on top of parent.
+func (r *Replayer) nextAttributes(ctx context.Context, p *pipeline, parent eth.L2BlockRef) (*derive.AttributesWithParent, error) {
+	temporaryErrs := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		attrs, err := p.attributes.NextAttributes(ctx, parent)
+		if err == nil {
+			return attrs, nil
+		}
+		if err == io.EOF {
+			if err = p.traversal.AdvanceL1Block(ctx); err == io.EOF {
+				return nil, errL1Exhausted
+			}
+		}
+		switch {
+		case err == nil, errors.Is(err, derive.NotEnoughData):
+			temporaryErrs = 0
+		case errors.Is(err, derive.ErrTemporary):
+			temporaryErrs++
+			if temporaryErrs >= maxTemporaryErrors {
+				return nil, err
+			}
+			r.log.Warn("Temporary derivation error", "err", err)
+		default:
+			return nil, err
+		}
+	}
+}
+
+// boundedL1 hides the L1 blocks after end from the L1 traversal.
+type boundedL1 struct {
+	derive.L1Fetcher
+	// end is the last L1 block to traverse, or zero if the L1 chain is not bounded.
+	end uint64
+}
+
+func (b *boundedL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	if b.end != 0 && num > b.end {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return b.L1Fetcher.L1BlockRefByNumber(ctx, num)
+}
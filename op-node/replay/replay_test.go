@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func l1InfoTx(t *testing.T, cfg *rollup.Config, info eth.BlockInfo, l2Time uint64) eth.Data {
+	tx, err := derive.L1InfoDepositBytes(cfg, eth.SystemConfig{}, 0, info, l2Time)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestExplain(t *testing.T) {
+	zero := uint64(0)
+	cfg := &rollup.Config{BlockTime: 2, L2ChainID: big.NewInt(901), RegolithTime: &zero, EcotoneTime: &zero}
+	rng := rand.New(rand.NewSource(1234))
+	info := testutils.RandomBlockInfo(rng)
+	other := *info
+	other.InfoBaseFee = new(big.Int).Add(info.InfoBaseFee, common.Big1)
+
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	attrs := &eth.PayloadAttributes{
+		Timestamp:    100,
+		GasLimit:     &gasLimit,
+		Transactions: []eth.Data{l1InfoTx(t, cfg, info, 100)},
+	}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		Timestamp:    102,
+		GasLimit:     gasLimit,
+		Transactions: []eth.Data{l1InfoTx(t, cfg, &other, 100)},
+	}}
+	require.Equal(t, []string{
+		"timestamp: derived 100, live 102",
+		"L1 info BaseFee: derived " + info.InfoBaseFee.String() + ", live " + other.InfoBaseFee.String(),
+	}, Explain(cfg, attrs, envelope))
+
+	// a missing user tx
+	userTx := types.NewTx(&types.LegacyTx{Nonce: 3, Gas: 21000, GasPrice: common.Big1})
+	data, err := userTx.MarshalBinary()
+	require.NoError(t, err)
+	envelope.ExecutionPayload.Timestamp = 100
+	envelope.ExecutionPayload.Transactions = []eth.Data{attrs.Transactions[0], data}
+	require.Equal(t, []string{"transaction count: derived 1, live 2"}, Explain(cfg, attrs, envelope))
+}
+
+func TestExplainL1InfoEncoding(t *testing.T) {
+	zero, ecotone := uint64(0), uint64(10)
+	bedrockCfg := &rollup.Config{BlockTime: 2, RegolithTime: &zero}
+	ecotoneCfg := &rollup.Config{BlockTime: 2, RegolithTime: &zero, EcotoneTime: &ecotone}
+	info := testutils.RandomBlockInfo(rand.New(rand.NewSource(1234)))
+
+	// the live node did not activate Ecotone yet
+	attrs := &eth.PayloadAttributes{Timestamp: 20, Transactions: []eth.Data{l1InfoTx(t, ecotoneCfg, info, 20)}}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		Timestamp:    20,
+		Transactions: []eth.Data{l1InfoTx(t, bedrockCfg, info, 20)},
+	}}
+	require.Equal(t, []string{"L1 info tx encoding: derived ecotone, live bedrock"}, Explain(ecotoneCfg, attrs, envelope))
+}
+
+type fakeL1 struct {
+	derive.L1Fetcher
+	block    *types.Block
+	receipts types.Receipts
+}
+
+func (f *fakeL1) L1BlockRefByNumber(_ context.Context, num uint64) (eth.L1BlockRef, error) {
+	if num != f.block.NumberU64() {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return eth.InfoToL1BlockRef(eth.BlockToInfo(f.block)), nil
+}
+
+func (f *fakeL1) InfoAndTxsByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	if hash != f.block.Hash() {
+		return nil, nil, ethereum.NotFound
+	}
+	return eth.BlockToInfo(f.block), f.block.Transactions(), nil
+}
+
+func (f *fakeL1) FetchReceipts(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	info, _, err := f.InfoAndTxsByHash(ctx, hash)
+	return info, f.receipts, err
+}
+
+func TestL1Recorder(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	block, receipts := testutils.RandomBlock(rand.New(rand.NewSource(1234)), 3)
+	live := &fakeL1{block: block, receipts: receipts}
+
+	recorder, err := NewL1Recorder(dir, live)
+	require.NoError(t, err)
+	ref, err := recorder.L1BlockRefByNumber(ctx, block.NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, block.Hash(), ref.Hash)
+	_, _, err = recorder.FetchReceipts(ctx, block.Hash())
+	require.NoError(t, err)
+
+	// replay from the recorded data only
+	offline, err := NewL1Recorder(dir, nil)
+	require.NoError(t, err)
+	ref, err = offline.L1BlockRefByNumber(ctx, block.NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, eth.InfoToL1BlockRef(eth.BlockToInfo(block)), ref)
+	head, err := offline.L1BlockRefByLabel(ctx, eth.Finalized)
+	require.NoError(t, err)
+	require.Equal(t, ref, head)
+
+	info, txs, err := offline.InfoAndTxsByHash(ctx, block.Hash())
+	require.NoError(t, err)
+	require.Equal(t, block.Hash(), info.Hash())
+	require.Len(t, txs, len(block.Transactions()))
+	for i, tx := range txs {
+		require.Equal(t, block.Transactions()[i].Hash(), tx.Hash())
+	}
+	_, recorded, err := offline.FetchReceipts(ctx, block.Hash())
+	require.NoError(t, err)
+	require.Len(t, recorded, len(receipts))
+	for i, rec := range recorded {
+		require.Equal(t, receipts[i].TxHash, rec.TxHash)
+		require.Equal(t, receipts[i].Logs, rec.Logs)
+	}
+
+	_, err = offline.L1BlockRefByNumber(ctx, block.NumberU64()+1)
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
@@ -27,6 +27,10 @@ type FetchingAttributesBuilder struct {
 	rollupCfg *rollup.Config
 	l1        L1ReceiptsFetcher
 	l2        SystemConfigL2Fetcher
+
+	// [Kroma: START]
+	inclusionList InclusionList
+	// [Kroma: END]
 }
 
 func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 SystemConfigL2Fetcher) *FetchingAttributesBuilder {
@@ -117,6 +121,23 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 	txs = append(txs, depositTxs...)
 	txs = append(txs, upgradeTxs...)
 
+	// [Kroma: START]
+	// Txs of the inclusion list are sequencer txs, so they are not included in blocks that must not have any,
+	// i.e. the blocks beyond the sequencer drift and the upgrade blocks.
+	if ba.inclusionList != nil && nextL2Time <= l1Info.Time()+ba.rollupCfg.MaxSequencerDrift &&
+		!ba.rollupCfg.IsEcotoneActivationBlock(nextL2Time) {
+		var depositGas uint64
+		for _, data := range txs {
+			var tx types.Transaction
+			if err := tx.UnmarshalBinary(data); err != nil {
+				return nil, NewCriticalError(fmt.Errorf("failed to decode deposit tx: %w", err))
+			}
+			depositGas += tx.Gas()
+		}
+		txs = append(txs, ba.inclusionList.Select(l2Parent.Number+1, sysConfig.GasLimit, depositGas)...)
+	}
+	// [Kroma: END]
+
 	var withdrawals *types.Withdrawals
 	if ba.rollupCfg.IsCanyon(nextL2Time) {
 		withdrawals = &types.Withdrawals{}
@@ -34,6 +34,10 @@ type AttributesQueue struct {
 	prev         *BatchQueue
 	batch        *SingularBatch
 	isLastInSpan bool
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
@@ -68,6 +72,9 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 		attr := AttributesWithParent{attrs, parent, aq.isLastInSpan}
 		aq.batch = nil
 		aq.isLastInSpan = false
+		// [Kroma: START]
+		aq.tracer.Event(StageAttributesQueue, aq.Origin(), "attributes_produced", "")
+		// [Kroma: END]
 		return &attr, nil
 	}
 
@@ -60,6 +60,10 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
@@ -106,6 +110,9 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*Si
 			// Drop cached batches and find another batch.
 			bq.log.Warn("parent block does not match the next batch. dropped cached batches", "parent", parent.ID(), "nextBatchTime", bq.nextSpan[0].GetTimestamp())
 			bq.nextSpan = bq.nextSpan[:0]
+			// [Kroma: START]
+			bq.tracer.Event(StageBatchQueue, bq.origin, "span_dropped", "parent block does not match the next batch")
+			// [Kroma: END]
 		}
 	}
 
@@ -228,10 +235,19 @@ func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2Bl
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
+	/* [Kroma: START]
 	validity := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
 	if validity == BatchDrop {
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
+	[Kroma: END] */
+	// [Kroma: START]
+	validity, reason := CheckBatchWithReason(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
+	if validity == BatchDrop {
+		bq.tracer.Event(StageBatchQueue, bq.origin, "batch_dropped", reason)
+		return // if we do drop the batch, CheckBatchWithReason will log the drop reason with WARN level.
+	}
+	// [Kroma: END]
 	batch.LogContext(bq.log).Debug("Adding batch")
 	bq.batches = append(bq.batches, &data)
 }
@@ -265,19 +281,33 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, paren
 	var remaining []*BatchWithL1InclusionBlock
 batchLoop:
 	for i, batch := range bq.batches {
+		/* [Kroma: START]
 		validity := CheckBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
+		[Kroma: END] */
+		// [Kroma: START]
+		validity, reason := CheckBatchWithReason(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
+		// [Kroma: END]
 		switch validity {
 		case BatchFuture:
 			remaining = append(remaining, batch)
+			// [Kroma: START]
+			bq.tracer.Event(StageBatchQueue, bq.origin, "batch_future", "")
+			// [Kroma: END]
 			continue
 		case BatchDrop:
 			batch.Batch.LogContext(bq.log).Warn("Dropping batch",
 				"parent", parent.ID(),
 				"parent_time", parent.Time,
 			)
+			// [Kroma: START]
+			bq.tracer.Event(StageBatchQueue, bq.origin, "batch_dropped", reason)
+			// [Kroma: END]
 			continue
 		case BatchAccept:
 			nextBatch = batch
+			// [Kroma: START]
+			bq.tracer.Event(StageBatchQueue, bq.origin, "batch_accepted", "")
+			// [Kroma: END]
 			// don't keep the current batch in the remaining items since we are processing it now,
 			// but retain every batch we didn't get to yet.
 			remaining = append(remaining, bq.batches[i+1:]...)
@@ -323,6 +353,9 @@ batchLoop:
 	// batch to ensure that we at least have one batch per epoch.
 	if nextTimestamp < nextEpoch.Time || firstOfEpoch {
 		bq.log.Info("Generating next batch", "epoch", epoch, "timestamp", nextTimestamp)
+		// [Kroma: START]
+		bq.tracer.Event(StageBatchQueue, bq.origin, "empty_batch_generated", "")
+		// [Kroma: END]
 		return &SingularBatch{
 			ParentHash:   parent.Hash,
 			EpochNum:     rollup.Epoch(epoch.Number),
@@ -377,3 +377,18 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 
 	return BatchAccept
 }
+
+// [Kroma: START]
+// CheckBatchWithReason checks the batch like CheckBatch, and also returns the reason of a BatchDrop.
+// The checks log the reason right before they drop a batch, so it is the message of the last record they logged.
+func CheckBatchWithReason(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef,
+	l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock, l2Fetcher SafeBlockFetcher) (BatchValidity, string) {
+	log, lastMessage := newReasonLogger(log)
+	validity := CheckBatch(ctx, cfg, log, l1Blocks, l2SafeHead, batch, l2Fetcher)
+	if validity != BatchDrop {
+		return validity, ""
+	}
+	return validity, lastMessage()
+}
+
+// [Kroma: END]
@@ -1519,7 +1519,12 @@ func TestValidBatch(t *testing.T) {
 		if testCase.DeltaTime != nil {
 			rcfg.DeltaTime = testCase.DeltaTime
 		}
+		/* [Kroma: START]
 		validity := CheckBatch(ctx, &rcfg, logger, testCase.L1Blocks, testCase.L2SafeHead, &testCase.Batch, &l2Client)
+		[Kroma: END] */
+		// [Kroma: START]
+		validity, reason := CheckBatchWithReason(ctx, &rcfg, logger, testCase.L1Blocks, testCase.L2SafeHead, &testCase.Batch, &l2Client)
+		// [Kroma: END]
 		require.Equal(t, testCase.Expected, validity, "batch check must return expected validity level")
 		if expLog := testCase.ExpectedLog; expLog != "" {
 			// Check if ExpectedLog is contained in the log buffer
@@ -1535,6 +1540,14 @@ func TestValidBatch(t *testing.T) {
 				t.Errorf("Unexpected log message containing %q was logged: %q", notExpLog, l.Message)
 			}
 		}
+		// [Kroma: START]
+		if validity == BatchDrop {
+			// the drop reason is the warning logged for the drop
+			require.Contains(t, reason, testCase.ExpectedLog)
+		} else {
+			require.Empty(t, reason)
+		}
+		// [Kroma: END]
 		logs.Clear()
 	}
 
@@ -39,6 +39,10 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 var _ ResettableStage = (*ChannelBank)(nil)
@@ -73,6 +77,9 @@ func (cb *ChannelBank) prune() {
 		cb.channelQueue = cb.channelQueue[1:]
 		delete(cb.channels, id)
 		cb.log.Info("pruning channel", "channel", id, "totalSize", totalSize, "channel_size", ch.size, "remaining_channel_count", len(cb.channels))
+		// [Kroma: START]
+		cb.tracer.Event(StageChannelBank, cb.Origin(), "channel_pruned", id.String())
+		// [Kroma: END]
 		totalSize -= ch.size
 	}
 }
@@ -95,17 +102,26 @@ func (cb *ChannelBank) IngestFrame(f Frame) {
 		cb.channels[f.ID] = currentCh
 		cb.channelQueue = append(cb.channelQueue, f.ID)
 		log.Info("created new channel")
+		// [Kroma: START]
+		cb.tracer.Event(StageChannelBank, origin, "channel_opened", f.ID.String())
+		// [Kroma: END]
 	}
 
 	// check if the channel is not timed out
 	if currentCh.OpenBlockNumber()+cb.cfg.ChannelTimeout < origin.Number {
 		log.Warn("channel is timed out, ignore frame")
+		// [Kroma: START]
+		cb.tracer.Event(StageChannelBank, origin, "frame_ignored", f.ID.String())
+		// [Kroma: END]
 		return
 	}
 
 	log.Trace("ingesting frame")
 	if err := currentCh.AddFrame(f, origin); err != nil {
 		log.Warn("failed to ingest frame into channel", "err", err)
+		// [Kroma: START]
+		cb.tracer.Event(StageChannelBank, origin, "frame_invalid", err.Error())
+		// [Kroma: END]
 		return
 	}
 	cb.metrics.RecordFrame()
@@ -129,6 +145,9 @@ func (cb *ChannelBank) Read() (data []byte, err error) {
 	if timedOut {
 		cb.log.Info("channel timed out", "channel", first, "frames", len(ch.inputs))
 		cb.metrics.RecordChannelTimedOut()
+		// [Kroma: START]
+		cb.tracer.Event(StageChannelBank, cb.Origin(), "channel_timed_out", first.String())
+		// [Kroma: END]
 		delete(cb.channels, first)
 		cb.channelQueue = cb.channelQueue[1:]
 		return nil, nil // multiple different channels may all be timed out
@@ -162,6 +181,9 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 		return nil, io.EOF
 	}
 	cb.log.Info("Reading channel", "channel", chanID, "frames", len(ch.inputs))
+	// [Kroma: START]
+	cb.tracer.Event(StageChannelBank, cb.Origin(), "channel_ready", chanID.String())
+	// [Kroma: END]
 
 	delete(cb.channels, chanID)
 	cb.channelQueue = slices.Delete(cb.channelQueue, i, i+1)
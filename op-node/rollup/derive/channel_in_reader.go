@@ -26,6 +26,10 @@ type ChannelInReader struct {
 	prev *ChannelBank
 
 	metrics Metrics
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 var _ ResettableStage = (*ChannelInReader)(nil)
@@ -86,6 +90,9 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 		return nil, NotEnoughData
 	} else if err != nil {
 		cr.log.Warn("failed to read batch from channel reader, skipping to next channel now", "err", err)
+		// [Kroma: START]
+		cr.tracer.Event(StageChannelInReader, cr.Origin(), "channel_invalid", err.Error())
+		// [Kroma: END]
 		cr.NextChannel()
 		return nil, NotEnoughData
 	}
@@ -97,6 +104,9 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 		}
 		singularBatch.LogContext(cr.log).Debug("decoded singular batch from channel", "stage_origin", cr.Origin())
 		cr.metrics.RecordDerivedBatches("singular")
+		// [Kroma: START]
+		cr.tracer.Event(StageChannelInReader, cr.Origin(), "batch_read", "singular")
+		// [Kroma: END]
 		return singularBatch, nil
 	case SpanBatchType:
 		if origin := cr.Origin(); !cr.cfg.IsDelta(origin.Time) {
@@ -111,6 +121,9 @@ func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
 		}
 		spanBatch.LogContext(cr.log).Debug("decoded span batch from channel", "stage_origin", cr.Origin())
 		cr.metrics.RecordDerivedBatches("span")
+		// [Kroma: START]
+		cr.tracer.Event(StageChannelInReader, cr.Origin(), "batch_read", "span")
+		// [Kroma: END]
 		return spanBatch, nil
 	default:
 		// error is bubbled up to user, but pipeline can skip the batch and continue after.
@@ -193,6 +193,10 @@ type EngineQueue struct {
 
 	safeHeadNotifs       SafeHeadListener // notified when safe head is updated
 	lastNotifiedSafeHead eth.L2BlockRef
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
@@ -438,6 +442,9 @@ func (eq *EngineQueue) postProcessSafeL2() error {
 	if err := eq.notifyNewSafeHead(eq.ec.SafeL2Head()); err != nil {
 		return err
 	}
+	// [Kroma: START]
+	eq.tracer.Event(StageEngineQueue, eq.origin, "safe_head_updated", eq.ec.SafeL2Head().ID().String())
+	// [Kroma: END]
 	// prune finality data if necessary
 	if uint64(len(eq.finalityData)) >= calcFinalityLookback(eq.cfg) {
 		eq.finalityData = append(eq.finalityData[:0], eq.finalityData[1:calcFinalityLookback(eq.cfg)]...)
@@ -645,6 +652,9 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 			}
 			// drop the payload without inserting it
 			eq.safeAttributes = nil
+			// [Kroma: START]
+			eq.tracer.Event(StageEngineQueue, eq.origin, "attributes_dropped", err.Error())
+			// [Kroma: END]
 			// Revert the pending safe head to the safe head.
 			eq.ec.SetPendingSafeL2Head(eq.ec.SafeL2Head())
 			// suppress the error b/c we want to retry with the next batch from the batch queue
@@ -759,6 +769,9 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 		}
 	}
 	eq.logSyncProgress("reset derivation work")
+	// [Kroma: START]
+	eq.tracer.Event(StageEngineQueue, eq.origin, "reset", "")
+	// [Kroma: END]
 	return io.EOF
 }
 
@@ -20,6 +20,10 @@ type FrameQueue struct {
 	log    log.Logger
 	frames []Frame
 	prev   NextDataProvider
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 func NewFrameQueue(log log.Logger, prev NextDataProvider) *FrameQueue {
@@ -43,6 +47,9 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 				fq.frames = append(fq.frames, new...)
 			} else {
 				fq.log.Warn("Failed to parse frames", "origin", fq.prev.Origin(), "err", err)
+				// [Kroma: START]
+				fq.tracer.Event(StageFrameQueue, fq.prev.Origin(), "frames_invalid", err.Error())
+				// [Kroma: END]
 			}
 		}
 	}
@@ -53,6 +60,9 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 
 	ret := fq.frames[0]
 	fq.frames = fq.frames[1:]
+	// [Kroma: START]
+	fq.tracer.Event(StageFrameQueue, fq.prev.Origin(), "frame_read", "")
+	// [Kroma: END]
 	return ret, nil
 }
 
@@ -0,0 +1,19 @@
+package derive
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// InclusionList provides the txs that the sequencer forces into the blocks it builds, after the deposits
+// and before the txs of the tx pool, e.g. protocol-operated txs that must land within a bounded time.
+type InclusionList interface {
+	// Select returns the encoded txs to force into the block with the given number and gas limit,
+	// after the deposits that use the given gas.
+	Select(number uint64, gasLimit uint64, depositGas uint64) []hexutil.Bytes
+}
+
+// SetInclusionList makes the builder force the txs of the inclusion list into the attributes it prepares.
+// It must only be set on the attributes builder of the sequencer, never on the one of the derivation.
+func (ba *FetchingAttributesBuilder) SetInclusionList(list InclusionList) {
+	ba.inclusionList = list
+}
@@ -27,6 +27,10 @@ type L1Retrieval struct {
 	prev    NextBlockProvider
 
 	datas DataIter
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 var _ ResettableStage = (*L1Retrieval)(nil)
@@ -57,6 +61,9 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		if l1r.datas, err = l1r.dataSrc.OpenData(ctx, next, l1r.prev.SystemConfig().BatcherAddr); err != nil {
 			return nil, fmt.Errorf("failed to open data source: %w", err)
 		}
+		// [Kroma: START]
+		l1r.tracer.Event(StageL1Retrieval, next, "data_opened", "")
+		// [Kroma: END]
 	}
 
 	l1r.log.Debug("fetching next piece of data")
@@ -29,6 +29,10 @@ type L1Traversal struct {
 	log      log.Logger
 	sysCfg   eth.SystemConfig
 	cfg      *rollup.Config
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 var _ ResettableStage = (*L1Traversal)(nil)
@@ -62,11 +66,17 @@ func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) error {
 	nextL1Origin, err := l1t.l1Blocks.L1BlockRefByNumber(ctx, origin.Number+1)
 	if errors.Is(err, ethereum.NotFound) {
 		l1t.log.Debug("can't find next L1 block info (yet)", "number", origin.Number+1, "origin", origin)
+		// [Kroma: START]
+		l1t.tracer.Event(StageL1Traversal, origin, "waiting_for_l1", "")
+		// [Kroma: END]
 		return io.EOF
 	} else if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to find L1 block info by number, at origin %s next %d: %w", origin, origin.Number+1, err))
 	}
 	if l1t.block.Hash != nextL1Origin.ParentHash {
+		// [Kroma: START]
+		l1t.tracer.Event(StageL1Traversal, origin, "reorg_detected", nextL1Origin.String())
+		// [Kroma: END]
 		return NewResetError(fmt.Errorf("detected L1 reorg from %s to %s with conflicting parent %s", l1t.block, nextL1Origin, nextL1Origin.ParentID()))
 	}
 
@@ -82,6 +92,9 @@ func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) error {
 
 	l1t.block = nextL1Origin
 	l1t.done = false
+	// [Kroma: START]
+	l1t.tracer.Event(StageL1Traversal, nextL1Origin, "origin_advanced", "")
+	// [Kroma: END]
 	return nil
 }
 
@@ -91,6 +104,9 @@ func (l1t *L1Traversal) Reset(ctx context.Context, base eth.L1BlockRef, cfg eth.
 	l1t.done = false
 	l1t.sysCfg = cfg
 	l1t.log.Info("completed reset of derivation pipeline", "origin", base)
+	// [Kroma: START]
+	l1t.tracer.Event(StageL1Traversal, base, "reset", "")
+	// [Kroma: END]
 	return io.EOF
 }
 
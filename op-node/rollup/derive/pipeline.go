@@ -22,6 +22,9 @@ type Metrics interface {
 	RecordChannelTimedOut()
 	RecordFrame()
 	RecordDerivedBatches(batchType string)
+	// [Kroma: START]
+	RecordDerivationEvent(stage string, event string)
+	// [Kroma: END]
 }
 
 type L1Fetcher interface {
@@ -65,6 +68,10 @@ type DerivationPipeline struct {
 	eng       EngineQueueStage
 
 	metrics Metrics
+
+	// [Kroma: START]
+	tracer *Tracer
+	// [Kroma: END]
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
@@ -85,6 +92,18 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	// Step stages
 	eng := NewEngineQueue(log, rollupCfg, l2Source, engine, metrics, attributesQueue, l1Fetcher, syncCfg, safeHeadListener)
 
+	// [Kroma: START]
+	tracer := NewTracer(metrics)
+	l1Traversal.tracer = tracer
+	l1Src.tracer = tracer
+	frameQueue.tracer = tracer
+	bank.tracer = tracer
+	chInReader.tracer = tracer
+	batchQueue.tracer = tracer
+	attributesQueue.tracer = tracer
+	eng.tracer = tracer
+	// [Kroma: END]
+
 	// Plasma takes control of the engine finalization signal only when usePlasma is enabled.
 	plasma.OnFinalizedHeadSignal(func(ref eth.L1BlockRef) {
 		eng.Finalize(ref)
@@ -105,9 +124,21 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 		eng:       eng,
 		metrics:   metrics,
 		traversal: l1Traversal,
+		// [Kroma: START]
+		tracer: tracer,
+		// [Kroma: END]
 	}
 }
 
+// [Kroma: START]
+
+// Tracer returns the tracer of the derivation stages.
+func (dp *DerivationPipeline) Tracer() *Tracer {
+	return dp.tracer
+}
+
+// [Kroma: END]
+
 // EngineReady returns true if the engine is ready to be used.
 // When it's being reset its state is inconsistent, and should not be used externally.
 func (dp *DerivationPipeline) EngineReady() bool {
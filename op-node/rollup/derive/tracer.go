@@ -0,0 +1,191 @@
+package derive
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slog"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Derivation stage names, as used in trace events and metrics.
+const (
+	StageL1Traversal     = "l1_traversal"
+	StageL1Retrieval     = "l1_retrieval"
+	StageFrameQueue      = "frame_queue"
+	StageChannelBank     = "channel_bank"
+	StageChannelInReader = "channel_in_reader"
+	StageBatchQueue      = "batch_queue"
+	StageAttributesQueue = "attributes_queue"
+	StageEngineQueue     = "engine_queue"
+)
+
+// pipelineStages lists the stages in pipeline order.
+var pipelineStages = []string{
+	StageL1Traversal, StageL1Retrieval, StageFrameQueue, StageChannelBank,
+	StageChannelInReader, StageBatchQueue, StageAttributesQueue, StageEngineQueue,
+}
+
+// traceBufferSize is the number of recent trace events kept.
+const traceBufferSize = 256
+
+// TraceEvent is a notable step of a derivation stage, like reading a frame or dropping a batch.
+type TraceEvent struct {
+	Time   time.Time   `json:"time"`
+	Stage  string      `json:"stage"`
+	Event  string      `json:"event"`
+	Origin eth.BlockID `json:"origin"`
+	Detail string      `json:"detail,omitempty"`
+	// Repeated counts the identical events that directly followed this one, e.g. while waiting for the next L1 block.
+	Repeated uint64 `json:"repeated,omitempty"`
+}
+
+// StageTrace is the current state of a derivation stage.
+type StageTrace struct {
+	Stage     string            `json:"stage"`
+	Origin    eth.BlockID       `json:"origin"`
+	LastEvent *TraceEvent       `json:"lastEvent,omitempty"`
+	Events    map[string]uint64 `json:"events"`
+}
+
+// DerivationTrace is a snapshot of the derivation pipeline trace.
+type DerivationTrace struct {
+	// Stages are in pipeline order, from L1 traversal to the engine queue.
+	Stages []StageTrace `json:"stages"`
+	// Recent are the most recent events of all stages, oldest first.
+	Recent []TraceEvent `json:"recent"`
+}
+
+// Tracer records the trace events of the derivation stages, counts them in the metrics,
+// and keeps the recent events and the last event of each stage to inspect a stalled derivation.
+// A nil Tracer ignores all events, so stages can be used without one.
+type Tracer struct {
+	metrics Metrics
+
+	mu     sync.Mutex
+	stages map[string]*StageTrace
+	recent []TraceEvent
+	// next is the index of recent to write the next event to, once the buffer is full
+	next int
+}
+
+func NewTracer(metrics Metrics) *Tracer {
+	t := &Tracer{
+		metrics: metrics,
+		stages:  make(map[string]*StageTrace),
+	}
+	for _, stage := range pipelineStages {
+		t.stages[stage] = &StageTrace{Stage: stage, Events: make(map[string]uint64)}
+	}
+	return t
+}
+
+// Event records an event of a stage at the given origin. The detail is optional.
+func (t *Tracer) Event(stage string, origin eth.L1BlockRef, event string, detail string) {
+	if t == nil {
+		return
+	}
+	t.metrics.RecordDerivationEvent(stage, event)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st, ok := t.stages[stage]
+	if !ok {
+		st = &StageTrace{Stage: stage, Events: make(map[string]uint64)}
+		t.stages[stage] = st
+	}
+	st.Origin = origin.ID()
+	st.Events[event]++
+
+	if len(t.recent) > 0 {
+		if last := &t.recent[t.lastIndex()]; last.Stage == stage && last.Event == event && last.Origin == origin.ID() && last.Detail == detail {
+			last.Repeated++
+			*st.LastEvent = *last
+			return
+		}
+	}
+	ev := TraceEvent{Time: time.Now(), Stage: stage, Event: event, Origin: origin.ID(), Detail: detail}
+	st.LastEvent = &ev
+	if len(t.recent) < traceBufferSize {
+		t.recent = append(t.recent, ev)
+	} else {
+		t.recent[t.next] = ev
+		t.next = (t.next + 1) % traceBufferSize
+	}
+}
+
+func (t *Tracer) lastIndex() int {
+	if len(t.recent) < traceBufferSize {
+		return len(t.recent) - 1
+	}
+	return (t.next + traceBufferSize - 1) % traceBufferSize
+}
+
+// Trace returns a snapshot of the trace.
+func (t *Tracer) Trace() *DerivationTrace {
+	out := &DerivationTrace{Stages: []StageTrace{}, Recent: []TraceEvent{}}
+	if t == nil {
+		return out
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, stage := range pipelineStages {
+		st := t.stages[stage]
+		cp := StageTrace{Stage: st.Stage, Origin: st.Origin, Events: make(map[string]uint64, len(st.Events))}
+		if st.LastEvent != nil {
+			last := *st.LastEvent
+			cp.LastEvent = &last
+		}
+		for k, v := range st.Events {
+			cp.Events[k] = v
+		}
+		out.Stages = append(out.Stages, cp)
+	}
+	if len(t.recent) < traceBufferSize {
+		out.Recent = append(out.Recent, t.recent...)
+	} else {
+		out.Recent = append(out.Recent, t.recent[t.next:]...)
+		out.Recent = append(out.Recent, t.recent[:t.next]...)
+	}
+	return out
+}
+
+// reasonRecorder is a log handler that remembers the message of the last record logged through it,
+// at the info level or above, even if the underlying handler does not log it.
+type reasonRecorder struct {
+	slog.Handler
+	last *string
+}
+
+// newReasonLogger returns a logger that logs to l, and a function that returns the message of the last record
+// logged through it. The checks that drop data log the reason right before, so the message is the drop reason.
+func newReasonLogger(l log.Logger) (log.Logger, func() string) {
+	var last string
+	return log.NewLogger(&reasonRecorder{Handler: l.Handler(), last: &last}), func() string { return last }
+}
+
+func (r *reasonRecorder) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelInfo || r.Handler.Enabled(ctx, level)
+}
+
+func (r *reasonRecorder) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelInfo {
+		*r.last = record.Message
+	}
+	if !r.Handler.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return r.Handler.Handle(ctx, record)
+}
+
+func (r *reasonRecorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &reasonRecorder{Handler: r.Handler.WithAttrs(attrs), last: r.last}
+}
+
+func (r *reasonRecorder) WithGroup(name string) slog.Handler {
+	return &reasonRecorder{Handler: r.Handler.WithGroup(name), last: r.last}
+}
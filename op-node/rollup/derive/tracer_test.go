@@ -0,0 +1,83 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestTracer(t *testing.T) {
+	tracer := NewTracer(&testutils.TestDerivationMetrics{})
+	a := eth.L1BlockRef{Hash: common.Hash{0xa}, Number: 10}
+	b := eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 11}
+
+	tracer.Event(StageL1Traversal, a, "waiting_for_l1", "")
+	tracer.Event(StageL1Traversal, a, "waiting_for_l1", "")
+	tracer.Event(StageL1Traversal, a, "waiting_for_l1", "")
+	tracer.Event(StageL1Traversal, b, "origin_advanced", "")
+	tracer.Event(StageBatchQueue, b, "batch_dropped", "dropping batch with old timestamp")
+
+	trace := tracer.Trace()
+	require.Len(t, trace.Stages, len(pipelineStages))
+	for i, stage := range pipelineStages {
+		require.Equal(t, stage, trace.Stages[i].Stage)
+	}
+
+	// repeated events are merged
+	require.Len(t, trace.Recent, 3)
+	require.Equal(t, "waiting_for_l1", trace.Recent[0].Event)
+	require.Equal(t, uint64(2), trace.Recent[0].Repeated)
+	require.Equal(t, "origin_advanced", trace.Recent[1].Event)
+	require.Equal(t, "dropping batch with old timestamp", trace.Recent[2].Detail)
+
+	traversal := trace.Stages[0]
+	require.Equal(t, b.ID(), traversal.Origin)
+	require.Equal(t, uint64(3), traversal.Events["waiting_for_l1"])
+	require.Equal(t, uint64(1), traversal.Events["origin_advanced"])
+	require.Equal(t, "origin_advanced", traversal.LastEvent.Event)
+
+	// the snapshot is not changed by later events
+	tracer.Event(StageL1Traversal, b, "reorg_detected", "")
+	require.Equal(t, "origin_advanced", traversal.LastEvent.Event)
+	require.Len(t, trace.Recent, 3)
+}
+
+func TestTracerRecentEvents(t *testing.T) {
+	tracer := NewTracer(&testutils.TestDerivationMetrics{})
+	for i := uint64(0); i < traceBufferSize+10; i++ {
+		tracer.Event(StageL1Traversal, eth.L1BlockRef{Number: i}, "origin_advanced", "")
+	}
+	recent := tracer.Trace().Recent
+	require.Len(t, recent, traceBufferSize)
+	// only the most recent events are kept, oldest first
+	for i, ev := range recent {
+		require.Equal(t, uint64(i+10), ev.Origin.Number)
+	}
+
+	// repeats of the last event are merged after the buffer wrapped around
+	tracer.Event(StageL1Traversal, eth.L1BlockRef{Number: traceBufferSize + 9}, "origin_advanced", "")
+	recent = tracer.Trace().Recent
+	require.Equal(t, uint64(1), recent[len(recent)-1].Repeated)
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+	tracer.Event(StageL1Traversal, eth.L1BlockRef{}, "origin_advanced", "")
+	trace := tracer.Trace()
+	require.Empty(t, trace.Stages)
+	require.Empty(t, trace.Recent)
+}
+
+func TestReasonLogger(t *testing.T) {
+	// the message is recorded even if it is not logged
+	l, lastMessage := newReasonLogger(testlog.Logger(t, log.LevelCrit))
+	l.New("batch_index", 1).Warn("dropping batch with old timestamp", "min_timestamp", 10)
+	l.Debug("not a reason")
+	require.Equal(t, "dropping batch with old timestamp", lastMessage())
+}
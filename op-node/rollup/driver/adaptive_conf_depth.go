@@ -0,0 +1,175 @@
+package driver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// reorgWindow is the number of L1 blocks a reorg keeps raising the confirmation depth for.
+	reorgWindow = 256
+	// healthyParticipation is the participation above which the beacon chain is considered stable.
+	healthyParticipation = 0.95
+	// minParticipation is the participation below which the beacon chain cannot finalize,
+	// at which the maximum confirmation depth is used.
+	minParticipation = 2.0 / 3.0
+	// participationPollInterval is the interval of the participation polls, an L1 slot.
+	participationPollInterval = 12 * time.Second
+)
+
+// L1ParticipationSource returns the participation of the L1 beacon chain at its head.
+type L1ParticipationSource interface {
+	SyncParticipation(ctx context.Context) (slot uint64, participation float64, err error)
+}
+
+type AdaptiveConfDepthMetrics interface {
+	RecordSequencerAdaptiveConfDepth(depth uint64, reorgDepth uint64, participation float64)
+}
+
+type l1Reorg struct {
+	depth uint64
+	// head is the number of the L1 head the reorg ended at.
+	head uint64
+}
+
+// adaptiveConfDepth is a confDepth whose depth follows the stability of L1: it starts at the minimum depth,
+// covers the depth of the recent L1 reorgs, and moves towards the maximum depth as the participation of the
+// beacon chain drops. The depth goes back to the minimum once L1 is stable again, so a period of instability
+// does not add deposit latency permanently.
+type adaptiveConfDepth struct {
+	derive.L1Fetcher
+	log     log.Logger
+	metrics AdaptiveConfDepthMetrics
+	l1Head  func() eth.L1BlockRef
+
+	minDepth uint64
+	maxDepth uint64
+
+	mu            sync.Mutex
+	reorgs        []l1Reorg
+	participation float64
+	depth         uint64
+}
+
+func NewAdaptiveConfDepth(log log.Logger, minDepth, maxDepth uint64, l1Head func() eth.L1BlockRef, fetcher derive.L1Fetcher, metrics AdaptiveConfDepthMetrics) *adaptiveConfDepth {
+	return &adaptiveConfDepth{
+		L1Fetcher:     fetcher,
+		log:           log,
+		metrics:       metrics,
+		l1Head:        l1Head,
+		minDepth:      minDepth,
+		maxDepth:      maxDepth,
+		participation: 1,
+		depth:         minDepth,
+	}
+}
+
+// OnL1Reorg records an L1 reorg that replaced at least the given number of blocks, and ended at the given head.
+func (c *adaptiveConfDepth) OnL1Reorg(depth uint64, head eth.L1BlockRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reorgs = append(c.reorgs, l1Reorg{depth: depth, head: head.Number})
+	c.updateLocked(head.Number)
+}
+
+// OnParticipation records the latest participation of the beacon chain, between 0 and 1.
+func (c *adaptiveConfDepth) OnParticipation(participation float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.participation = participation
+	c.updateLocked(c.l1Head().Number)
+}
+
+// Depth returns the current confirmation depth.
+func (c *adaptiveConfDepth) Depth() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.updateLocked(c.l1Head().Number)
+}
+
+func (c *adaptiveConfDepth) updateLocked(head uint64) uint64 {
+	// forget the reorgs that are out of the window
+	var reorgDepth uint64
+	recent := c.reorgs[:0]
+	for _, r := range c.reorgs {
+		if r.head+reorgWindow <= head {
+			continue
+		}
+		recent = append(recent, r)
+		reorgDepth = max(reorgDepth, r.depth)
+	}
+	c.reorgs = recent
+
+	depth := c.minDepth
+	reason := "stable"
+	if reorgDepth+1 > depth {
+		// stay deeper than the recent reorgs
+		depth = reorgDepth + 1
+		reason = "reorg"
+	}
+	if c.participation < healthyParticipation {
+		participationDepth := c.maxDepth
+		if c.participation > minParticipation {
+			// interpolate between the minimum and maximum depth
+			share := (healthyParticipation - c.participation) / (healthyParticipation - minParticipation)
+			participationDepth = c.minDepth + uint64(share*float64(c.maxDepth-c.minDepth)+0.5)
+		}
+		if participationDepth > depth {
+			depth = participationDepth
+			reason = "participation"
+		}
+	}
+	depth = min(depth, c.maxDepth)
+
+	if depth != c.depth {
+		c.log.Info("Changed sequencer L1 confirmation depth", "from", c.depth, "to", depth, "reason", reason,
+			"reorg_depth", reorgDepth, "participation", c.participation)
+		c.depth = depth
+	}
+	c.metrics.RecordSequencerAdaptiveConfDepth(depth, reorgDepth, c.participation)
+	return depth
+}
+
+// L1BlockRefByNumber hides the L1 blocks within the current confirmation depth of the L1 head, like confDepth.
+func (c *adaptiveConfDepth) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	l1Head := c.l1Head()
+	if l1Head == (eth.L1BlockRef{}) {
+		return c.L1Fetcher.L1BlockRefByNumber(ctx, num)
+	}
+	if depth := c.Depth(); num == 0 || depth == 0 || num+depth <= l1Head.Number {
+		return c.L1Fetcher.L1BlockRefByNumber(ctx, num)
+	}
+	return eth.L1BlockRef{}, ethereum.NotFound
+}
+
+// pollParticipation polls the participation of the beacon chain until the context is done.
+func (c *adaptiveConfDepth) pollParticipation(ctx context.Context, source L1ParticipationSource) {
+	ticker := time.NewTicker(participationPollInterval)
+	defer ticker.Stop()
+	for {
+		cCtx, cancel := context.WithTimeout(ctx, participationPollInterval)
+		slot, participation, err := source.SyncParticipation(cCtx)
+		cancel()
+		if err != nil {
+			// keep the last participation, the reorg tracking still applies
+			c.log.Warn("Failed to fetch L1 beacon chain participation", "err", err)
+		} else {
+			c.log.Debug("Fetched L1 beacon chain participation", "slot", slot, "participation", participation)
+			c.OnParticipation(participation)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+var _ derive.L1Fetcher = (*adaptiveConfDepth)(nil)
@@ -0,0 +1,84 @@
+package driver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestAdaptiveConfDepth(t *testing.T) {
+	l1Head := eth.L1BlockRef{Number: 1000, Hash: exHash}
+	l1Fetcher := &testutils.MockL1Source{}
+	cd := NewAdaptiveConfDepth(testlog.Logger(t, log.LevelError), 4, 20, func() eth.L1BlockRef { return l1Head }, l1Fetcher, metrics.NoopMetrics)
+
+	require.Equal(t, uint64(4), cd.Depth(), "stable L1 uses the minimum depth")
+
+	cd.OnL1Reorg(7, eth.L1BlockRef{Number: 995})
+	require.Equal(t, uint64(8), cd.Depth(), "depth covers the recent reorg")
+
+	cd.OnL1Reorg(30, eth.L1BlockRef{Number: 996})
+	require.Equal(t, uint64(20), cd.Depth(), "depth is capped at the maximum")
+
+	l1Head.Number = 995 + reorgWindow + 10
+	require.Equal(t, uint64(4), cd.Depth(), "reorgs out of the window are forgotten")
+
+	cd.OnParticipation(0.99)
+	require.Equal(t, uint64(4), cd.Depth(), "healthy participation does not change the depth")
+	cd.OnParticipation((healthyParticipation + minParticipation) / 2)
+	require.Equal(t, uint64(12), cd.Depth(), "depth is interpolated between the minimum and maximum")
+	cd.OnParticipation(0.5)
+	require.Equal(t, uint64(20), cd.Depth(), "depth is the maximum when L1 cannot finalize")
+	cd.OnParticipation(1)
+	require.Equal(t, uint64(4), cd.Depth(), "depth shortens once L1 is stable again")
+
+	cd.OnL1Reorg(9, l1Head)
+	l1Fetcher.ExpectL1BlockRefByNumber(l1Head.Number-10, eth.L1BlockRef{Number: l1Head.Number - 10}, nil)
+	out, err := cd.L1BlockRefByNumber(context.Background(), l1Head.Number-10)
+	require.NoError(t, err)
+	require.Equal(t, eth.L1BlockRef{Number: l1Head.Number - 10}, out)
+	_, err = cd.L1BlockRefByNumber(context.Background(), l1Head.Number-9)
+	require.Equal(t, ethereum.NotFound, err)
+	l1Fetcher.AssertExpectations(t)
+}
+
+func TestL1StateReorgListener(t *testing.T) {
+	var reorgs []uint64
+	s := NewL1State(testlog.Logger(t, log.LevelError), metrics.NoopMetrics)
+	s.reorgListener = reorgListenerFn(func(depth uint64, head eth.L1BlockRef) { reorgs = append(reorgs, depth) })
+
+	a := eth.L1BlockRef{Number: 10, Hash: exHash}
+	s.HandleNewL1HeadBlock(a)
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 11, Hash: common.Hash{0x02}, ParentHash: exHash})
+	// replaces 10 and 11
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 10, Hash: common.Hash{0x03}})
+	require.Equal(t, []uint64{2}, reorgs)
+
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 11, Hash: common.Hash{0x04}, ParentHash: common.Hash{0x03}})
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 12, Hash: common.Hash{0x05}, ParentHash: common.Hash{0x04}})
+	// a longer chain that replaces 12
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 13, Hash: common.Hash{0x06}, ParentHash: common.Hash{0x07}})
+	require.Equal(t, []uint64{2, 1}, reorgs)
+	// a longer chain that replaces 11, 12 and 13
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 12, Hash: common.Hash{0x08}, ParentHash: common.Hash{0x09}})
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 13, Hash: common.Hash{0x0a}, ParentHash: common.Hash{0x08}})
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 14, Hash: common.Hash{0x0b}, ParentHash: common.Hash{0x0a}})
+	require.Equal(t, []uint64{2, 1, 3}, reorgs)
+	// a sibling of the head
+	s.HandleNewL1HeadBlock(eth.L1BlockRef{Number: 14, Hash: common.Hash{0x0c}, ParentHash: common.Hash{0x0a}})
+	require.Equal(t, []uint64{2, 1, 3, 1}, reorgs)
+}
+
+type reorgListenerFn func(depth uint64, head eth.L1BlockRef)
+
+func (fn reorgListenerFn) OnL1Reorg(depth uint64, head eth.L1BlockRef) {
+	fn(depth, head)
+}
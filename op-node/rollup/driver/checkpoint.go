@@ -0,0 +1,96 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// checkpointPollInterval is the interval between attempts to make the execution engine accept the checkpoint.
+const checkpointPollInterval = 10 * time.Second
+
+type CheckpointEngine interface {
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+}
+
+// SyncToCheckpoint makes the execution engine sync to the checkpoint block, and marks it as the unsafe, safe and
+// finalized head. The engine syncs the history before the checkpoint by itself (e.g. snap sync),
+// so derivation can start from the checkpoint instead of genesis.
+// It returns when the engine accepted the checkpoint, or already has a finalized block at or past the checkpoint.
+func SyncToCheckpoint(ctx context.Context, log log.Logger, cfg *rollup.Config, engine CheckpointEngine, checkpoint *eth.ExecutionPayloadEnvelope, pollInterval time.Duration) error {
+	ref, err := derive.PayloadToBlockRef(cfg, checkpoint.ExecutionPayload)
+	if err != nil {
+		return fmt.Errorf("failed to decode checkpoint block: %w", err)
+	}
+	log = log.New("checkpoint", ref)
+	log.Info("Syncing execution engine to checkpoint")
+	start := time.Now()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for ; ; <-ticker.C {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		done, err := tryCheckpoint(ctx, engine, checkpoint, ref)
+		if err != nil {
+			log.Warn("Failed to sync to checkpoint, retrying", "err", err)
+			continue
+		}
+		if done {
+			log.Info("Synced execution engine to checkpoint", "duration", time.Since(start))
+			return nil
+		}
+		log.Info("Waiting for execution engine to sync to checkpoint", "elapsed", time.Since(start))
+	}
+}
+
+func tryCheckpoint(ctx context.Context, engine CheckpointEngine, checkpoint *eth.ExecutionPayloadEnvelope, ref eth.L2BlockRef) (bool, error) {
+	finalized, err := engine.L2BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return false, fmt.Errorf("failed to fetch finalized head: %w", err)
+	}
+	if err == nil && finalized.Number >= ref.Number {
+		// e.g. a restart after the checkpoint was already reached
+		return true, nil
+	}
+
+	status, err := engine.NewPayload(ctx, checkpoint.ExecutionPayload, checkpoint.ParentBeaconBlockRoot)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert checkpoint payload: %w", err)
+	}
+	switch status.Status {
+	case eth.ExecutionValid, eth.ExecutionSyncing, eth.ExecutionAccepted:
+	default:
+		return false, eth.NewPayloadErr(checkpoint.ExecutionPayload, status)
+	}
+
+	fc := eth.ForkchoiceState{
+		HeadBlockHash:      ref.Hash,
+		SafeBlockHash:      ref.Hash,
+		FinalizedBlockHash: ref.Hash,
+	}
+	res, err := engine.ForkchoiceUpdate(ctx, &fc, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to update forkchoice to checkpoint: %w", err)
+	}
+	switch res.PayloadStatus.Status {
+	case eth.ExecutionValid:
+		return true, nil
+	case eth.ExecutionSyncing:
+		return false, nil
+	default:
+		return false, eth.ForkchoiceUpdateErr(res.PayloadStatus)
+	}
+}
@@ -0,0 +1,64 @@
+package driver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSyncToCheckpoint(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	// The checkpoint is the L2 genesis block here, so the block ref can be decoded without an L1 info deposit.
+	checkpointID := eth.BlockID{Hash: common.Hash{0xaa}, Number: 100}
+	cfg := &rollup.Config{Genesis: rollup.Genesis{L2: checkpointID}}
+	checkpoint := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		BlockHash:   checkpointID.Hash,
+		BlockNumber: eth.Uint64Quantity(checkpointID.Number),
+	}}
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      checkpointID.Hash,
+		SafeBlockHash:      checkpointID.Hash,
+		FinalizedBlockHash: checkpointID.Hash,
+	}
+
+	t.Run("Synced", func(t *testing.T) {
+		engine := &testutils.MockEngine{}
+		defer engine.AssertExpectations(t)
+		// the engine starts syncing on the first attempt, and finished on the second
+		for _, status := range []eth.ExecutePayloadStatus{eth.ExecutionSyncing, eth.ExecutionValid} {
+			engine.ExpectL2BlockRefByLabel(eth.Finalized, eth.L2BlockRef{}, ethereum.NotFound)
+			engine.ExpectNewPayload(checkpoint.ExecutionPayload, nil, &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil)
+			engine.ExpectForkchoiceUpdate(fc, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: status}}, nil)
+		}
+		require.NoError(t, SyncToCheckpoint(context.Background(), logger, cfg, engine, checkpoint, time.Millisecond))
+	})
+
+	t.Run("AlreadyFinalized", func(t *testing.T) {
+		engine := &testutils.MockEngine{}
+		defer engine.AssertExpectations(t)
+		engine.ExpectL2BlockRefByLabel(eth.Finalized, eth.L2BlockRef{Number: checkpointID.Number + 10}, nil)
+		require.NoError(t, SyncToCheckpoint(context.Background(), logger, cfg, engine, checkpoint, time.Millisecond))
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		engine := &testutils.MockEngine{}
+		ctx, cancel := context.WithCancel(context.Background())
+		// an invalid checkpoint is retried until the node is stopped
+		notFound := ethereum.NotFound
+		engine.On("L2BlockRefByLabel", eth.BlockLabel(eth.Finalized)).Run(func(_ mock.Arguments) { cancel() }).Return(eth.L2BlockRef{}, &notFound)
+		engine.On("NewPayload", checkpoint.ExecutionPayload, (*common.Hash)(nil)).Return(&eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, nil)
+		require.ErrorIs(t, SyncToCheckpoint(ctx, logger, cfg, engine, checkpoint, time.Millisecond), context.Canceled)
+	})
+}
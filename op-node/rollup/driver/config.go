@@ -1,5 +1,10 @@
 package driver
 
+// [Kroma: START]
+import "fmt"
+
+// [Kroma: END]
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +25,35 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// [Kroma: START]
+	// SequencerPriorityGasShare is the maximum percentage of the block gas limit the priority txs the sequencer
+	// forces into a block may use, after the deposits. The tx pool fills the rest of the block.
+	// The inclusion list is disabled if 0.
+	SequencerPriorityGasShare uint64 `json:"sequencer_priority_gas_share"`
+
+	// SequencerInclusionListPolicy is the ordering policy of the priority txs of different senders: fifo or fee.
+	SequencerInclusionListPolicy string `json:"sequencer_inclusion_list_policy"`
+
+	// SequencerAdaptiveConfDepth makes the sequencer adapt its L1 confirmation depth to the stability of L1,
+	// between SequencerConfDepth and SequencerMaxConfDepth.
+	SequencerAdaptiveConfDepth bool `json:"sequencer_adaptive_conf_depth"`
+
+	// SequencerMaxConfDepth is the maximum L1 confirmation depth of the adaptive mode.
+	// It must leave time to adopt an L1 origin within rollup.Config.MaxSequencerDrift.
+	SequencerMaxConfDepth uint64 `json:"sequencer_max_conf_depth"`
+	// [Kroma: END]
+}
+
+// [Kroma: START]
+func (c *Config) Check() error {
+	if c.SequencerPriorityGasShare > 100 {
+		return fmt.Errorf("sequencer priority gas share %d is not a percentage", c.SequencerPriorityGasShare)
+	}
+	if c.SequencerAdaptiveConfDepth && c.SequencerMaxConfDepth < c.SequencerConfDepth {
+		return fmt.Errorf("sequencer max conf depth %d is lower than the conf depth %d", c.SequencerMaxConfDepth, c.SequencerConfDepth)
+	}
+	return CheckInclusionListPolicy(c.SequencerInclusionListPolicy)
 }
+
+// [Kroma: END]
@@ -30,6 +30,9 @@ type Metrics interface {
 	RecordFrame()
 
 	RecordDerivedBatches(batchType string)
+	// [Kroma: START]
+	RecordDerivationEvent(stage string, event string)
+	// [Kroma: END]
 
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 
@@ -40,6 +43,9 @@ type Metrics interface {
 	EngineMetrics
 	L1FetcherMetrics
 	SequencerMetrics
+	// [Kroma: START]
+	AdaptiveConfDepthMetrics
+	// [Kroma: END]
 }
 
 type L1Chain interface {
@@ -52,6 +58,9 @@ type L2Chain interface {
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
 	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	// [Kroma: START]
+	AccountReader
+	// [Kroma: END]
 }
 
 type DerivationPipeline interface {
@@ -133,7 +142,20 @@ func NewDriver(
 ) *Driver {
 	l1 = NewMeteredL1Fetcher(l1, metrics)
 	l1State := NewL1State(log, metrics)
+	/* [Kroma: START]
 	sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, l1State.L1Head, l1)
+	[Kroma: END] */
+	// [Kroma: START]
+	var sequencerConfDepth derive.L1Fetcher
+	var adaptiveConfDepth *adaptiveConfDepth
+	if driverCfg.SequencerAdaptiveConfDepth {
+		adaptiveConfDepth = NewAdaptiveConfDepth(log, driverCfg.SequencerConfDepth, driverCfg.SequencerMaxConfDepth, l1State.L1Head, l1, metrics)
+		l1State.reorgListener = adaptiveConfDepth
+		sequencerConfDepth = adaptiveConfDepth
+	} else {
+		sequencerConfDepth = NewConfDepth(driverCfg.SequencerConfDepth, l1State.L1Head, l1)
+	}
+	// [Kroma: END]
 	findL1Origin := NewL1OriginSelector(log, cfg, sequencerConfDepth)
 	verifConfDepth := NewConfDepth(driverCfg.VerifierConfDepth, l1State.L1Head, l1)
 	engine := derive.NewEngineController(l2, log, metrics, cfg, syncCfg.SyncMode)
@@ -141,6 +163,16 @@ func NewDriver(
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log) // Only use the metered engine in the sequencer b/c it records sequencing metrics.
 	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
+	// [Kroma: START]
+	var priorityTxs *PriorityTxQueue
+	if driverCfg.SequencerEnabled && driverCfg.SequencerPriorityGasShare > 0 {
+		priorityTxs = NewPriorityTxQueue(log, cfg, l2, driverCfg.SequencerInclusionListPolicy, driverCfg.SequencerPriorityGasShare)
+		attrBuilder.SetInclusionList(priorityTxs)
+		sequencer.inclusionList = priorityTxs
+	} else if driverCfg.SequencerEnabled {
+		log.Info("Sequencer inclusion list is disabled, the priority gas share is 0")
+	}
+	// [Kroma: END]
 	driverCtx, driverCancel := context.WithCancel(context.Background())
 	asyncGossiper := async.NewAsyncGossiper(driverCtx, network, log, metrics)
 	return &Driver{
@@ -172,5 +204,11 @@ func NewDriver(
 		altSync:            altSync,
 		asyncGossiper:      asyncGossiper,
 		sequencerConductor: sequencerConductor,
+		// [Kroma: START]
+		tracer:            derivationPipeline.Tracer(),
+		priorityTxs:       priorityTxs,
+		adaptiveConfDepth: adaptiveConfDepth,
+		statusFeed:        newSyncStatusFeed(),
+		// [Kroma: END]
 	}
 }
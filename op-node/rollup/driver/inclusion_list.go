@@ -0,0 +1,434 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// InclusionListPolicyFIFO orders the priority txs by arrival.
+	InclusionListPolicyFIFO = "fifo"
+	// InclusionListPolicyFee orders the priority txs by gas tip cap, and by arrival for equal tips.
+	InclusionListPolicyFee = "fee"
+)
+
+const (
+	// maxPriorityTxs is the maximum number of submitted txs waiting in the priority tx queue.
+	maxPriorityTxs = 256
+	// maxForwardedTxs is the maximum number of forwarded txs waiting in the priority tx queue.
+	maxForwardedTxs = 64
+	// forwardedGasShare is the percentage of the priority gas of a block the forwarded txs may use.
+	forwardedGasShare = 50
+)
+
+var (
+	ErrPriorityTxQueueFull = errors.New("priority tx queue is full")
+	ErrKnownPriorityTx     = errors.New("priority tx already queued")
+	ErrInclusionListOff    = errors.New("inclusion list is disabled")
+)
+
+// AccountReader reads the L2 state the priority txs are checked against.
+type AccountReader interface {
+	GetTransactionCount(ctx context.Context, address common.Address, blockTag string) (uint64, error)
+	GetBalance(ctx context.Context, address common.Address, blockTag string) (*big.Int, error)
+	// NextBaseFee returns the base fee of the block after the latest block.
+	NextBaseFee(ctx context.Context) (*big.Int, error)
+}
+
+type priorityTx struct {
+	tx     *types.Transaction
+	data   hexutil.Bytes
+	sender common.Address
+	// seq is the arrival order of the tx.
+	seq uint64
+	// suspect is set on the txs of a selection the engine rejected, until they are isolated.
+	suspect bool
+	// preconfirmed is set on the txs forwarded by the other nodes,
+	// which the sequencer promised to include by a block number.
+	preconfirmed bool
+}
+
+// PriorityTxQueue is the inclusion list of the sequencer: it holds the priority txs submitted to the sequencer,
+// e.g. oracle updates and validator reward claims that must land within a bounded time,
+// and selects the ones to force into the next block after the deposits.
+// The txs of a sender are always selected in nonce order, the policy orders the txs of different senders.
+// The priority txs use at most a share of the block gas limit, the txs of the tx pool fill the rest of the block.
+// The txs forwarded by the other nodes are preconfirmed: they have their own slots in the queue and their own share
+// of the priority gas, and are selected after the submitted txs. The submitted txs never use the gas of the block
+// promised to the preconfirmed txs, so the sequencer can keep the promise of including them by a block number.
+type PriorityTxQueue struct {
+	log      log.Logger
+	signer   types.Signer
+	accounts AccountReader
+	policy   string
+	// gasShare is the percentage of the block gas limit the priority txs of a block may use.
+	gasShare uint64
+
+	mu       sync.Mutex
+	txs      map[common.Hash]*priorityTx
+	nextSeq  uint64
+	selected []common.Hash
+	// number is the number of the block the last selection was made for.
+	number uint64
+	// preconfGas is the gas of the txs preconfirmed by block number, for the blocks not built yet.
+	preconfGas map[uint64]uint64
+}
+
+// NewPriorityTxQueue creates a queue with the given policy, fifo if empty, and priority gas share,
+// which must be between 1 and 100. The submitted txs are checked against the state of the accounts reader.
+func NewPriorityTxQueue(log log.Logger, cfg *rollup.Config, accounts AccountReader, policy string, gasShare uint64) *PriorityTxQueue {
+	if policy == "" {
+		policy = InclusionListPolicyFIFO
+	}
+	return &PriorityTxQueue{
+		log:        log,
+		signer:     types.LatestSignerForChainID(cfg.L2ChainID),
+		accounts:   accounts,
+		policy:     policy,
+		gasShare:   gasShare,
+		txs:        make(map[common.Hash]*priorityTx),
+		preconfGas: make(map[uint64]uint64),
+	}
+}
+
+func CheckInclusionListPolicy(policy string) error {
+	switch policy {
+	case "", InclusionListPolicyFIFO, InclusionListPolicyFee:
+		return nil
+	default:
+		return fmt.Errorf("unknown inclusion list policy %q", policy)
+	}
+}
+
+// Submit adds a signed tx to the queue, to be included in one of the next blocks. gasLimit is the block gas limit
+// at the unsafe head. The tx must pass the static checks of the tx pool, follow the account nonce or a queued tx
+// of the sender, and the sender must be able to pay for it and for its queued txs,
+// so the queue cannot be filled with txs that can never be included.
+func (q *PriorityTxQueue) Submit(ctx context.Context, data hexutil.Bytes, gasLimit uint64) (common.Hash, error) {
+	ptx, nonce, balance, err := q.prepare(ctx, data, gasLimit)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.insert(ptx, nonce, balance); err != nil {
+		return ptx.tx.Hash(), err
+	}
+	q.log.Info("Queued priority tx", "hash", ptx.tx.Hash(), "sender", ptx.sender, "nonce", ptx.tx.Nonce(), "queued", len(q.txs))
+	return ptx.tx.Hash(), nil
+}
+
+// Preconfirm adds a signed tx forwarded by another node to the queue like Submit, and returns the number of the block
+// the tx is promised to be included by. head is the unsafe head and gasLimit the block gas limit at the head. The tx is
+// promised to the first block after the head that is not selected yet and has forwarded gas left for it.
+// The promise holds unless the deposits of the block leave less gas than the preconfirmed txs use,
+// the block cannot include sequencer txs, or the engine rejects a block with priority txs.
+func (q *PriorityTxQueue) Preconfirm(ctx context.Context, data hexutil.Bytes, head uint64, gasLimit uint64) (uint64, error) {
+	ptx, nonce, balance, err := q.prepare(ctx, data, gasLimit)
+	if err != nil {
+		return 0, err
+	}
+	ptx.preconfirmed = true
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	capacity := q.forwardedGas(gasLimit)
+	if ptx.tx.Gas() > capacity {
+		return 0, fmt.Errorf("tx gas %d exceeds the preconfirmed gas of a block %d", ptx.tx.Gas(), capacity)
+	}
+	for _, queued := range q.txs {
+		// the txs before it would have to be included first, but do not count as preconfirmed gas
+		if queued.sender == ptx.sender && !queued.preconfirmed {
+			return 0, fmt.Errorf("sender %s has queued priority txs that are not preconfirmed", ptx.sender)
+		}
+	}
+	number := max(head, q.number) + 1
+	for q.preconfGas[number]+ptx.tx.Gas() > capacity {
+		number++
+	}
+	if err := q.insert(ptx, nonce, balance); err != nil {
+		return 0, err
+	}
+	q.preconfGas[number] += ptx.tx.Gas()
+	q.log.Info("Preconfirmed priority tx", "hash", ptx.tx.Hash(), "sender", ptx.sender, "nonce", ptx.tx.Nonce(),
+		"block", number, "queued", len(q.txs))
+	return number, nil
+}
+
+// prepare decodes the tx and checks it against the block gas limit, and the next base fee if the queue has
+// an accounts reader, which it reads the nonce and balance of the sender from.
+func (q *PriorityTxQueue) prepare(ctx context.Context, data hexutil.Bytes, gasLimit uint64) (*priorityTx, uint64, *big.Int, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to decode tx: %w", err)
+	}
+	if tx.IsDepositTx() {
+		return nil, 0, nil, errors.New("deposit txs cannot be submitted as priority txs")
+	}
+	sender, err := types.Sender(q.signer, &tx)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("invalid tx signature: %w", err)
+	}
+	if err := checkStatic(&tx, gasLimit); err != nil {
+		return nil, 0, nil, err
+	}
+	var nonce uint64
+	var balance *big.Int
+	if q.accounts != nil {
+		baseFee, err := q.accounts.NextBaseFee(ctx)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to get next base fee: %w", err)
+		}
+		if tx.GasFeeCapIntCmp(baseFee) < 0 {
+			return nil, 0, nil, fmt.Errorf("%w: fee cap %v, next base fee %v", core.ErrFeeCapTooLow, tx.GasFeeCap(), baseFee)
+		}
+		if nonce, err = q.accounts.GetTransactionCount(ctx, sender, "latest"); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to get nonce of %s: %w", sender, err)
+		}
+		if balance, err = q.accounts.GetBalance(ctx, sender, "latest"); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to get balance of %s: %w", sender, err)
+		}
+	}
+	return &priorityTx{tx: &tx, data: data, sender: sender}, nonce, balance, nil
+}
+
+// checkStatic runs the checks of the tx pool that do not depend on the state,
+// so the engine does not reject the blocks the tx is selected for.
+func checkStatic(tx *types.Transaction, gasLimit uint64) error {
+	if tx.Gas() > gasLimit {
+		return fmt.Errorf("tx gas %d exceeds the block gas limit %d", tx.Gas(), gasLimit)
+	}
+	if tx.GasTipCapIntCmp(tx.GasFeeCap()) > 0 {
+		return fmt.Errorf("%w: tip %v, fee cap %v", core.ErrTipAboveFeeCap, tx.GasTipCap(), tx.GasFeeCap())
+	}
+	intrinsicGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, true, true)
+	if err != nil {
+		return err
+	}
+	if tx.Gas() < intrinsicGas {
+		return fmt.Errorf("%w: gas %d, intrinsic gas %d", core.ErrIntrinsicGas, tx.Gas(), intrinsicGas)
+	}
+	return nil
+}
+
+// insert checks the tx against the queue and the account of the sender, and queues it. The lock must be held.
+func (q *PriorityTxQueue) insert(ptx *priorityTx, nonce uint64, balance *big.Int) error {
+	if _, ok := q.txs[ptx.tx.Hash()]; ok {
+		return ErrKnownPriorityTx
+	}
+	count := 0
+	for _, queued := range q.txs {
+		if queued.preconfirmed == ptx.preconfirmed {
+			count++
+		}
+	}
+	if (ptx.preconfirmed && count >= maxForwardedTxs) || (!ptx.preconfirmed && count >= maxPriorityTxs) {
+		return ErrPriorityTxQueueFull
+	}
+	if q.accounts != nil {
+		if err := q.checkAccount(ptx.tx, ptx.sender, nonce, balance); err != nil {
+			return err
+		}
+	}
+	ptx.seq = q.nextSeq
+	q.nextSeq++
+	q.txs[ptx.tx.Hash()] = ptx
+	return nil
+}
+
+// checkAccount checks the tx can be included after the queued txs of the sender,
+// given the nonce and balance of the sender.
+func (q *PriorityTxQueue) checkAccount(tx *types.Transaction, sender common.Address, nonce uint64, balance *big.Int) error {
+	if tx.Nonce() < nonce {
+		return fmt.Errorf("%w: tx nonce %d, account nonce %d", core.ErrNonceTooLow, tx.Nonce(), nonce)
+	}
+	queued := make(map[uint64]bool)
+	cost := new(big.Int).Set(tx.Cost())
+	for _, ptx := range q.txs {
+		if ptx.sender != sender {
+			continue
+		}
+		if ptx.tx.Nonce() == tx.Nonce() {
+			return fmt.Errorf("priority tx %s with nonce %d already queued", ptx.tx.Hash(), tx.Nonce())
+		}
+		queued[ptx.tx.Nonce()] = true
+		cost.Add(cost, ptx.tx.Cost())
+	}
+	if tx.Nonce() > nonce && !queued[tx.Nonce()-1] {
+		return fmt.Errorf("%w: tx nonce %d, account nonce %d", core.ErrNonceTooHigh, tx.Nonce(), nonce)
+	}
+	if balance.Cmp(cost) < 0 {
+		return fmt.Errorf("%w: balance %v, cost of queued txs %v", core.ErrInsufficientFunds, balance, cost)
+	}
+	return nil
+}
+
+// Select returns the priority txs to force into the block with the given number and gas limit, after deposits
+// that use the given gas. The priority txs use at most the gas share of the block gas limit, and never more than
+// the gas the deposits leave. The selection is kept until the txs are included or the engine rejects them.
+func (q *PriorityTxQueue) Select(number uint64, gasLimit uint64, depositGas uint64) []hexutil.Bytes {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// the block may be built again after a reorg or a rejection, the promises are kept for it and the later blocks
+	q.number = number
+	for n := range q.preconfGas {
+		if n < number {
+			delete(q.preconfGas, n)
+		}
+	}
+
+	// the txs of each sender in nonce order
+	bySender := make(map[common.Address][]*priorityTx)
+	isolating := false
+	for _, ptx := range q.txs {
+		bySender[ptx.sender] = append(bySender[ptx.sender], ptx)
+		isolating = isolating || ptx.suspect
+	}
+	heads := make([]*priorityTx, 0, len(bySender))
+	for sender, txs := range bySender {
+		sort.Slice(txs, func(i, j int) bool { return txs[i].tx.Nonce() < txs[j].tx.Nonce() })
+		bySender[sender] = txs[1:]
+		// while the txs of a rejected selection are isolated, only they are selected
+		if isolating && !txs[0].suspect {
+			continue
+		}
+		heads = append(heads, txs[0])
+	}
+
+	budget := gasLimit / 100 * q.gasShare
+	if depositGas >= gasLimit {
+		budget = 0
+	} else if left := gasLimit - depositGas; left < budget {
+		budget = left
+	}
+	forwardedBudget := min(q.forwardedGas(gasLimit), budget)
+	// the gas promised to the preconfirmed txs of the block is kept for them
+	submittedBudget := budget - min(q.preconfGas[number], forwardedBudget)
+	var out []hexutil.Bytes
+	q.selected = q.selected[:0]
+	for len(heads) > 0 {
+		best := 0
+		for i := range heads {
+			if q.before(heads[i], heads[best]) {
+				best = i
+			}
+		}
+		next := heads[best]
+		gas := next.tx.Gas()
+		if gas > budget || (next.preconfirmed && gas > forwardedBudget) || (!next.preconfirmed && gas > submittedBudget) {
+			// the later txs of the sender cannot be included before this one
+			heads = append(heads[:best], heads[best+1:]...)
+			continue
+		}
+		budget -= gas
+		if next.preconfirmed {
+			forwardedBudget -= gas
+		} else {
+			submittedBudget -= gas
+		}
+		out = append(out, next.data)
+		q.selected = append(q.selected, next.tx.Hash())
+		if isolating {
+			// one suspect tx at a time, so a rejection identifies the invalid tx
+			break
+		}
+
+		if rest := bySender[next.sender]; len(rest) > 0 {
+			heads[best] = rest[0]
+			bySender[next.sender] = rest[1:]
+		} else {
+			heads = append(heads[:best], heads[best+1:]...)
+		}
+	}
+	if len(out) > 0 {
+		q.log.Debug("Selected priority txs", "count", len(out), "queued", len(q.txs), "policy", q.policy, "isolating", isolating)
+	}
+	return out
+}
+
+// forwardedGas returns the gas the forwarded txs may use in a block with the given gas limit.
+func (q *PriorityTxQueue) forwardedGas(gasLimit uint64) uint64 {
+	return gasLimit / 100 * q.gasShare / 100 * forwardedGasShare
+}
+
+// before returns whether a is ordered before b: the submitted txs first, by the policy,
+// then the preconfirmed txs, in the order they were promised.
+func (q *PriorityTxQueue) before(a, b *priorityTx) bool {
+	if a.preconfirmed != b.preconfirmed {
+		return !a.preconfirmed
+	}
+	if a.preconfirmed {
+		return a.seq < b.seq
+	}
+	if q.policy == InclusionListPolicyFee {
+		if c := a.tx.GasTipCapCmp(b.tx); c != 0 {
+			return c > 0
+		}
+	}
+	return a.seq < b.seq
+}
+
+// Included removes the txs of a sealed block from the queue.
+func (q *PriorityTxQueue) Included(txs []eth.Data) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.txs) == 0 {
+		return
+	}
+	for _, data := range txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(data); err != nil {
+			continue
+		}
+		if _, ok := q.txs[tx.Hash()]; ok {
+			delete(q.txs, tx.Hash())
+			q.log.Info("Included priority tx", "hash", tx.Hash())
+		}
+	}
+	q.selected = q.selected[:0]
+}
+
+// Rejected handles the rejection of the block attributes with the last selection by the engine,
+// so an invalid priority tx does not block the sequencer. The engine does not tell which tx is invalid:
+// a rejected tx that was selected alone is dropped, the txs of a larger selection are kept
+// and selected one at a time in the next blocks, until the invalid ones are found.
+func (q *PriorityTxQueue) Rejected() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.selected) == 1 {
+		hash := q.selected[0]
+		if ptx, ok := q.txs[hash]; ok {
+			delete(q.txs, hash)
+			q.log.Warn("Dropped priority tx the engine rejected", "hash", hash, "preconfirmed", ptx.preconfirmed)
+		}
+	} else if len(q.selected) > 1 {
+		for _, hash := range q.selected {
+			if ptx, ok := q.txs[hash]; ok {
+				ptx.suspect = true
+			}
+		}
+		q.log.Warn("Engine rejected priority txs, selecting them one at a time", "count", len(q.selected))
+	}
+	q.selected = q.selected[:0]
+}
+
+// Len returns the number of queued priority txs.
+func (q *PriorityTxQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.txs)
+}
@@ -0,0 +1,301 @@
+package driver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testGasLimit is the block gas limit the test txs are submitted at.
+const testGasLimit = 30_000_000
+
+type testAccounts struct {
+	nonces   map[common.Address]uint64
+	balances map[common.Address]*big.Int
+	baseFee  int64
+}
+
+func (a *testAccounts) GetTransactionCount(_ context.Context, address common.Address, _ string) (uint64, error) {
+	return a.nonces[address], nil
+}
+
+func (a *testAccounts) GetBalance(_ context.Context, address common.Address, _ string) (*big.Int, error) {
+	if b, ok := a.balances[address]; ok {
+		return b, nil
+	}
+	return big.NewInt(1e18), nil
+}
+
+func (a *testAccounts) NextBaseFee(_ context.Context) (*big.Int, error) {
+	return big.NewInt(a.baseFee), nil
+}
+
+func signPriorityTx(t *testing.T, cfg *rollup.Config, key *ecdsa.PrivateKey, nonce uint64, tip int64, gas uint64) hexutil.Bytes {
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(cfg.L2ChainID), &types.DynamicFeeTx{
+		ChainID:   cfg.L2ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: big.NewInt(tip + 1000),
+		Gas:       gas,
+		To:        &common.Address{},
+	})
+	require.NoError(t, err)
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return data
+}
+
+func newTestQueue(t *testing.T, policy string, gasShare uint64) (*PriorityTxQueue, *rollup.Config, *testAccounts) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+	accounts := &testAccounts{nonces: make(map[common.Address]uint64), balances: make(map[common.Address]*big.Int)}
+	return NewPriorityTxQueue(testlog.Logger(t, log.LevelError), cfg, accounts, policy, gasShare), cfg, accounts
+}
+
+func submitAll(t *testing.T, q *PriorityTxQueue, txs ...hexutil.Bytes) {
+	for _, tx := range txs {
+		_, err := q.Submit(context.Background(), tx, testGasLimit)
+		require.NoError(t, err)
+	}
+}
+
+func TestPriorityTxQueueSelect(t *testing.T) {
+	alice, _ := crypto.GenerateKey()
+	bob, _ := crypto.GenerateKey()
+
+	t.Run("fifo", func(t *testing.T) {
+		q, cfg, _ := newTestQueue(t, InclusionListPolicyFIFO, 50)
+		a0 := signPriorityTx(t, cfg, alice, 0, 1, 100_000)
+		b0 := signPriorityTx(t, cfg, bob, 0, 1, 100_000)
+		a1 := signPriorityTx(t, cfg, alice, 1, 10, 100_000)
+		submitAll(t, q, a0, b0, a1)
+		require.Equal(t, []hexutil.Bytes{a0, b0, a1}, q.Select(1, 1_000_000, 0))
+	})
+
+	t.Run("fee", func(t *testing.T) {
+		q, cfg, _ := newTestQueue(t, InclusionListPolicyFee, 50)
+		a0 := signPriorityTx(t, cfg, alice, 0, 1, 100_000)
+		a1 := signPriorityTx(t, cfg, alice, 1, 10, 100_000)
+		b0 := signPriorityTx(t, cfg, bob, 0, 5, 100_000)
+		submitAll(t, q, a0, a1, b0)
+		// the txs of alice are selected in nonce order, even if a1 pays the highest tip
+		require.Equal(t, []hexutil.Bytes{b0, a0, a1}, q.Select(2, 1_000_000, 0))
+	})
+
+	t.Run("gas share", func(t *testing.T) {
+		q, cfg, _ := newTestQueue(t, InclusionListPolicyFIFO, 30)
+		a0 := signPriorityTx(t, cfg, alice, 0, 1, 200_000)
+		a1 := signPriorityTx(t, cfg, alice, 1, 1, 150_000)
+		b0 := signPriorityTx(t, cfg, bob, 0, 1, 50_000)
+		submitAll(t, q, a0, a1, b0)
+		// 300k of gas is the budget of the priority txs: a1 does not fit after a0, and b0 does not wait for it
+		require.Equal(t, []hexutil.Bytes{a0, b0}, q.Select(3, 1_000_000, 0))
+		// a0 does not fit, and a1 cannot be selected without it
+		require.Empty(t, q.Select(4, 100_000, 0))
+		// the deposits leave 220k of gas
+		require.Equal(t, []hexutil.Bytes{a0}, q.Select(5, 1_000_000, 780_000))
+		require.Empty(t, q.Select(6, 1_000_000, 1_000_000))
+	})
+}
+
+func TestPriorityTxQueueLifecycle(t *testing.T) {
+	q, cfg, _ := newTestQueue(t, InclusionListPolicyFIFO, 100)
+	key, _ := crypto.GenerateKey()
+	tx0 := signPriorityTx(t, cfg, key, 0, 1, 21_000)
+	tx1 := signPriorityTx(t, cfg, key, 1, 1, 21_000)
+
+	_, err := q.Submit(context.Background(), tx0, testGasLimit)
+	require.NoError(t, err)
+	_, err = q.Submit(context.Background(), tx0, testGasLimit)
+	require.ErrorIs(t, err, ErrKnownPriorityTx)
+	_, err = q.Submit(context.Background(), hexutil.Bytes{0x01, 0x02}, testGasLimit)
+	require.Error(t, err)
+	_, err = q.Submit(context.Background(), tx1, testGasLimit)
+	require.NoError(t, err)
+
+	require.Equal(t, []hexutil.Bytes{tx0, tx1}, q.Select(7, 30_000_000, 0))
+	q.Included([]eth.Data{eth.Data(tx0)})
+	require.Equal(t, 1, q.Len())
+
+	require.Equal(t, []hexutil.Bytes{tx1}, q.Select(8, 30_000_000, 0))
+	q.Rejected()
+	require.Equal(t, 0, q.Len())
+}
+
+func TestPriorityTxQueueRejected(t *testing.T) {
+	q, cfg, _ := newTestQueue(t, InclusionListPolicyFIFO, 100)
+	alice, _ := crypto.GenerateKey()
+	bob, _ := crypto.GenerateKey()
+	carol, _ := crypto.GenerateKey()
+	a0 := signPriorityTx(t, cfg, alice, 0, 1, 21_000)
+	b0 := signPriorityTx(t, cfg, bob, 0, 1, 21_000)
+	b1 := signPriorityTx(t, cfg, bob, 1, 1, 21_000)
+	submitAll(t, q, a0, b0, b1)
+
+	require.Equal(t, []hexutil.Bytes{a0, b0, b1}, q.Select(9, 30_000_000, 0))
+	// the engine rejects the selection: the txs are kept, and selected one at a time
+	q.Rejected()
+	require.Equal(t, 3, q.Len())
+	c0 := signPriorityTx(t, cfg, carol, 0, 1, 21_000)
+	submitAll(t, q, c0)
+
+	require.Equal(t, []hexutil.Bytes{a0}, q.Select(10, 30_000_000, 0))
+	q.Included([]eth.Data{eth.Data(a0)})
+	require.Equal(t, []hexutil.Bytes{b0}, q.Select(11, 30_000_000, 0))
+	// b0 alone is rejected: only b0 is dropped
+	q.Rejected()
+	require.Equal(t, 2, q.Len())
+	require.Equal(t, []hexutil.Bytes{b1}, q.Select(12, 30_000_000, 0))
+	q.Included([]eth.Data{eth.Data(b1)})
+	// the txs of the rejected selection are isolated, the other txs are selected as usual again
+	require.Equal(t, []hexutil.Bytes{c0}, q.Select(13, 30_000_000, 0))
+}
+
+func TestPriorityTxQueueAccountChecks(t *testing.T) {
+	q, cfg, accounts := newTestQueue(t, InclusionListPolicyFIFO, 100)
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	accounts.nonces[sender] = 5
+
+	_, err := q.Submit(context.Background(), signPriorityTx(t, cfg, key, 4, 1, 21_000), testGasLimit)
+	require.ErrorIs(t, err, core.ErrNonceTooLow)
+	_, err = q.Submit(context.Background(), signPriorityTx(t, cfg, key, 6, 1, 21_000), testGasLimit)
+	require.ErrorIs(t, err, core.ErrNonceTooHigh)
+	submitAll(t, q, signPriorityTx(t, cfg, key, 5, 1, 21_000))
+	// a tx that follows a queued tx of the sender
+	submitAll(t, q, signPriorityTx(t, cfg, key, 6, 1, 21_000))
+	_, err = q.Submit(context.Background(), signPriorityTx(t, cfg, key, 6, 2, 21_000), testGasLimit)
+	require.ErrorContains(t, err, "already queued")
+
+	// the sender must be able to pay for all its queued txs
+	accounts.balances[sender] = big.NewInt(3 * 21_000 * 1001)
+	_, err = q.Submit(context.Background(), signPriorityTx(t, cfg, key, 7, 1, 21_000), testGasLimit)
+	require.NoError(t, err)
+	_, err = q.Submit(context.Background(), signPriorityTx(t, cfg, key, 8, 1, 21_000), testGasLimit)
+	require.ErrorIs(t, err, core.ErrInsufficientFunds)
+}
+
+func TestPriorityTxQueueRejectsOtherChain(t *testing.T) {
+	q, _, _ := newTestQueue(t, InclusionListPolicyFIFO, 100)
+	key, _ := crypto.GenerateKey()
+	otherCfg := &rollup.Config{L2ChainID: big.NewInt(902)}
+	_, err := q.Submit(context.Background(), signPriorityTx(t, otherCfg, key, 0, 1, 21_000), testGasLimit)
+	require.Error(t, err)
+}
+
+func TestPriorityTxQueuePreconfirm(t *testing.T) {
+	q, cfg, _ := newTestQueue(t, InclusionListPolicyFee, 50)
+	alice, _ := crypto.GenerateKey()
+	bob, _ := crypto.GenerateKey()
+	carol, _ := crypto.GenerateKey()
+	ctx := context.Background()
+
+	// 200k of gas can be preconfirmed per block, half of the priority gas
+	a0 := signPriorityTx(t, cfg, alice, 0, 1, 120_000)
+	number, err := q.Preconfirm(ctx, a0, 9, 800_000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), number)
+	a1 := signPriorityTx(t, cfg, alice, 1, 1, 60_000)
+	number, err = q.Preconfirm(ctx, a1, 9, 800_000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), number)
+	// block 10 has no preconfirmed gas left for it
+	b0 := signPriorityTx(t, cfg, bob, 0, 1, 100_000)
+	number, err = q.Preconfirm(ctx, b0, 9, 800_000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), number)
+	_, err = q.Preconfirm(ctx, signPriorityTx(t, cfg, carol, 0, 1, 300_000), 9, 800_000)
+	require.ErrorContains(t, err, "exceeds the preconfirmed gas")
+
+	// the submitted txs are selected before the preconfirmed txs
+	c0 := signPriorityTx(t, cfg, carol, 0, 1, 21_000)
+	submitAll(t, q, c0)
+	_, err = q.Preconfirm(ctx, signPriorityTx(t, cfg, carol, 1, 1, 21_000), 9, 800_000)
+	require.ErrorContains(t, err, "not preconfirmed")
+	require.Equal(t, []hexutil.Bytes{c0, a0, a1}, q.Select(10, 800_000, 0))
+
+	// block 10 is being built: the next tx is promised to block 11, which has 100k of preconfirmed gas left
+	dave, _ := crypto.GenerateKey()
+	d0 := signPriorityTx(t, cfg, dave, 0, 1, 100_000)
+	number, err = q.Preconfirm(ctx, d0, 9, 800_000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), number)
+	q.Included([]eth.Data{eth.Data(c0), eth.Data(a0), eth.Data(a1)})
+	require.Equal(t, []hexutil.Bytes{b0, d0}, q.Select(11, 800_000, 0))
+	q.Included([]eth.Data{eth.Data(b0), eth.Data(d0)})
+	require.Zero(t, q.Len())
+}
+
+func TestPriorityTxQueueForwardedBudget(t *testing.T) {
+	q, cfg, _ := newTestQueue(t, InclusionListPolicyFIFO, 50)
+	ctx := context.Background()
+	keys := make([]*ecdsa.PrivateKey, maxForwardedTxs+5)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+
+	// of the 400k of priority gas, the submitted txs may use the 250k that is not promised to the forwarded txs
+	f0 := signPriorityTx(t, cfg, keys[0], 0, 1, 150_000)
+	number, err := q.Preconfirm(ctx, f0, 9, 800_000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), number)
+	s0 := signPriorityTx(t, cfg, keys[1], 0, 1, 300_000)
+	s1 := signPriorityTx(t, cfg, keys[2], 0, 1, 100_000)
+	submitAll(t, q, s0, s1)
+	require.Equal(t, []hexutil.Bytes{s1, f0}, q.Select(10, 800_000, 0))
+	// the promise is kept when the block is built again
+	require.Equal(t, []hexutil.Bytes{s1, f0}, q.Select(10, 800_000, 0))
+	q.Included([]eth.Data{eth.Data(s1), eth.Data(f0)})
+	require.Equal(t, []hexutil.Bytes{s0}, q.Select(11, 800_000, 0))
+	q.Included([]eth.Data{eth.Data(s0)})
+
+	// the forwarded txs cannot take the slots of the submitted txs
+	for i := 0; i < maxForwardedTxs; i++ {
+		_, err := q.Preconfirm(ctx, signPriorityTx(t, cfg, keys[3+i], 0, 1, 21_000), 11, 800_000)
+		require.NoError(t, err)
+	}
+	_, err = q.Preconfirm(ctx, signPriorityTx(t, cfg, keys[3+maxForwardedTxs], 0, 1, 21_000), 11, 800_000)
+	require.ErrorIs(t, err, ErrPriorityTxQueueFull)
+	submitAll(t, q, signPriorityTx(t, cfg, keys[4+maxForwardedTxs], 0, 1, 21_000))
+}
+
+func TestPriorityTxQueueStaticChecks(t *testing.T) {
+	q, cfg, accounts := newTestQueue(t, InclusionListPolicyFIFO, 100)
+	accounts.baseFee = 1000
+	key, _ := crypto.GenerateKey()
+	submit := func(tip int64, feeCap int64, gas uint64) error {
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(cfg.L2ChainID), &types.DynamicFeeTx{
+			ChainID:   cfg.L2ChainID,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(feeCap),
+			Gas:       gas,
+			To:        &common.Address{},
+		})
+		require.NoError(t, err)
+		data, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		_, err = q.Submit(context.Background(), data, testGasLimit)
+		return err
+	}
+
+	require.ErrorIs(t, submit(1, 999, 21_000), core.ErrFeeCapTooLow)
+	require.ErrorIs(t, submit(1001, 1000, 21_000), core.ErrTipAboveFeeCap)
+	require.ErrorIs(t, submit(1, 1000, 20_999), core.ErrIntrinsicGas)
+	require.ErrorContains(t, submit(1, 1000, testGasLimit+1), "exceeds the block gas limit")
+	_, err := q.Preconfirm(context.Background(), signPriorityTx(t, cfg, key, 0, 1, 20_999), 9, testGasLimit)
+	require.ErrorIs(t, err, core.ErrIntrinsicGas)
+	require.Equal(t, 0, q.Len())
+	require.NoError(t, submit(1, 1000, 21_000))
+}
@@ -1,11 +1,18 @@
 package driver
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// [Kroma: START]
+// maxRecentL1Heads is the number of the recent L1 heads whose hashes are kept to find the depth of an L1 reorg.
+const maxRecentL1Heads = 64
+
+// [Kroma: END]
+
 type L1Metrics interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordL1Ref(name string, ref eth.L1BlockRef)
@@ -20,12 +27,24 @@ type L1State struct {
 	l1Head      eth.L1BlockRef
 	l1Safe      eth.L1BlockRef
 	l1Finalized eth.L1BlockRef
+
+	// [Kroma: START]
+	// reorgListener is notified of the L1 reorgs, nil if no component follows them.
+	reorgListener interface {
+		OnL1Reorg(depth uint64, head eth.L1BlockRef)
+	}
+	// recentHeads are the hashes of the recent L1 heads by number, on the chain of the current head.
+	recentHeads map[uint64]common.Hash
+	// [Kroma: END]
 }
 
 func NewL1State(log log.Logger, metrics L1Metrics) *L1State {
 	return &L1State{
 		log:     log,
 		metrics: metrics,
+		// [Kroma: START]
+		recentHeads: make(map[uint64]common.Hash),
+		// [Kroma: END]
 	}
 }
 
@@ -43,14 +62,59 @@ func (s *L1State) HandleNewL1HeadBlock(head eth.L1BlockRef) {
 		if s.l1Head.Number >= head.Number {
 			s.metrics.RecordL1ReorgDepth(s.l1Head.Number - head.Number)
 		}
+		// [Kroma: START]
+		if depth, ok := s.reorgDepth(head); ok && s.reorgListener != nil {
+			s.reorgListener.OnL1Reorg(depth, head)
+		}
+		// [Kroma: END]
 		// New L1 block is not the same as the current head or a single step linear extension.
 		// This could either be a long L1 extension, or a reorg, or we simply missed a head update.
 		s.log.Warn("L1 head signal indicates a possible L1 re-org", "old_l1_head", s.l1Head, "new_l1_head_parent", head.ParentHash, "new_l1_head", head)
 	}
 	s.metrics.RecordL1Ref("l1_head", head)
 	s.l1Head = head
+	// [Kroma: START]
+	s.trackHead(head)
+	// [Kroma: END]
+}
+
+// [Kroma: START]
+
+// reorgDepth returns the number of the blocks of the current chain that the new head replaces, if it is not
+// an extension of the current chain. The parent hash of the new head is compared with the tracked head at its
+// height, so a reorg to a longer chain is detected as well as a reorg to a shorter one.
+// A new head more than a block above the current head may hide a reorg, which cannot be told from missed head
+// updates without fetching the blocks in between, so it is not reported.
+func (s *L1State) reorgDepth(head eth.L1BlockRef) (uint64, bool) {
+	if head.Number == 0 || head.Number > s.l1Head.Number+1 {
+		return 0, false
+	}
+	// the number of the blocks from the new head to the old head, which are replaced
+	replaced := s.l1Head.Number + 1 - head.Number
+	parent, ok := s.recentHeads[head.Number-1]
+	if ok && parent == head.ParentHash {
+		return replaced, true
+	}
+	if ok {
+		// the parent is replaced too
+		return replaced + 1, true
+	}
+	// the parent is older than the tracked heads, so only a lower bound of the depth is known
+	return max(replaced, 1), true
+}
+
+// trackHead records the new head, and forgets the heads it replaces and the heads that are too old.
+func (s *L1State) trackHead(head eth.L1BlockRef) {
+	for number := range s.recentHeads {
+		if number >= head.Number || number+maxRecentL1Heads <= head.Number {
+			delete(s.recentHeads, number)
+		}
+	}
+	s.recentHeads[head.Number] = head.Hash
 }
 
+// [Kroma: END]
+
 func (s *L1State) HandleNewL1SafeBlock(safe eth.L1BlockRef) {
 	s.log.Info("New L1 safe block", "l1_safe", safe)
 	s.metrics.RecordL1Ref("l1_safe", safe)
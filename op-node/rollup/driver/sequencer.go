@@ -47,6 +47,11 @@ type Sequencer struct {
 	timeNow func() time.Time
 
 	nextAction time.Time
+
+	// [Kroma: START]
+	// inclusionList is the queue of the priority txs the attributes builder forces into the blocks, nil if disabled.
+	inclusionList *PriorityTxQueue
+	// [Kroma: END]
 }
 
 func NewSequencer(log log.Logger, rollupCfg *rollup.Config, engine derive.EngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics) *Sequencer {
@@ -107,6 +112,12 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	withParent := derive.NewAttributesWithParent(attrs, l2Head, false)
 	errTyp, err := d.engine.StartPayload(ctx, l2Head, withParent, false)
 	if err != nil {
+		// [Kroma: START]
+		if errTyp == derive.BlockInsertPayloadErr && d.inclusionList != nil {
+			// the engine rejects attributes with a priority tx that cannot be included
+			d.inclusionList.Rejected()
+		}
+		// [Kroma: END]
 		return fmt.Errorf("failed to start building on top of L2 chain %s, error (%d): %w", l2Head, errTyp, err)
 	}
 	return nil
@@ -120,6 +131,11 @@ func (d *Sequencer) CompleteBuildingBlock(ctx context.Context, agossip async.Asy
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete building block: error (%d): %w", errTyp, err)
 	}
+	// [Kroma: START]
+	if d.inclusionList != nil {
+		d.inclusionList.Included(envelope.ExecutionPayload.Transactions)
+	}
+	// [Kroma: END]
 	return envelope, nil
 }
 
@@ -11,7 +11,10 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
@@ -20,6 +23,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
 var (
@@ -33,6 +37,20 @@ type SyncStatus = eth.SyncStatus
 // sealingDuration defines the expected time it takes to seal the block
 const sealingDuration = time.Millisecond * 50
 
+// [Kroma: START]
+var tracer = tracing.Tracer("op-node/rollup/driver")
+
+// endStepSpan ends the span of a derivation step. Running out of data is the idle state of the pipeline, not an error.
+func endStepSpan(span trace.Span, err error) {
+	if err == io.EOF || errors.Is(err, derive.NotEnoughData) || errors.Is(err, derive.EngineELSyncing) {
+		span.SetAttributes(attribute.String("idle", err.Error()))
+		err = nil
+	}
+	tracing.EndSpan(span, err)
+}
+
+// [Kroma: END]
+
 type Driver struct {
 	l1State L1StateIface
 
@@ -40,6 +58,19 @@ type Driver struct {
 	// The derivation pipeline determines the new l2Safe.
 	derivation DerivationPipeline
 
+	// [Kroma: START]
+	// tracer records the steps of the derivation stages, it is safe to read outside the event loop.
+	tracer *derive.Tracer
+	// priorityTxs is the inclusion list of the sequencer, nil if sequencing is disabled.
+	priorityTxs *PriorityTxQueue
+	// adaptiveConfDepth is the L1 confirmation depth of the sequencer in the adaptive mode, nil otherwise.
+	adaptiveConfDepth *adaptiveConfDepth
+	// l1Participation is the source of the L1 participation the adaptive confirmation depth follows, nil if unavailable.
+	l1Participation L1ParticipationSource
+	// statusFeed streams the changes of the sync status to the subscribers.
+	statusFeed *syncStatusFeed
+	// [Kroma: END]
+
 	// The engine controller is used by the sequencer & derivation components.
 	// We will also use it for EL sync in a future PR.
 	engineController *derive.EngineController
@@ -139,6 +170,16 @@ func (s *Driver) Start() error {
 	s.wg.Add(1)
 	go s.eventLoop()
 
+	// [Kroma: START]
+	if s.adaptiveConfDepth != nil && s.l1Participation != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.adaptiveConfDepth.pollParticipation(s.driverCtx, s.l1Participation)
+		}()
+	}
+	// [Kroma: END]
+
 	return nil
 }
 
@@ -210,6 +251,16 @@ func (s *Driver) eventLoop() {
 
 	defer s.driverCancel()
 
+	// [Kroma: START]
+	// Sync the execution engine to the checkpoint first, derivation starts from the checkpoint afterwards.
+	if s.syncCfg.Checkpoint != nil {
+		if err := SyncToCheckpoint(s.driverCtx, s.log, s.config, s.l2, s.syncCfg.Checkpoint, checkpointPollInterval); err != nil {
+			s.log.Error("Failed to sync to checkpoint", "err", err)
+			return
+		}
+	}
+	// [Kroma: END]
+
 	// stepReqCh is used to request that the driver attempts to step forward by one L1 block.
 	stepReqCh := make(chan struct{}, 1)
 
@@ -272,6 +323,9 @@ func (s *Driver) eventLoop() {
 		if s.driverCtx.Err() != nil { // don't try to schedule/handle more work when we are closing.
 			return
 		}
+		// [Kroma: START]
+		s.statusFeed.update(s.syncStatus())
+		// [Kroma: END]
 
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
@@ -314,6 +368,9 @@ func (s *Driver) eventLoop() {
 			_, err := s.sequencer.RunNextSequencerAction(s.driverCtx, s.asyncGossiper, s.sequencerConductor)
 			if errors.Is(err, derive.ErrReset) {
 				s.derivation.Reset()
+				// [Kroma: START]
+				s.statusFeed.send(eth.SyncStatusEventReset, s.syncStatus(), err)
+				// [Kroma: END]
 			} else if err != nil {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
@@ -370,7 +427,17 @@ func (s *Driver) eventLoop() {
 			}
 			s.metrics.SetDerivationIdle(false)
 			s.log.Debug("Derivation process step", "onto_origin", s.derivation.Origin(), "attempts", stepAttempts)
+			/* [Kroma: START]
 			err := s.derivation.Step(s.driverCtx)
+			[Kroma: END] */
+			// [Kroma: START]
+			stepCtx, span := tracer.Start(s.driverCtx, "derivation.step", trace.WithAttributes(
+				attribute.String("origin", s.derivation.Origin().String()),
+				attribute.Int("attempts", stepAttempts),
+			))
+			err := s.derivation.Step(stepCtx)
+			endStepSpan(span, err)
+			// [Kroma: END]
 			stepAttempts += 1 // count as attempt by default. We reset to 0 if we are making healthy progress.
 			if err == io.EOF {
 				s.log.Debug("Derivation process went idle", "progress", s.derivation.Origin(), "err", err)
@@ -387,13 +454,22 @@ func (s *Driver) eventLoop() {
 				s.log.Warn("Derivation pipeline is reset", "err", err)
 				s.derivation.Reset()
 				s.metrics.RecordPipelineReset()
+				// [Kroma: START]
+				s.statusFeed.send(eth.SyncStatusEventReset, s.syncStatus(), err)
+				// [Kroma: END]
 				continue
 			} else if err != nil && errors.Is(err, derive.ErrTemporary) {
 				s.log.Warn("Derivation process temporary error", "attempts", stepAttempts, "err", err)
+				// [Kroma: START]
+				s.statusFeed.send(eth.SyncStatusEventDerivationError, s.syncStatus(), err)
+				// [Kroma: END]
 				reqStep()
 				continue
 			} else if err != nil && errors.Is(err, derive.ErrCritical) {
 				s.log.Error("Derivation process critical error", "err", err)
+				// [Kroma: START]
+				s.statusFeed.send(eth.SyncStatusEventDerivationError, s.syncStatus(), err)
+				// [Kroma: END]
 				return
 			} else if err != nil && errors.Is(err, derive.NotEnoughData) {
 				stepAttempts = 0 // don't do a backoff for this error
@@ -401,6 +477,9 @@ func (s *Driver) eventLoop() {
 				continue
 			} else if err != nil {
 				s.log.Error("Derivation process error", "attempts", stepAttempts, "err", err)
+				// [Kroma: START]
+				s.statusFeed.send(eth.SyncStatusEventDerivationError, s.syncStatus(), err)
+				// [Kroma: END]
 				reqStep()
 				continue
 			} else {
@@ -413,6 +492,9 @@ func (s *Driver) eventLoop() {
 			s.log.Warn("Derivation pipeline is manually reset")
 			s.derivation.Reset()
 			s.metrics.RecordPipelineReset()
+			// [Kroma: START]
+			s.statusFeed.send(eth.SyncStatusEventReset, s.syncStatus(), errors.New("manual reset"))
+			// [Kroma: END]
 			close(respCh)
 		case resp := <-s.startSequencer:
 			unsafeHead := s.engineController.UnsafeL2Head().Hash
@@ -649,4 +731,66 @@ func (s *Driver) BlockRefsWithStatus(ctx context.Context, num uint64) (eth.L2Blo
 	}
 }
 
+// DerivationTrace returns a snapshot of the state and recent events of the derivation stages.
+// It does not block the driver event loop, so it can be used to inspect a stalled derivation.
+func (s *Driver) DerivationTrace() *derive.DerivationTrace {
+	return s.tracer.Trace()
+}
+
+// SetL1ParticipationSource sets the source of the L1 participation the adaptive confirmation depth of the sequencer follows.
+// It must be called before the driver is started.
+func (s *Driver) SetL1ParticipationSource(source L1ParticipationSource) {
+	s.l1Participation = source
+}
+
+// SubmitPriorityTx queues a signed tx to be forced into one of the next blocks the sequencer builds.
+func (s *Driver) SubmitPriorityTx(ctx context.Context, tx hexutil.Bytes) (common.Hash, error) {
+	if s.priorityTxs == nil {
+		if !s.driverConfig.SequencerEnabled {
+			return common.Hash{}, errors.New("sequencer is not enabled")
+		}
+		return common.Hash{}, fmt.Errorf("%w: the priority gas share is 0", ErrInclusionListOff)
+	}
+	_, gasLimit, err := s.unsafeHeadGasLimit(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return s.priorityTxs.Submit(ctx, tx, gasLimit)
+}
+
+// PreconfirmTx queues a signed tx to be forced into the blocks the sequencer builds,
+// and returns the number of the block the tx is promised to be included by.
+func (s *Driver) PreconfirmTx(ctx context.Context, tx hexutil.Bytes) (uint64, error) {
+	if s.priorityTxs == nil {
+		if !s.driverConfig.SequencerEnabled {
+			return 0, errors.New("sequencer is not enabled")
+		}
+		return 0, fmt.Errorf("%w: the priority gas share is 0", ErrInclusionListOff)
+	}
+	head, gasLimit, err := s.unsafeHeadGasLimit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return s.priorityTxs.Preconfirm(ctx, tx, head.Number, gasLimit)
+}
+
+// unsafeHeadGasLimit returns the unsafe head and the block gas limit at it.
+func (s *Driver) unsafeHeadGasLimit(ctx context.Context) (eth.L2BlockRef, uint64, error) {
+	head, err := s.l2.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return eth.L2BlockRef{}, 0, fmt.Errorf("failed to get unsafe head: %w", err)
+	}
+	sysCfg, err := s.l2.SystemConfigByL2Hash(ctx, head.Hash)
+	if err != nil {
+		return eth.L2BlockRef{}, 0, fmt.Errorf("failed to get system config of unsafe head %s: %w", head, err)
+	}
+	return head, sysCfg.GasLimit, nil
+}
+
+// SubscribeSyncStatus subscribes to the changes of the heads and the L1 origin, the resets and the derivation errors
+// of the driver. The subscription ends if the subscriber falls behind.
+func (s *Driver) SubscribeSyncStatus() *SyncStatusSubscription {
+	return s.statusFeed.subscribe()
+}
+
 // [Kroma: END]
@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"errors"
+	gosync "sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// syncStatusEventBuffer is the number of events a subscriber can fall behind by.
+const syncStatusEventBuffer = 256
+
+// ErrSyncStatusSubscriberBehind ends the subscriptions that do not keep up with the events,
+// the subscriber has to fetch the sync status to catch up.
+var ErrSyncStatusSubscriberBehind = errors.New("sync status subscriber fell behind")
+
+// SyncStatusSubscription receives the sync status events of the driver.
+type SyncStatusSubscription struct {
+	feed   *syncStatusFeed
+	events chan eth.SyncStatusEvent
+	err    chan error
+}
+
+// Events returns the channel of the events.
+func (s *SyncStatusSubscription) Events() <-chan eth.SyncStatusEvent {
+	return s.events
+}
+
+// Err returns a channel that receives the error that ended the subscription, if any,
+// and is closed when the subscription ends.
+func (s *SyncStatusSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *SyncStatusSubscription) Unsubscribe() {
+	s.feed.remove(s, nil)
+}
+
+// syncStatusFeed sends the changes of the sync status to the subscribers, without blocking the event loop.
+type syncStatusFeed struct {
+	mu   gosync.Mutex
+	subs map[*SyncStatusSubscription]struct{}
+	// last is the last status the changes are computed against, only accessed from the event loop.
+	last *eth.SyncStatus
+}
+
+func newSyncStatusFeed() *syncStatusFeed {
+	return &syncStatusFeed{subs: make(map[*SyncStatusSubscription]struct{})}
+}
+
+func (f *syncStatusFeed) subscribe() *SyncStatusSubscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sub := &SyncStatusSubscription{
+		feed:   f,
+		events: make(chan eth.SyncStatusEvent, syncStatusEventBuffer),
+		err:    make(chan error, 1),
+	}
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+func (f *syncStatusFeed) remove(sub *SyncStatusSubscription, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.removeLocked(sub, err)
+}
+
+func (f *syncStatusFeed) removeLocked(sub *SyncStatusSubscription, err error) {
+	if _, ok := f.subs[sub]; !ok {
+		return
+	}
+	delete(f.subs, sub)
+	if err != nil {
+		sub.err <- err
+	}
+	close(sub.err)
+}
+
+// update sends an event for every head and origin of the status that changed since the last update.
+func (f *syncStatusFeed) update(status *eth.SyncStatus) {
+	last := f.last
+	f.last = status
+	if last == nil {
+		return
+	}
+	if status.UnsafeL2 != last.UnsafeL2 {
+		f.send(eth.SyncStatusEventUnsafeHead, status, nil)
+	}
+	if status.SafeL2 != last.SafeL2 {
+		f.send(eth.SyncStatusEventSafeHead, status, nil)
+	}
+	if status.FinalizedL2 != last.FinalizedL2 {
+		f.send(eth.SyncStatusEventFinalizedHead, status, nil)
+	}
+	if status.CurrentL1 != last.CurrentL1 {
+		f.send(eth.SyncStatusEventL1Origin, status, nil)
+	}
+}
+
+// send sends an event to all subscribers, and ends the subscriptions of the subscribers that fell behind.
+func (f *syncStatusFeed) send(kind eth.SyncStatusEventKind, status *eth.SyncStatus, cause error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.subs) == 0 {
+		return
+	}
+	ev := eth.SyncStatusEvent{
+		Kind:   kind,
+		Time:   uint64(time.Now().UnixMilli()),
+		Status: status,
+	}
+	if cause != nil {
+		ev.Error = cause.Error()
+	}
+	for sub := range f.subs {
+		select {
+		case sub.events <- ev:
+		default:
+			f.removeLocked(sub, ErrSyncStatusSubscriberBehind)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package driver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestSyncStatusFeed(t *testing.T) {
+	feed := newSyncStatusFeed()
+	sub := feed.subscribe()
+
+	status := &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Number: 1}}
+	feed.update(status)
+	require.Empty(t, sub.Events(), "the first status is the baseline")
+
+	next := *status
+	next.UnsafeL2 = eth.L2BlockRef{Number: 2}
+	next.SafeL2 = eth.L2BlockRef{Number: 1}
+	next.CurrentL1 = eth.L1BlockRef{Number: 10}
+	feed.update(&next)
+	feed.update(&next)
+	var kinds []eth.SyncStatusEventKind
+	for len(sub.Events()) > 0 {
+		ev := <-sub.Events()
+		require.Equal(t, &next, ev.Status)
+		kinds = append(kinds, ev.Kind)
+	}
+	require.Equal(t, []eth.SyncStatusEventKind{eth.SyncStatusEventUnsafeHead, eth.SyncStatusEventSafeHead, eth.SyncStatusEventL1Origin}, kinds)
+
+	feed.send(eth.SyncStatusEventReset, &next, errors.New("reorg"))
+	ev := <-sub.Events()
+	require.Equal(t, eth.SyncStatusEventReset, ev.Kind)
+	require.Equal(t, "reorg", ev.Error)
+
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	require.False(t, ok, "unsubscribing closes the error channel")
+	sub.Unsubscribe()
+}
+
+func TestSyncStatusFeedSlowSubscriber(t *testing.T) {
+	feed := newSyncStatusFeed()
+	slow := feed.subscribe()
+	fast := feed.subscribe()
+	for i := 0; i <= syncStatusEventBuffer; i++ {
+		feed.send(eth.SyncStatusEventDerivationError, &eth.SyncStatus{}, errors.New("failed"))
+		<-fast.Events()
+	}
+	require.ErrorIs(t, <-slow.Err(), ErrSyncStatusSubscriberBehind)
+	require.Empty(t, fast.Err())
+	fast.Unsubscribe()
+}
@@ -0,0 +1,105 @@
+package sync
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// checkpointSigningDomain separates checkpoint signatures from any other message signed by the same key.
+var checkpointSigningDomain = [32]byte{31: 3}
+
+var ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// Checkpoint is a trusted L2 output a fresh node starts syncing from, instead of replaying the chain from genesis.
+type Checkpoint struct {
+	L2BlockNumber uint64      `json:"l2BlockNumber"`
+	OutputRoot    eth.Bytes32 `json:"outputRoot"`
+}
+
+// SigningHash returns the hash a checkpoint signer signs:
+// keccak256(domain ++ chain ID ++ big-endian L2 block number ++ output root).
+func (c *Checkpoint) SigningHash(chainID *big.Int) (common.Hash, error) {
+	if chainID.BitLen() > 256 {
+		return common.Hash{}, errors.New("chain_id is too large")
+	}
+	var msg [32 + 32 + 8 + 32]byte
+	copy(msg[:32], checkpointSigningDomain[:])
+	chainID.FillBytes(msg[32:64])
+	binary.BigEndian.PutUint64(msg[64:72], c.L2BlockNumber)
+	copy(msg[72:], c.OutputRoot[:])
+	return crypto.Keccak256Hash(msg[:]), nil
+}
+
+// Verify checks the output is the preimage of the checkpoint output root.
+func (c *Checkpoint) Verify(output *eth.OutputV0) error {
+	if root := eth.OutputRoot(output); root != c.OutputRoot {
+		return fmt.Errorf("%w: output root of block %s is %s, expected %s", ErrInvalidCheckpoint, output.BlockHash, root, c.OutputRoot)
+	}
+	return nil
+}
+
+// SignedCheckpoint is the content of a checkpoint file: a checkpoint signed by a trusted key.
+type SignedCheckpoint struct {
+	Checkpoint
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// SignCheckpoint signs the checkpoint for the given chain.
+func SignCheckpoint(key *ecdsa.PrivateKey, chainID *big.Int, c Checkpoint) (*SignedCheckpoint, error) {
+	h, err := c.SigningHash(chainID)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(h[:], key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+	return &SignedCheckpoint{Checkpoint: c, Signature: sig}, nil
+}
+
+// RecoverSigner recovers the address that signed the checkpoint.
+func (c *SignedCheckpoint) RecoverSigner(chainID *big.Int) (common.Address, error) {
+	if len(c.Signature) != 65 {
+		return common.Address{}, fmt.Errorf("%w: expected 65 signature bytes, got %d", ErrInvalidCheckpoint, len(c.Signature))
+	}
+	h, err := c.SigningHash(chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(h[:], c.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// LoadCheckpointFile reads a JSON encoded SignedCheckpoint, and checks it is signed by the given signer.
+func LoadCheckpointFile(path string, chainID *big.Int, signer common.Address) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+	var signed SignedCheckpoint
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint file: %w", err)
+	}
+	addr, err := signed.RecoverSigner(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if addr != signer {
+		return nil, fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidCheckpoint, addr, signer)
+	}
+	return &signed.Checkpoint, nil
+}
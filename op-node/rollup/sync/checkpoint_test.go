@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestLoadCheckpointFile(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(255)
+
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32{0x1},
+		MessagePasserStorageRoot: eth.Bytes32{0x2},
+		BlockHash:                common.Hash{0x3},
+		NextBlockHash:            common.Hash{0x4},
+	}
+	checkpoint := Checkpoint{L2BlockNumber: 1800, OutputRoot: eth.OutputRoot(output)}
+
+	writeFile := func(t *testing.T, signed *SignedCheckpoint) string {
+		data, err := json.Marshal(signed)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "checkpoint.json")
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return path
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		signed, err := SignCheckpoint(key, chainID, checkpoint)
+		require.NoError(t, err)
+		loaded, err := LoadCheckpointFile(writeFile(t, signed), chainID, signer)
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, *loaded)
+		require.NoError(t, loaded.Verify(output))
+	})
+
+	t.Run("WrongSigner", func(t *testing.T) {
+		signed, err := SignCheckpoint(key, chainID, checkpoint)
+		require.NoError(t, err)
+		_, err = LoadCheckpointFile(writeFile(t, signed), chainID, common.Address{0x42})
+		require.ErrorIs(t, err, ErrInvalidCheckpoint)
+	})
+
+	t.Run("WrongChain", func(t *testing.T) {
+		signed, err := SignCheckpoint(key, big.NewInt(1), checkpoint)
+		require.NoError(t, err)
+		_, err = LoadCheckpointFile(writeFile(t, signed), chainID, signer)
+		require.ErrorIs(t, err, ErrInvalidCheckpoint)
+	})
+
+	t.Run("OutputMismatch", func(t *testing.T) {
+		other := *output
+		other.NextBlockHash = common.Hash{0x5}
+		require.ErrorIs(t, checkpoint.Verify(&other), ErrInvalidCheckpoint)
+	})
+}
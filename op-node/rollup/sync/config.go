@@ -3,6 +3,8 @@ package sync
 import (
 	"fmt"
 	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type Mode int
@@ -70,4 +72,11 @@ type Config struct {
 	// Note: We probably need to detect the condition that snap sync has not complete when we do a restart prior to running sync-start if we are doing
 	// snap sync with a genesis finalization data.
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
+
+	// [Kroma: START]
+	// Checkpoint is the payload of a trusted L2 block the execution engine is synced to before derivation starts,
+	// instead of replaying the chain from genesis. It is verified against a Checkpoint when the node starts,
+	// and nil if checkpoint sync is disabled or the execution engine already has a finalized block.
+	Checkpoint *eth.ExecutionPayloadEnvelope `json:"-"`
+	// [Kroma: END]
 }
@@ -13,6 +13,7 @@ import (
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
@@ -49,7 +50,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	driverConfig := NewDriverConfig(ctx)
 
-	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
+	// [Kroma: START]
+	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx, log)
+	// [Kroma: END]
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
@@ -71,6 +74,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	checkpointConfig, err := NewCheckpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint config: %w", err)
+	}
+
 	/* [Kroma: START]
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
@@ -88,6 +96,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
 			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
 			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			// [Kroma: START]
+			PolicyPath: ctx.String(flags.RPCPolicy.Name),
+			// [Kroma: END]
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
@@ -107,6 +118,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		ConfigPersistence: configPersistence,
 		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
 		Sync:              *syncConfig,
+		Checkpoint:        *checkpointConfig,
 		/* [Kroma: START]
 		RollupHalt:        haltOption,
 		[Kroma: END] */
@@ -117,6 +129,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		ConductorRpcTimeout: ctx.Duration(flags.ConductorRpcTimeoutFlag.Name),
 
 		Plasma: plasma.ReadCLIConfig(ctx),
+		// [Kroma: START]
+		Tracing: tracing.ReadCLIConfig(ctx),
+		// [Kroma: END]
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -201,6 +216,12 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		// [Kroma: START]
+		SequencerPriorityGasShare:    ctx.Uint64(flags.SequencerPriorityGasShareFlag.Name),
+		SequencerInclusionListPolicy: ctx.String(flags.SequencerInclusionListPolicyFlag.Name),
+		SequencerAdaptiveConfDepth:   ctx.Bool(flags.SequencerAdaptiveL1Confs.Name),
+		SequencerMaxConfDepth:        ctx.Uint64(flags.SequencerMaxL1Confs.Name),
+		// [Kroma: END]
 	}
 }
 
@@ -210,6 +231,13 @@ func NewRollupConfigFromCLI(log log.Logger, ctx *cli.Context) (*rollup.Config, e
 	if ctx.Bool(flags.BetaExtraNetworks.Name) {
 		log.Warn("The beta.extra-networks flag is deprecated and can be omitted safely.")
 	}
+	// [Kroma: START]
+	if registry := ctx.String(opflags.NetworkRegistryFlagName); registry != "" {
+		if err := chaincfg.LoadNetworks(registry); err != nil {
+			return nil, err
+		}
+	}
+	// [Kroma: END]
 	rollupConfig, err := NewRollupConfig(log, network, rollupConfigPath)
 	if err != nil {
 		return nil, err
@@ -295,3 +323,24 @@ func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 
 	return cfg, nil
 }
+
+// NewCheckpointConfig loads the checkpoint sync options.
+func NewCheckpointConfig(ctx *cli.Context) (*node.CheckpointConfig, error) {
+	cfg := &node.CheckpointConfig{
+		L2RpcAddr: ctx.String(flags.CheckpointL2RPC.Name),
+		File:      ctx.String(flags.CheckpointFile.Name),
+	}
+	if addr := ctx.String(flags.CheckpointL2OutputOracle.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid L2OutputOracle address: %q", addr)
+		}
+		cfg.L2OutputOracle = common.HexToAddress(addr)
+	}
+	if addr := ctx.String(flags.CheckpointSigner.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid checkpoint signer address: %q", addr)
+		}
+		cfg.Signer = common.HexToAddress(addr)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,423 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrNoHealthyEndpoint = errors.New("all RPC endpoints failed")
+	ErrInconsistentBlock = errors.New("RPC endpoints disagree on block hash")
+)
+
+type FailoverConfig struct {
+	// HealthCheckInterval is the minimum time between two health checks of the endpoints.
+	// Health checks run in the background, triggered by requests, so an idle client makes no requests.
+	HealthCheckInterval time.Duration
+	// MaxHeadLag is the number of blocks an endpoint may be behind the highest known head
+	// before it is considered stale.
+	MaxHeadLag uint64
+	// MaxConsecutiveErrors is the number of consecutive failed requests after which an endpoint is considered unhealthy,
+	// until it serves a request or health check again.
+	MaxConsecutiveErrors int
+	// HealthCheckTimeout is the timeout of a single health check or cross-check request.
+	HealthCheckTimeout time.Duration
+	// CrossCheckBlocks cross-checks the hash of every block requested by number with the other endpoints.
+	CrossCheckBlocks bool
+}
+
+func DefaultFailoverConfig() FailoverConfig {
+	return FailoverConfig{
+		HealthCheckInterval:  12 * time.Second,
+		MaxHeadLag:           2,
+		MaxConsecutiveErrors: 3,
+		HealthCheckTimeout:   5 * time.Second,
+		CrossCheckBlocks:     true,
+	}
+}
+
+type failoverEndpoint struct {
+	index int
+	rpc   RPC
+
+	// all fields below are protected by the FailoverRPC mutex
+	latency         time.Duration // exponentially weighted moving average
+	consecutiveErrs int
+	head            uint64
+	headKnown       bool
+	// inconsistent is set when the endpoint reported a block hash that the majority of endpoints disagrees with.
+	inconsistent bool
+}
+
+// FailoverRPC is a RPC client backed by multiple endpoints of the same chain.
+// Requests are sent to the healthiest endpoint, and fail over to the next one on transport errors.
+// JSON-RPC errors are returned as-is: they are answers of the endpoint, not failures.
+//
+// Endpoints are ranked on their recent errors, the freshness of their head and their latency.
+// The periodic health check also cross-checks the block hashes of the endpoints at a common height,
+// and deprioritizes endpoints that disagree with the majority.
+// With CrossCheckBlocks, the blocks requested by number are also cross-checked per request: the block is accepted
+// if the endpoints that have it agree on its hash by a majority. An endpoint that disagrees with the majority is
+// deprioritized and the request fails over, and the request fails with ErrInconsistentBlock if there is no majority.
+// The blocks requested by hash, by label, or in a batch are not cross-checked: a block fetched by hash is checked
+// against its hash by the caller, and the head of each endpoint is its own.
+// Null results, e.g. of a block an endpoint does not have yet, are answers too and do not fail over.
+//
+// Subscriptions are made with the healthiest endpoint, and end when that endpoint fails:
+// the subscriber has to resubscribe, as with a single endpoint.
+type FailoverRPC struct {
+	log log.Logger
+	cfg FailoverConfig
+
+	mu        sync.Mutex
+	endpoints []*failoverEndpoint
+	lastCheck time.Time
+
+	checking atomic.Bool
+}
+
+var _ RPC = (*FailoverRPC)(nil)
+
+// NewFailoverRPC creates a FailoverRPC over the given endpoints, in order of preference.
+func NewFailoverRPC(lgr log.Logger, cfg FailoverConfig, endpoints ...RPC) *FailoverRPC {
+	f := &FailoverRPC{
+		log: lgr,
+		cfg: cfg,
+	}
+	for i, ep := range endpoints {
+		f.endpoints = append(f.endpoints, &failoverEndpoint{index: i, rpc: ep})
+	}
+	return f
+}
+
+func (f *FailoverRPC) Close() {
+	for _, ep := range f.endpoints {
+		ep.rpc.Close()
+	}
+}
+
+func (f *FailoverRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if f.cfg.CrossCheckBlocks && method == "eth_getBlockByNumber" && len(args) > 0 && isBlockNumber(args[0]) {
+		var raw json.RawMessage
+		err := f.try(ctx, func(ep *failoverEndpoint) error {
+			if err := ep.rpc.CallContext(ctx, &raw, method, args...); err != nil {
+				return err
+			}
+			return f.crossCheckBlock(ctx, ep, raw)
+		})
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(raw, result)
+	}
+	return f.try(ctx, func(ep *failoverEndpoint) error {
+		return ep.rpc.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (f *FailoverRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.try(ctx, func(ep *failoverEndpoint) error {
+		for i := range b {
+			b[i].Error = nil
+		}
+		return ep.rpc.BatchCallContext(ctx, b)
+	})
+}
+
+func (f *FailoverRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := f.try(ctx, func(ep *failoverEndpoint) (err error) {
+		sub, err = ep.rpc.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+// isBlockNumber returns whether the block argument is a number, rather than a label like "latest".
+func isBlockNumber(arg any) bool {
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return false
+	}
+	var n hexutil.Uint64
+	return json.Unmarshal(data, &n) == nil
+}
+
+// crossCheckBlock checks the block the endpoint answered against the blocks of the other endpoints at the same number.
+func (f *FailoverRPC) crossCheckBlock(ctx context.Context, answered *failoverEndpoint, raw json.RawMessage) error {
+	var block *failoverBlockHeader
+	if err := json.Unmarshal(raw, &block); err != nil || block == nil {
+		// null, or not a block: nothing to cross-check
+		return nil
+	}
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		votes = map[common.Hash]int{block.Hash: 1}
+		total = 1
+	)
+	for _, ep := range f.endpoints {
+		if ep == answered {
+			continue
+		}
+		wg.Add(1)
+		go func(ep *failoverEndpoint) {
+			defer wg.Done()
+			cCtx, cancel := context.WithTimeout(ctx, f.cfg.HealthCheckTimeout)
+			defer cancel()
+			var other *failoverBlockHeader
+			// the endpoints that fail or do not have the block yet do not vote
+			if err := ep.rpc.CallContext(cCtx, &other, "eth_getBlockByNumber", block.Number, false); err != nil || other == nil {
+				return
+			}
+			mu.Lock()
+			votes[other.Hash]++
+			total++
+			mu.Unlock()
+		}(ep)
+	}
+	wg.Wait()
+
+	if votes[block.Hash]*2 > total {
+		return nil
+	}
+	for h, n := range votes {
+		if n*2 > total {
+			f.mu.Lock()
+			if !answered.inconsistent {
+				f.log.Warn("RPC endpoint disagrees with majority on block hash", "endpoint", answered.index,
+					"number", block.Number, "hash", block.Hash, "majority", h)
+			}
+			answered.inconsistent = true
+			f.mu.Unlock()
+			break
+		}
+	}
+	return fmt.Errorf("%w: block %d", ErrInconsistentBlock, uint64(block.Number))
+}
+
+// try runs fn against the endpoints in order of health, until one of them does not fail.
+func (f *FailoverRPC) try(ctx context.Context, fn func(ep *failoverEndpoint) error) error {
+	f.maybeCheckHealth()
+
+	var errs error
+	for _, ep := range f.ranked() {
+		start := time.Now()
+		err := fn(ep)
+		if ctx.Err() != nil {
+			// the caller gave up, this says nothing about the endpoint
+			return err
+		}
+		if err != nil && !isJSONRPCError(err) {
+			f.recordFailure(ep, err)
+			errs = errors.Join(errs, fmt.Errorf("endpoint %d: %w", ep.index, err))
+			continue
+		}
+		f.recordSuccess(ep, time.Since(start))
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrNoHealthyEndpoint, errs)
+}
+
+// isJSONRPCError returns true if the error is a response of the endpoint, rather than a failure to get a response.
+func isJSONRPCError(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// ranked returns the endpoints, from the most to the least healthy.
+// Unhealthy endpoints are still included, as a last resort.
+func (f *FailoverRPC) ranked() []*failoverEndpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var bestHead uint64
+	for _, ep := range f.endpoints {
+		if ep.headKnown && !ep.inconsistent {
+			bestHead = max(bestHead, ep.head)
+		}
+	}
+	healthy := func(ep *failoverEndpoint) bool {
+		if ep.inconsistent || ep.consecutiveErrs >= f.cfg.MaxConsecutiveErrors {
+			return false
+		}
+		return !ep.headKnown || ep.head+f.cfg.MaxHeadLag >= bestHead
+	}
+
+	ranked := make([]*failoverEndpoint, len(f.endpoints))
+	copy(ranked, f.endpoints)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if ha, hb := healthy(a), healthy(b); ha != hb {
+			return ha
+		}
+		if a.consecutiveErrs != b.consecutiveErrs {
+			return a.consecutiveErrs < b.consecutiveErrs
+		}
+		return a.latency < b.latency
+	})
+	return ranked
+}
+
+func (f *FailoverRPC) recordSuccess(ep *failoverEndpoint, latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ep.consecutiveErrs >= f.cfg.MaxConsecutiveErrors {
+		f.log.Info("RPC endpoint recovered", "endpoint", ep.index)
+	}
+	ep.consecutiveErrs = 0
+	if ep.latency == 0 {
+		ep.latency = latency
+	} else {
+		ep.latency = (ep.latency*4 + latency) / 5
+	}
+}
+
+func (f *FailoverRPC) recordFailure(ep *failoverEndpoint, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ep.consecutiveErrs++
+	if ep.consecutiveErrs == f.cfg.MaxConsecutiveErrors {
+		f.log.Warn("RPC endpoint is unhealthy, failing over", "endpoint", ep.index, "err", err)
+	} else {
+		f.log.Debug("RPC endpoint request failed", "endpoint", ep.index, "err", err)
+	}
+}
+
+// maybeCheckHealth starts a health check in the background, if the last one is older than the health check interval.
+func (f *FailoverRPC) maybeCheckHealth() {
+	f.mu.Lock()
+	due := time.Since(f.lastCheck) >= f.cfg.HealthCheckInterval
+	f.mu.Unlock()
+	if !due || !f.checking.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer f.checking.Store(false)
+		f.CheckHealth(context.Background())
+	}()
+}
+
+type failoverBlockHeader struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// CheckHealth fetches the head of every endpoint, and cross-checks the block hashes of the endpoints
+// at a common height below their heads.
+func (f *FailoverRPC) CheckHealth(ctx context.Context) {
+	heads := f.fetchBlocks(ctx, func(*failoverEndpoint) any { return "latest" })
+
+	f.mu.Lock()
+	f.lastCheck = time.Now()
+	var (
+		height    uint64
+		responded int
+	)
+	for _, ep := range f.endpoints {
+		head, ok := heads[ep]
+		if !ok {
+			continue
+		}
+		ep.head, ep.headKnown = uint64(head.Number), true
+		if responded == 0 || ep.head < height {
+			height = ep.head
+		}
+		responded++
+	}
+	f.mu.Unlock()
+	if responded < 2 {
+		return
+	}
+
+	// compare a few blocks below the lowest head, so endpoints are not flagged for a reorg of the tip
+	if height > f.cfg.MaxHeadLag {
+		height -= f.cfg.MaxHeadLag
+	} else {
+		height = 0
+	}
+	blocks := f.fetchBlocks(ctx, func(ep *failoverEndpoint) any {
+		if _, ok := heads[ep]; !ok {
+			return nil
+		}
+		return hexutil.Uint64(height)
+	})
+
+	votes := make(map[common.Hash]int)
+	for _, block := range blocks {
+		votes[block.Hash]++
+	}
+	var majority common.Hash
+	for h, n := range votes {
+		if n*2 > len(blocks) {
+			majority = h
+		}
+	}
+	if majority == (common.Hash{}) {
+		if len(votes) > 1 {
+			f.log.Warn("RPC endpoints disagree on block hash, no majority", "number", height, "hashes", len(votes))
+		}
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ep, block := range blocks {
+		inconsistent := block.Hash != majority
+		if inconsistent && !ep.inconsistent {
+			f.log.Warn("RPC endpoint disagrees with majority on block hash", "endpoint", ep.index,
+				"number", height, "hash", block.Hash, "majority", majority)
+		}
+		ep.inconsistent = inconsistent
+	}
+}
+
+// fetchBlocks fetches a block header from every endpoint concurrently, the block being selected by the given function.
+// Endpoints for which the function returns nil are skipped. Endpoints that fail to return a block are not included in the result.
+func (f *FailoverRPC) fetchBlocks(ctx context.Context, blockArg func(ep *failoverEndpoint) any) map[*failoverEndpoint]*failoverBlockHeader {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result = make(map[*failoverEndpoint]*failoverBlockHeader)
+	)
+	for _, ep := range f.endpoints {
+		arg := blockArg(ep)
+		if arg == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(ep *failoverEndpoint) {
+			defer wg.Done()
+			cCtx, cancel := context.WithTimeout(ctx, f.cfg.HealthCheckTimeout)
+			defer cancel()
+			var header *failoverBlockHeader
+			start := time.Now()
+			err := ep.rpc.CallContext(cCtx, &header, "eth_getBlockByNumber", arg, false)
+			if err == nil && header == nil {
+				err = ethereum.NotFound
+			}
+			if err != nil {
+				f.recordFailure(ep, fmt.Errorf("health check failed: %w", err))
+				return
+			}
+			f.recordSuccess(ep, time.Since(start))
+			mu.Lock()
+			result[ep] = header
+			mu.Unlock()
+		}(ep)
+	}
+	wg.Wait()
+	return result
+}
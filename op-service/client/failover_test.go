@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type testRPCError struct {
+	code int
+	msg  string
+}
+
+func (e *testRPCError) Error() string  { return e.msg }
+func (e *testRPCError) ErrorCode() int { return e.code }
+
+// fakeChainRPC serves eth_getBlockByNumber for a chain of the given hashes, and eth_chainId.
+type fakeChainRPC struct {
+	mu     sync.Mutex
+	hashes []common.Hash
+	err    error
+	calls  int
+}
+
+func (f *fakeChainRPC) Close() {}
+
+func (f *fakeChainRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	var value any
+	switch method {
+	case "eth_chainId":
+		value = hexutil.Uint64(901)
+	case "eth_getBlockByNumber":
+		number := uint64(len(f.hashes) - 1)
+		if arg, _ := json.Marshal(args[0]); string(arg) != `"latest"` {
+			var n hexutil.Uint64
+			if err := json.Unmarshal(arg, &n); err != nil {
+				return err
+			}
+			number = uint64(n)
+		}
+		if number < uint64(len(f.hashes)) {
+			value = &failoverBlockHeader{Number: hexutil.Uint64(number), Hash: f.hashes[number]}
+		}
+	default:
+		return &testRPCError{code: -32601, msg: "method not found"}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f *fakeChainRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = f.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (f *fakeChainRPC) EthSubscribe(context.Context, any, ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("notifications not supported")
+}
+
+func (f *fakeChainRPC) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func testChain(n int, fork byte) []common.Hash {
+	hashes := make([]common.Hash, n)
+	for i := range hashes {
+		hashes[i] = common.Hash{fork, byte(i)}
+	}
+	return hashes
+}
+
+func testFailoverConfig() FailoverConfig {
+	cfg := DefaultFailoverConfig()
+	cfg.HealthCheckInterval = time.Hour // only explicit health checks
+	return cfg
+}
+
+func newTestFailoverRPC(t *testing.T, endpoints ...RPC) *FailoverRPC {
+	f := NewFailoverRPC(log.NewLogger(log.DiscardHandler()), testFailoverConfig(), endpoints...)
+	f.lastCheck = time.Now()
+	return f
+}
+
+func TestFailoverRPCFailsOver(t *testing.T) {
+	a := &fakeChainRPC{hashes: testChain(10, 0), err: errors.New("connection refused")}
+	b := &fakeChainRPC{hashes: testChain(10, 0)}
+	f := newTestFailoverRPC(t, a, b)
+
+	var chainID hexutil.Uint64
+	require.NoError(t, f.CallContext(context.Background(), &chainID, "eth_chainId"))
+	require.Equal(t, hexutil.Uint64(901), chainID)
+	require.Equal(t, 1, a.Calls())
+
+	// the failing endpoint is tried after the endpoint without errors
+	require.NoError(t, f.CallContext(context.Background(), &chainID, "eth_chainId"))
+	require.Equal(t, 1, a.Calls())
+
+	// it is preferred again once it recovers
+	a.err = nil
+	b.err = errors.New("timeout")
+	require.NoError(t, f.CallContext(context.Background(), &chainID, "eth_chainId"))
+	require.NoError(t, f.CallContext(context.Background(), &chainID, "eth_chainId"))
+	require.Equal(t, 3, a.Calls())
+	b.err = nil
+
+	// JSON-RPC errors are answers, and are not failed over
+	err := f.CallContext(context.Background(), &chainID, "eth_unknown")
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32601, rpcErr.ErrorCode())
+
+	// fails once all endpoints fail
+	a.err = errors.New("connection refused")
+	b.err = errors.New("timeout")
+	err = f.CallContext(context.Background(), &chainID, "eth_chainId")
+	require.ErrorIs(t, err, ErrNoHealthyEndpoint)
+}
+
+func TestFailoverRPCHealthCheck(t *testing.T) {
+	t.Run("StaleHead", func(t *testing.T) {
+		stale := &fakeChainRPC{hashes: testChain(10, 0)}
+		fresh := &fakeChainRPC{hashes: testChain(20, 0)}
+		f := newTestFailoverRPC(t, stale, fresh)
+		f.CheckHealth(context.Background())
+
+		ranked := f.ranked()
+		require.Equal(t, RPC(fresh), ranked[0].rpc)
+		require.False(t, ranked[1].inconsistent, "a lagging endpoint is not inconsistent")
+	})
+
+	t.Run("InconsistentHash", func(t *testing.T) {
+		a := &fakeChainRPC{hashes: testChain(20, 0)}
+		forked := &fakeChainRPC{hashes: testChain(20, 1)}
+		c := &fakeChainRPC{hashes: testChain(21, 0)}
+		f := newTestFailoverRPC(t, forked, a, c)
+		f.CheckHealth(context.Background())
+
+		ranked := f.ranked()
+		require.Equal(t, RPC(forked), ranked[2].rpc)
+		require.True(t, ranked[2].inconsistent)
+
+		// the endpoint recovers once it agrees with the majority again
+		forked.hashes = testChain(20, 0)
+		f.CheckHealth(context.Background())
+		require.False(t, f.endpoints[0].inconsistent)
+	})
+
+	t.Run("NoMajority", func(t *testing.T) {
+		a := &fakeChainRPC{hashes: testChain(20, 0)}
+		b := &fakeChainRPC{hashes: testChain(20, 1)}
+		f := newTestFailoverRPC(t, a, b)
+		f.CheckHealth(context.Background())
+		require.False(t, f.endpoints[0].inconsistent)
+		require.False(t, f.endpoints[1].inconsistent)
+	})
+}
+
+func TestFailoverRPCCrossCheck(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Majority", func(t *testing.T) {
+		a := &fakeChainRPC{hashes: testChain(20, 1)}
+		b := &fakeChainRPC{hashes: testChain(20, 0)}
+		c := &fakeChainRPC{hashes: testChain(20, 0)}
+		f := newTestFailoverRPC(t, a, b, c)
+		var header *failoverBlockHeader
+		require.NoError(t, f.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(5), false))
+		require.Equal(t, common.Hash{0, 5}, header.Hash)
+		require.True(t, f.endpoints[0].inconsistent)
+
+		// the blocks requested by label are not cross-checked
+		calls := b.Calls() + c.Calls()
+		require.NoError(t, f.CallContext(ctx, &header, "eth_getBlockByNumber", "latest", false))
+		require.Equal(t, calls+1, b.Calls()+c.Calls())
+	})
+
+	t.Run("Lagging", func(t *testing.T) {
+		a := &fakeChainRPC{hashes: testChain(20, 0)}
+		b := &fakeChainRPC{hashes: testChain(10, 1)}
+		f := newTestFailoverRPC(t, a, b)
+		var header *failoverBlockHeader
+		require.NoError(t, f.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(15), false))
+		require.Equal(t, common.Hash{0, 15}, header.Hash)
+		require.NoError(t, f.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(25), false))
+		require.Nil(t, header)
+	})
+
+	t.Run("NoMajority", func(t *testing.T) {
+		a := &fakeChainRPC{hashes: testChain(20, 0)}
+		b := &fakeChainRPC{hashes: testChain(20, 1)}
+		f := newTestFailoverRPC(t, a, b)
+		var header *failoverBlockHeader
+		err := f.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.Uint64(5), false)
+		require.ErrorIs(t, err, ErrInconsistentBlock)
+		require.False(t, f.endpoints[0].inconsistent)
+		require.False(t, f.endpoints[1].inconsistent)
+	})
+}
+
+func TestGethRPCClient(t *testing.T) {
+	a := &fakeChainRPC{hashes: testChain(10, 0), err: errors.New("connection refused")}
+	b := &fakeChainRPC{hashes: testChain(10, 0)}
+	cl, err := NewGethRPCClient(context.Background(), newTestFailoverRPC(t, a, b))
+	require.NoError(t, err)
+	defer cl.Close()
+
+	var chainID hexutil.Uint64
+	require.NoError(t, cl.CallContext(context.Background(), &chainID, "eth_chainId"))
+	require.Equal(t, hexutil.Uint64(901), chainID)
+
+	var header failoverBlockHeader
+	require.NoError(t, cl.CallContext(context.Background(), &header, "eth_getBlockByNumber", hexutil.Uint64(3), false))
+	require.Equal(t, common.Hash{0, 3}, header.Hash)
+
+	err = cl.CallContext(context.Background(), &chainID, "eth_unknown")
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -32601, rpcErr.ErrorCode())
+
+	var latest failoverBlockHeader
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBlockByNumber", Args: []any{"latest", false}, Result: &latest},
+		{Method: "eth_unknown", Result: new(json.RawMessage)},
+	}
+	require.NoError(t, cl.BatchCallContext(context.Background(), batch))
+	require.NoError(t, batch[0].Error)
+	require.Equal(t, hexutil.Uint64(9), latest.Number)
+	require.ErrorAs(t, batch[1].Error, &rpcErr)
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// NewGethRPCClient wraps a RPC into a go-ethereum *rpc.Client, for packages that require one, like ethclient.
+// The returned client behaves like a HTTP client: it does not support subscriptions.
+// Closing it does not close the underlying RPC.
+func NewGethRPCClient(ctx context.Context, c RPC) (*rpc.Client, error) {
+	httpClient := &http.Client{Transport: &rpcTransport{c: c}}
+	// the URL is never dialed: all requests are served by the transport
+	return rpc.DialOptions(ctx, "http://rpc.internal", rpc.WithHTTPClient(httpClient))
+}
+
+type jsonrpcMessage struct {
+	Version string            `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage   `json:"id,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+	Error   *jsonrpcError     `json:"error,omitempty"`
+	Result  json.RawMessage   `json:"result,omitempty"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func toJSONRPCError(err error) *jsonrpcError {
+	out := &jsonrpcError{Code: -32603, Message: err.Error()}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		out.Code = rpcErr.ErrorCode()
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		out.Data = dataErr.ErrorData()
+	}
+	return out
+}
+
+// rpcTransport serves the JSON-RPC requests of a go-ethereum HTTP client with a RPC.
+type rpcTransport struct {
+	c RPC
+}
+
+func (t *rpcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var out any
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		var msgs []*jsonrpcMessage
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			return nil, fmt.Errorf("invalid batch request: %w", err)
+		}
+		if out, err = t.batch(req.Context(), msgs); err != nil {
+			return nil, err
+		}
+	} else {
+		var msg jsonrpcMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+		if out, err = t.call(req.Context(), &msg); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+func (t *rpcTransport) call(ctx context.Context, msg *jsonrpcMessage) (*jsonrpcMessage, error) {
+	var result json.RawMessage
+	err := t.c.CallContext(ctx, &result, msg.Method, rawArgs(msg.Params)...)
+	resp := &jsonrpcMessage{Version: "2.0", ID: msg.ID}
+	if err != nil {
+		if !isJSONRPCError(err) {
+			return nil, err
+		}
+		resp.Error = toJSONRPCError(err)
+	} else {
+		resp.Result = nullIfEmpty(result)
+	}
+	return resp, nil
+}
+
+func (t *rpcTransport) batch(ctx context.Context, msgs []*jsonrpcMessage) ([]*jsonrpcMessage, error) {
+	elems := make([]rpc.BatchElem, len(msgs))
+	results := make([]json.RawMessage, len(msgs))
+	for i, msg := range msgs {
+		elems[i] = rpc.BatchElem{Method: msg.Method, Args: rawArgs(msg.Params), Result: &results[i]}
+	}
+	if err := t.c.BatchCallContext(ctx, elems); err != nil {
+		return nil, err
+	}
+	resp := make([]*jsonrpcMessage, len(msgs))
+	for i, msg := range msgs {
+		resp[i] = &jsonrpcMessage{Version: "2.0", ID: msg.ID}
+		if elems[i].Error != nil {
+			resp[i].Error = toJSONRPCError(elems[i].Error)
+		} else {
+			resp[i].Result = nullIfEmpty(results[i])
+		}
+	}
+	return resp, nil
+}
+
+func rawArgs(params []json.RawMessage) []any {
+	args := make([]any, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	return args
+}
+
+func nullIfEmpty(result json.RawMessage) json.RawMessage {
+	if len(result) == 0 {
+		return json.RawMessage("null")
+	}
+	return result
+}
@@ -6,9 +6,11 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
@@ -33,6 +35,7 @@ type rpcConfig struct {
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	failover         FailoverConfig
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -72,9 +75,19 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithFailoverConfig configures the failover between endpoints, when multiple RPC urls are given.
+// See NewFailoverRPC for more details.
+func WithFailoverConfig(failoverCfg FailoverConfig) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.failover = failoverCfg
+		return nil
+	}
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
+// A comma-separated list of urls creates a FailoverRPC over all of them.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
-	var cfg rpcConfig
+	cfg := rpcConfig{failover: DefaultFailoverConfig()}
 	for i, opt := range opts {
 		if err := opt(&cfg); err != nil {
 			return nil, fmt.Errorf("rpc option %d failed to apply to RPC config: %w", i, err)
@@ -85,13 +98,28 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 		cfg.backoffAttempts = 1
 	}
 
-	underlying, err := dialRPCClientWithBackoff(ctx, lgr, addr, cfg.backoffAttempts, cfg.gethRPCOptions...)
-	if err != nil {
-		return nil, err
+	var wrapped RPC
+	if addrs := SplitRPCAddrs(addr); len(addrs) > 1 {
+		endpoints := make([]RPC, 0, len(addrs))
+		for _, a := range addrs {
+			underlying, err := dialRPCClientWithBackoff(ctx, lgr, a, cfg.backoffAttempts, cfg.gethRPCOptions...)
+			if err != nil {
+				for _, ep := range endpoints {
+					ep.Close()
+				}
+				return nil, err
+			}
+			endpoints = append(endpoints, &BaseRPCClient{c: underlying})
+		}
+		wrapped = NewFailoverRPC(lgr, cfg.failover, endpoints...)
+	} else {
+		underlying, err := dialRPCClientWithBackoff(ctx, lgr, addr, cfg.backoffAttempts, cfg.gethRPCOptions...)
+		if err != nil {
+			return nil, err
+		}
+		wrapped = &BaseRPCClient{c: underlying}
 	}
 
-	var wrapped RPC = &BaseRPCClient{c: underlying}
-
 	if cfg.limit != 0 {
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
@@ -100,13 +128,27 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 }
 
 // NewRPCWithClient builds a new polling client with the given underlying RPC client.
+// If addr is a list of urls, the client polls if any of them is a HTTP url.
 func NewRPCWithClient(ctx context.Context, lgr log.Logger, addr string, underlying RPC, pollInterval time.Duration) (RPC, error) {
-	if httpRegex.MatchString(addr) {
-		underlying = NewPollingClient(ctx, lgr, underlying, WithPollRate(pollInterval))
+	for _, a := range SplitRPCAddrs(addr) {
+		if httpRegex.MatchString(a) {
+			return NewPollingClient(ctx, lgr, underlying, WithPollRate(pollInterval)), nil
+		}
 	}
 	return underlying, nil
 }
 
+// SplitRPCAddrs splits a comma-separated list of RPC urls.
+func SplitRPCAddrs(addr string) []string {
+	var addrs []string
+	for _, a := range strings.Split(addr, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs
+}
+
 // Dials a JSON-RPC endpoint repeatedly, with a backoff, until a client connection is established. Auth is optional.
 func dialRPCClientWithBackoff(ctx context.Context, log log.Logger, addr string, attempts int, opts ...rpc.ClientOption) (*rpc.Client, error) {
 	bOff := retry.Exponential()
@@ -163,6 +205,7 @@ func (b *BaseRPCClient) Close() {
 	b.c.Close()
 }
 
+/* [Kroma: START]
 func (b *BaseRPCClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
 	cCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -174,6 +217,28 @@ func (b *BaseRPCClient) BatchCallContext(ctx context.Context, batch []rpc.BatchE
 	defer cancel()
 	return b.c.BatchCallContext(cCtx, batch)
 }
+[Kroma: END] */
+
+// [Kroma: START]
+var tracer = tracing.Tracer("op-service/client")
+
+func (b *BaseRPCClient) CallContext(ctx context.Context, result any, method string, args ...any) (err error) {
+	ctx, span := tracing.StartRPCClientSpan(ctx, tracer, method, 0)
+	defer func() { tracing.EndSpan(span, err) }()
+	cCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return b.c.CallContext(cCtx, result, method, args...)
+}
+
+func (b *BaseRPCClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) (err error) {
+	ctx, span := tracing.StartRPCClientSpan(ctx, tracer, tracing.BatchMethod, len(batch))
+	defer func() { tracing.EndSpan(span, err) }()
+	cCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	return b.c.BatchCallContext(cCtx, batch)
+}
+
+// [Kroma: END]
 
 func (b *BaseRPCClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
 	return b.c.EthSubscribe(ctx, channel, args...)
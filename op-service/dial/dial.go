@@ -57,7 +57,29 @@ func DialRPCClientWithTimeout(ctx context.Context, timeout time.Duration, log lo
 }
 
 // Dials a JSON-RPC endpoint repeatedly, with a backoff, until a client connection is established. Auth is optional.
+// A comma-separated list of urls dials all of them, and returns a client that fails over between them.
+// The client serves the requests over an in-process HTTP transport, so it does not support subscriptions,
+// unlike the client.FailoverRPC it wraps: callers that subscribe must be given a single url, or use client.NewRPC.
 func dialRPCClientWithBackoff(ctx context.Context, log log.Logger, addr string) (*rpc.Client, error) {
+	addrs := client.SplitRPCAddrs(addr)
+	if len(addrs) <= 1 {
+		return dialSingleRPCClientWithBackoff(ctx, log, addr)
+	}
+	endpoints := make([]client.RPC, 0, len(addrs))
+	for _, a := range addrs {
+		c, err := dialSingleRPCClientWithBackoff(ctx, log, a)
+		if err != nil {
+			for _, ep := range endpoints {
+				ep.Close()
+			}
+			return nil, err
+		}
+		endpoints = append(endpoints, client.NewBaseRPCClient(c))
+	}
+	return client.NewGethRPCClient(ctx, client.NewFailoverRPC(log, client.DefaultFailoverConfig(), endpoints...))
+}
+
+func dialSingleRPCClientWithBackoff(ctx context.Context, log log.Logger, addr string) (*rpc.Client, error) {
 	bOff := retry.Fixed(defaultRetryTime)
 	return retry.Do(ctx, defaultRetryCount, bOff, func() (*rpc.Client, error) {
 		if !client.IsURLAvailable(addr) {
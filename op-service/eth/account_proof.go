@@ -130,6 +130,19 @@ func (res *AccountResult) Verify(stateRoot common.Hash) error {
 	// verify storage proof values, if any, against the storage trie root hash of the account
 	for i, entry := range res.StorageProof {
 		validator := func(val []byte, isZktrie bool) error {
+			// [Kroma: START]
+			// zkTrie stores the value as is, padded to 32 bytes, and proves an absent slot with no value.
+			if isZktrie {
+				var expected []byte
+				if entry.Value.ToInt().Sign() != 0 {
+					expected = common.BigToHash(entry.Value.ToInt()).Bytes()
+				}
+				if !bytes.Equal(expected, val) {
+					return fmt.Errorf("value %d in storage proof does not match proven value at key %s", i, entry.Key)
+				}
+				return nil
+			}
+			// [Kroma: END]
 			_, expected, _, err := rlp.Split(val)
 			if err != nil {
 				return err
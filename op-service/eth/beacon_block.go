@@ -0,0 +1,42 @@
+package eth
+
+import (
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// APISyncAggregate is the sync aggregate of a beacon block: the sync committee members that signed the parent block.
+type APISyncAggregate struct {
+	SyncCommitteeBits hexutil.Bytes `json:"sync_committee_bits"`
+}
+
+// Participation returns the share of the sync committee that signed, between 0 and 1.
+func (a *APISyncAggregate) Participation() float64 {
+	if len(a.SyncCommitteeBits) == 0 {
+		return 0
+	}
+	set := 0
+	for _, b := range a.SyncCommitteeBits {
+		set += bits.OnesCount8(b)
+	}
+	return float64(set) / float64(8*len(a.SyncCommitteeBits))
+}
+
+// ReducedBeaconBlockBody is the part of a beacon block body the node reads.
+type ReducedBeaconBlockBody struct {
+	SyncAggregate APISyncAggregate `json:"sync_aggregate"`
+}
+
+type ReducedBeaconBlock struct {
+	Slot Uint64String           `json:"slot"`
+	Body ReducedBeaconBlockBody `json:"body"`
+}
+
+type ReducedSignedBeaconBlock struct {
+	Message ReducedBeaconBlock `json:"message"`
+}
+
+type APIBeaconBlockResponse struct {
+	Data ReducedSignedBeaconBlock `json:"data"`
+}
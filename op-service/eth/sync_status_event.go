@@ -0,0 +1,28 @@
+package eth
+
+type SyncStatusEventKind string
+
+const (
+	// SyncStatusEventUnsafeHead is emitted when the unsafe L2 head changes.
+	SyncStatusEventUnsafeHead SyncStatusEventKind = "unsafe_head"
+	// SyncStatusEventSafeHead is emitted when the safe L2 head changes.
+	SyncStatusEventSafeHead SyncStatusEventKind = "safe_head"
+	// SyncStatusEventFinalizedHead is emitted when the finalized L2 head changes.
+	SyncStatusEventFinalizedHead SyncStatusEventKind = "finalized_head"
+	// SyncStatusEventL1Origin is emitted when the L1 origin of the derivation changes.
+	SyncStatusEventL1Origin SyncStatusEventKind = "l1_origin"
+	// SyncStatusEventReset is emitted when the derivation pipeline is reset.
+	SyncStatusEventReset SyncStatusEventKind = "reset"
+	// SyncStatusEventDerivationError is emitted when a derivation step fails.
+	SyncStatusEventDerivationError SyncStatusEventKind = "derivation_error"
+)
+
+// SyncStatusEvent is a change of the sync status of the driver, with the sync status after the change.
+type SyncStatusEvent struct {
+	Kind SyncStatusEventKind `json:"kind"`
+	// Time is the unix time of the event, in milliseconds.
+	Time   uint64      `json:"time"`
+	Status *SyncStatus `json:"status"`
+	// Error is the cause of reset and derivation error events.
+	Error string `json:"error,omitempty"`
+}
@@ -13,6 +13,7 @@ import (
 const (
 	RollupConfigFlagName    = "rollup.config"
 	NetworkFlagName         = "network"
+	NetworkRegistryFlagName = "network.registry"
 	CanyonOverrideFlagName  = "override.canyon"
 	DeltaOverrideFlagName   = "override.delta"
 	EcotoneOverrideFlagName = "override.ecotone"
@@ -42,6 +43,7 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 			Category: category,
 		},
 		CLINetworkFlag(envPrefix, category),
+		CLINetworkRegistryFlag(envPrefix, category),
 		CLIRollupConfigFlag(envPrefix, category),
 	}
 }
@@ -55,6 +57,15 @@ func CLINetworkFlag(envPrefix string, category string) cli.Flag {
 	}
 }
 
+func CLINetworkRegistryFlag(envPrefix string, category string) cli.Flag {
+	return &cli.StringFlag{
+		Name:     NetworkRegistryFlagName,
+		Usage:    "Directory of additional networks to select from, with a subdirectory of rollup.json, addresses.json and deploy-config.json per network",
+		EnvVars:  opservice.PrefixEnvVar(envPrefix, "NETWORK_REGISTRY"),
+		Category: category,
+	}
+}
+
 func CLIRollupConfigFlag(envPrefix string, category string) cli.Flag {
 	return &cli.StringFlag{
 		Name:     RollupConfigFlagName,
@@ -18,6 +18,9 @@ type RPCMetricer interface {
 	RecordRPCServerRequest(method string) func()
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	// [Kroma: START]
+	RecordRPCServerRejection(method string, reason string)
+	// [Kroma: END]
 }
 
 // RPCMetrics tracks all the RPC metrics for the op-service RPC.
@@ -27,6 +30,9 @@ type RPCMetrics struct {
 	RPCClientRequestsTotal          *prometheus.CounterVec
 	RPCClientRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientResponsesTotal         *prometheus.CounterVec
+	// [Kroma: START]
+	RPCServerRejectionsTotal *prometheus.CounterVec
+	// [Kroma: END]
 }
 
 // MakeRPCMetrics creates a new RPCMetrics instance with the given process name, and
@@ -76,6 +82,17 @@ func MakeRPCMetrics(ns string, factory Factory) RPCMetrics {
 			"method",
 			"error",
 		}),
+		// [Kroma: START]
+		RPCServerRejectionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "rejections_total",
+			Help:      "Total requests to the RPC server rejected by the RPC policy",
+		}, []string{
+			"method",
+			"reason",
+		}),
+		// [Kroma: END]
 	}
 }
 
@@ -126,6 +143,15 @@ func (m *RPCMetrics) RecordRPCClientResponse(method string, err error) {
 	m.RPCClientResponsesTotal.WithLabelValues(method, errStr).Inc()
 }
 
+// [Kroma: START]
+
+// RecordRPCServerRejection records a call rejected by the RPC policy, labeled by the rule of the call.
+func (m *RPCMetrics) RecordRPCServerRejection(method string, reason string) {
+	m.RPCServerRejectionsTotal.WithLabelValues(method, reason).Inc()
+}
+
+// [Kroma: END]
+
 type NoopRPCMetrics struct{}
 
 func (n *NoopRPCMetrics) RecordRPCServerRequest(method string) func() {
@@ -138,4 +164,10 @@ func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 func (n *NoopRPCMetrics) RecordRPCClientResponse(method string, err error) {
 }
 
+// [Kroma: START]
+func (n *NoopRPCMetrics) RecordRPCServerRejection(method string, reason string) {
+}
+
+// [Kroma: END]
+
 var _ RPCMetricer = (*NoopRPCMetrics)(nil)
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,6 +13,9 @@ const (
 	ListenAddrFlagName  = "rpc.addr"
 	PortFlagName        = "rpc.port"
 	EnableAdminFlagName = "rpc.enable-admin"
+	// [Kroma: START]
+	PolicyFlagName = "rpc.policy"
+	// [Kroma: END]
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -33,6 +37,13 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:   "Enable the admin API",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+		// [Kroma: START]
+		&cli.StringFlag{
+			Name:    PolicyFlagName,
+			Usage:   "Path to a JSON RPC policy with per-method allow/deny, auth and rate limit rules",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_POLICY"),
+		},
+		// [Kroma: END]
 	}
 }
 
@@ -40,6 +51,9 @@ type CLIConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// [Kroma: START]
+	PolicyPath string
+	// [Kroma: END]
 }
 
 func DefaultCLIConfig() CLIConfig {
@@ -58,10 +72,29 @@ func (c CLIConfig) Check() error {
 	return nil
 }
 
+// [Kroma: START]
+
+// PolicyOption returns the option that applies the RPC policy of the config, which does nothing without a policy.
+func (c CLIConfig) PolicyOption(m opmetrics.RPCMetricer) (ServerOption, error) {
+	if c.PolicyPath == "" {
+		return func(b *Server) {}, nil
+	}
+	policy, err := LoadPolicy(c.PolicyPath)
+	if err != nil {
+		return nil, err
+	}
+	return WithPolicy(policy, m), nil
+}
+
+// [Kroma: END]
+
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		ListenAddr:  ctx.String(ListenAddrFlagName),
 		ListenPort:  ctx.Int(PortFlagName),
 		EnableAdmin: ctx.Bool(EnableAdminFlagName),
+		// [Kroma: START]
+		PolicyPath: ctx.String(PolicyFlagName),
+		// [Kroma: END]
 	}
 }
@@ -0,0 +1,515 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/time/rate"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const (
+	// DefaultRuleKey is the key of the rule of the methods without a method or namespace rule.
+	DefaultRuleKey = "*"
+
+	// defaultMaxRequestSize is the request size limit of geth, used when the policy does not set one.
+	defaultMaxRequestSize = 5 * 1024 * 1024
+	// jwtExpiryTimeout is the time a JWT is accepted after it is issued, as in the geth JWT handler.
+	jwtExpiryTimeout = 60 * time.Second
+	// clientIdleTimeout is the time after which the rate limit state of an idle client is dropped.
+	clientIdleTimeout = 10 * time.Minute
+	// maxIdleLimiters is the number of rate limited clients above which the idle clients are dropped.
+	maxIdleLimiters = 10_000
+)
+
+// The reasons of the rejections, used as metric labels.
+const (
+	RejectDenied       = "denied"
+	RejectUnauthorized = "unauthorized"
+	RejectRateLimited  = "rate_limited"
+	RejectTooLarge     = "too_large"
+	RejectInvalid      = "invalid"
+)
+
+// unknownMethod is the metric label of the rejected requests whose method cannot be read.
+const unknownMethod = "<unknown>"
+
+// The JSON-RPC error codes of the rejections.
+const (
+	errcodeParse        = -32700
+	errcodeNotFound     = -32601
+	errcodeUnauthorized = -32001
+	errcodeRejected     = -32000
+	errcodeLimited      = -32005
+)
+
+// MethodRule is the policy of a method or a namespace.
+type MethodRule struct {
+	// Deny rejects the calls as if the methods did not exist.
+	Deny bool `json:"deny"`
+	// Public serves the calls without a JWT, when the server has a JWT secret.
+	// Without a JWT secret all the methods are public.
+	Public bool `json:"public"`
+	// RateLimit is the number of calls per second allowed per client, 0 for no limit.
+	// A client is the subject of its JWT, or its IP if it has no JWT subject.
+	RateLimit float64 `json:"rateLimit"`
+	// Burst is the number of calls a client can make at once, the rate limit rounded up if 0.
+	Burst int `json:"burst"`
+}
+
+// Policy restricts the calls to the RPC server, so methods of different sensitivity can be served on the same port.
+// The rule of a call is the rule of its method ("admin_startSequencer"), else of its namespace ("admin_*"),
+// else the default rule ("*"). Without a default rule, the other methods are allowed without limit.
+type Policy struct {
+	Rules map[string]MethodRule `json:"rules"`
+	// MaxRequestSize is the maximum size of a request body in bytes, the geth limit of 5MB if 0.
+	MaxRequestSize int64 `json:"maxRequestSize"`
+	// JWTSecretPath is the path of the hex encoded 32 bytes JWT secret, which overrides the secret of the server.
+	JWTSecretPath string `json:"jwtSecretPath"`
+	// ClientIPHeader is the header the client IP is read from, e.g. X-Forwarded-For behind a proxy.
+	// The last entry of the header is used, which is the one appended by the proxy in front of the server,
+	// as the entries before it are set by the client. The remote address of the connection is used if empty.
+	ClientIPHeader string `json:"clientIPHeader"`
+
+	jwtSecret []byte
+}
+
+// LoadPolicy reads a JSON policy from a file, and the JWT secret it refers to.
+func LoadPolicy(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open RPC policy: %w", err)
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	var policy Policy
+	if err := dec.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to decode RPC policy %s: %w", path, err)
+	}
+	if err := policy.Check(); err != nil {
+		return nil, fmt.Errorf("invalid RPC policy %s: %w", path, err)
+	}
+	if policy.JWTSecretPath != "" {
+		data, err := os.ReadFile(policy.JWTSecretPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RPC JWT secret: %w", err)
+		}
+		secret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != 32 {
+			return nil, fmt.Errorf("invalid RPC JWT secret in path %s, not 32 hex-formatted bytes", policy.JWTSecretPath)
+		}
+		policy.jwtSecret = secret
+	}
+	return &policy, nil
+}
+
+func (p *Policy) Check() error {
+	for key, rule := range p.Rules {
+		if key != DefaultRuleKey {
+			ns, method, ok := strings.Cut(key, "_")
+			if !ok || ns == "" || method == "" || (strings.Contains(method, "*") && method != "*") {
+				return fmt.Errorf("invalid rule key %q, expected a method, a namespace followed by _* or *", key)
+			}
+		}
+		if rule.RateLimit < 0 || math.IsInf(rule.RateLimit, 0) || math.IsNaN(rule.RateLimit) {
+			return fmt.Errorf("invalid rate limit %v of rule %q", rule.RateLimit, key)
+		}
+		if rule.Burst < 0 {
+			return fmt.Errorf("negative burst of rule %q", key)
+		}
+	}
+	if p.MaxRequestSize < 0 {
+		return errors.New("negative max request size")
+	}
+	return nil
+}
+
+// Rule returns the key and the rule that applies to the method.
+func (p *Policy) Rule(method string) (string, MethodRule) {
+	if rule, ok := p.Rules[method]; ok {
+		return method, rule
+	}
+	if ns, _, ok := strings.Cut(method, "_"); ok {
+		key := ns + "_*"
+		if rule, ok := p.Rules[key]; ok {
+			return key, rule
+		}
+	}
+	return DefaultRuleKey, p.Rules[DefaultRuleKey]
+}
+
+type jsonrpcCall struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonrpcErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   jsonrpcError    `json:"error"`
+}
+
+type rejection struct {
+	reason string
+	err    jsonrpcError
+}
+
+type limiterKey struct {
+	rule   string
+	client string
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type policyHandler struct {
+	next      http.Handler
+	ws        http.Handler
+	policy    *Policy
+	jwtSecret []byte
+	log       log.Logger
+	m         opmetrics.RPCMetricer
+
+	// wsMethods are the methods served on the websocket connections, nil if they serve every method.
+	wsMethods []string
+
+	mu       sync.Mutex
+	limiters map[limiterKey]*clientLimiter
+}
+
+// NewPolicyHandler returns a handler that enforces the policy on the JSON-RPC calls before they reach next.
+// The calls of the methods that are not public require a JWT signed with the secret of the policy,
+// or the given secret if the policy has none. The calls on a websocket connection are not checked,
+// and the connection may serve every method, so the upgrade is admitted by the default rule,
+// and refused if any rule is stricter than the default rule.
+func NewPolicyHandler(next http.Handler, policy *Policy, jwtSecret []byte, log log.Logger, m opmetrics.RPCMetricer) http.Handler {
+	return NewWebsocketPolicyHandler(next, next, policy, jwtSecret, log, m, nil)
+}
+
+// NewWebsocketPolicyHandler is NewPolicyHandler for a server whose websocket connections are served by ws,
+// and serve only the given methods. The admitted upgrades are handed to ws, and the other requests to next.
+// The upgrade is admitted if the rules of all these methods allow the client, and takes a token of each of their
+// rate limits. The calls on the connection are not checked.
+func NewWebsocketPolicyHandler(next http.Handler, ws http.Handler, policy *Policy, jwtSecret []byte, log log.Logger, m opmetrics.RPCMetricer, wsMethods []string) http.Handler {
+	if policy.jwtSecret != nil {
+		jwtSecret = policy.jwtSecret
+	}
+	if m == nil {
+		m = new(opmetrics.NoopRPCMetrics)
+	}
+	return &policyHandler{
+		next:      next,
+		ws:        ws,
+		policy:    policy,
+		jwtSecret: jwtSecret,
+		log:       log,
+		m:         m,
+		wsMethods: wsMethods,
+		limiters:  make(map[limiterKey]*clientLimiter),
+	}
+}
+
+func (h *policyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	subject, authenticated := h.authenticate(r)
+	client := "sub:" + subject
+	if subject == "" {
+		client = "ip:" + h.clientIP(r)
+	}
+
+	if IsWebsocket(r) {
+		h.serveWebsocket(w, r, client, authenticated)
+		return
+	}
+	if r.Method != http.MethodPost {
+		// the RPC handler serves no calls on the other requests
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	maxSize := h.policy.MaxRequestSize
+	if maxSize == 0 {
+		maxSize = defaultMaxRequestSize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.reject(unknownMethod, client, &rejection{reason: RejectTooLarge})
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var calls []jsonrpcCall
+	batch := len(bytes.TrimSpace(body)) > 0 && bytes.TrimSpace(body)[0] == '['
+	if batch {
+		err = json.Unmarshal(body, &calls)
+	} else {
+		calls = make([]jsonrpcCall, 1)
+		err = json.Unmarshal(body, &calls[0])
+	}
+	if err != nil {
+		rej := &rejection{reason: RejectInvalid, err: jsonrpcError{Code: errcodeParse, Message: "parse error"}}
+		h.reject(unknownMethod, client, rej)
+		writeRejections(w, false, []jsonrpcCall{{}}, []*rejection{rej})
+		return
+	}
+
+	// check all the calls before taking tokens of the rate limits
+	keys := make([]string, len(calls))
+	rules := make([]MethodRule, len(calls))
+	rejections := make([]*rejection, len(calls))
+	rejected := false
+	for i, call := range calls {
+		keys[i], rules[i] = h.policy.Rule(call.Method)
+		if rej := h.check(rules[i], authenticated); rej != nil {
+			if rej.reason == RejectDenied {
+				rej.err.Message = fmt.Sprintf("the method %s does not exist/is not available", call.Method)
+			}
+			rejections[i] = rej
+			rejected = true
+		}
+	}
+	if !rejected {
+		if i := h.reserve(keys, rules, client); i >= 0 {
+			rejections[i] = rateLimited()
+			rejected = true
+		}
+	}
+	if !rejected {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	for i, rej := range rejections {
+		if rej == nil {
+			rejections[i] = &rejection{err: jsonrpcError{Code: errcodeRejected, Message: "batch contains a rejected call"}}
+			continue
+		}
+		// the rejections are labeled by the rule key, which is the method for the methods with a rule of their own,
+		// so the methods of arbitrary calls do not become labels
+		h.reject(keys[i], client, rej)
+	}
+	writeRejections(w, batch, calls, rejections)
+}
+
+// IsWebsocket returns whether the request is a websocket upgrade. The handlers that serve websocket on the same endpoint
+// as HTTP must route with it, so a request that is checked as an HTTP request is never upgraded, and the reverse.
+func IsWebsocket(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// serveWebsocket admits a websocket connection if the rules of all the methods it serves allow the client.
+func (h *policyHandler) serveWebsocket(w http.ResponseWriter, r *http.Request, client string, authenticated bool) {
+	var keys []string
+	var rules []MethodRule
+	if h.wsMethods == nil {
+		defaultRule := h.policy.Rules[DefaultRuleKey]
+		for key, rule := range h.policy.Rules {
+			if h.stricter(rule, defaultRule) {
+				h.reject(key, client, &rejection{reason: RejectDenied})
+				http.Error(w, fmt.Sprintf("websocket is not available, the rule %s is stricter than the default rule", key), http.StatusForbidden)
+				return
+			}
+		}
+		keys, rules = []string{DefaultRuleKey}, []MethodRule{defaultRule}
+	} else {
+		seen := make(map[string]bool)
+		for _, method := range h.wsMethods {
+			key, rule := h.policy.Rule(method)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys, rules = append(keys, key), append(rules, rule)
+		}
+	}
+
+	for i, rule := range rules {
+		if rej := h.check(rule, authenticated); rej != nil {
+			h.reject(keys[i], client, rej)
+			http.Error(w, rej.err.Message, http.StatusForbidden)
+			return
+		}
+	}
+	if i := h.reserve(keys, rules, client); i >= 0 {
+		h.reject(keys[i], client, rateLimited())
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	h.ws.ServeHTTP(w, r)
+}
+
+// stricter returns whether the rule rejects or limits a call that the default rule allows.
+func (h *policyHandler) stricter(rule MethodRule, defaultRule MethodRule) bool {
+	if rule.Deny && !defaultRule.Deny {
+		return true
+	}
+	if h.jwtSecret != nil && !rule.Public && defaultRule.Public {
+		return true
+	}
+	if rule.RateLimit == 0 {
+		return false
+	}
+	return defaultRule.RateLimit == 0 || rule.RateLimit < defaultRule.RateLimit || burst(rule) < burst(defaultRule)
+}
+
+// check returns the rejection of a call by the rule, if any.
+func (h *policyHandler) check(rule MethodRule, authenticated bool) *rejection {
+	if rule.Deny {
+		return &rejection{reason: RejectDenied, err: jsonrpcError{Code: errcodeNotFound, Message: "the method does not exist/is not available"}}
+	}
+	if h.jwtSecret != nil && !rule.Public && !authenticated {
+		return &rejection{reason: RejectUnauthorized, err: jsonrpcError{Code: errcodeUnauthorized, Message: "unauthorized: missing or invalid token"}}
+	}
+	return nil
+}
+
+func rateLimited() *rejection {
+	return &rejection{reason: RejectRateLimited, err: jsonrpcError{Code: errcodeLimited, Message: "rate limit exceeded"}}
+}
+
+func (h *policyHandler) reject(label string, client string, rej *rejection) {
+	h.m.RecordRPCServerRejection(label, rej.reason)
+	h.log.Debug("Rejected RPC request", "rule", label, "client", client, "reason", rej.reason)
+}
+
+// reserve takes a token from the bucket of the client for the rule of each call, if the rule has a rate limit.
+// If a call is rate limited, the tokens taken for the other calls are given back, and the index of the call
+// is returned, else -1.
+func (h *policyHandler) reserve(keys []string, rules []MethodRule, client string) int {
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	reservations := make([]*rate.Reservation, 0, len(keys))
+	for i, rule := range rules {
+		if rule.RateLimit == 0 {
+			continue
+		}
+		r := h.limiter(keys[i], rule, client, now).ReserveN(now, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			// cancel in the reverse order, so the tokens of the calls that share a bucket are all given back
+			for j := len(reservations) - 1; j >= 0; j-- {
+				reservations[j].CancelAt(now)
+			}
+			return i
+		}
+		reservations = append(reservations, r)
+	}
+	return -1
+}
+
+// limiter returns the rate limiter of the client for the rule. The caller must hold the lock.
+func (h *policyHandler) limiter(key string, rule MethodRule, client string, now time.Time) *rate.Limiter {
+	lk := limiterKey{rule: key, client: client}
+	cl, ok := h.limiters[lk]
+	if !ok {
+		if len(h.limiters) >= maxIdleLimiters {
+			for k, v := range h.limiters {
+				if now.Sub(v.lastSeen) > clientIdleTimeout {
+					delete(h.limiters, k)
+				}
+			}
+		}
+		cl = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(rule.RateLimit), burst(rule))}
+		h.limiters[lk] = cl
+	}
+	cl.lastSeen = now
+	return cl.limiter
+}
+
+// burst returns the number of calls a client can make at once under the rule.
+func burst(rule MethodRule) int {
+	if rule.Burst == 0 {
+		return int(math.Ceil(rule.RateLimit))
+	}
+	return rule.Burst
+}
+
+// authenticate returns whether the request has a valid JWT, and the subject of the JWT.
+func (h *policyHandler) authenticate(r *http.Request) (string, bool) {
+	if h.jwtSecret == nil {
+		return "", false
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(auth, "Bearer "), &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return h.jwtSecret, nil
+	})
+	if err != nil {
+		return "", false
+	}
+	if claims.IssuedAt == nil {
+		return "", false
+	}
+	if since := time.Since(claims.IssuedAt.Time); since > jwtExpiryTimeout || since < -jwtExpiryTimeout {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+func (h *policyHandler) clientIP(r *http.Request) string {
+	if h.policy.ClientIPHeader != "" {
+		// the header may be repeated, the entries of the proxy are the last ones
+		if values := r.Header.Values(h.policy.ClientIPHeader); len(values) > 0 {
+			v := values[len(values)-1]
+			return strings.TrimSpace(v[strings.LastIndex(v, ",")+1:])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeRejections(w http.ResponseWriter, batch bool, calls []jsonrpcCall, rejections []*rejection) {
+	responses := make([]jsonrpcErrorResponse, len(calls))
+	for i, call := range calls {
+		id := call.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		responses[i] = jsonrpcErrorResponse{Version: "2.0", ID: id, Error: rejections[i].err}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	if batch {
+		_ = enc.Encode(responses)
+	} else {
+		_ = enc.Encode(responses[0])
+	}
+}
@@ -0,0 +1,280 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testRejections struct {
+	rejections map[string]int
+}
+
+func (t *testRejections) RecordRPCServerRequest(method string) func() {
+	return func() {}
+}
+
+func (t *testRejections) RecordRPCClientRequest(method string) func(err error) {
+	return func(err error) {}
+}
+
+func (t *testRejections) RecordRPCClientResponse(method string, err error) {
+}
+
+func (t *testRejections) RecordRPCServerRejection(method string, reason string) {
+	t.rejections[method+"/"+reason]++
+}
+
+func startPolicyServer(t *testing.T, policy *Policy, secret []byte) (string, *testRejections) {
+	m := &testRejections{rejections: make(map[string]int)}
+	opts := []ServerOption{
+		WithAPIs([]rpc.API{{Namespace: "test", Service: new(testAPI)}, {Namespace: "admin", Service: new(testAPI)}}),
+		WithLogger(testlog.Logger(t, log.LevelError)),
+		WithPolicy(policy, m),
+	}
+	if secret != nil {
+		opts = append(opts, WithJWTSecret(secret))
+	}
+	server := NewServer("127.0.0.1", 0, "test", opts...)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { _ = server.Stop() })
+	return "http://" + server.Endpoint(), m
+}
+
+func TestPolicyRules(t *testing.T) {
+	secret := make([]byte, 32)
+	secret[0] = 1
+	policy := &Policy{Rules: map[string]MethodRule{
+		"*":               {Deny: true},
+		"admin_*":         {},
+		"test_frobnicate": {Public: true},
+	}}
+	require.NoError(t, policy.Check())
+	endpoint, m := startPolicyServer(t, policy, secret)
+
+	public, err := rpc.Dial(endpoint)
+	require.NoError(t, err)
+	defer public.Close()
+	authed, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPAuth(node.NewJWTAuth([32]byte(secret))))
+	require.NoError(t, err)
+	defer authed.Close()
+
+	var res int
+	require.NoError(t, public.Call(&res, "test_frobnicate", 2))
+	require.Equal(t, 4, res)
+
+	// admin methods require a JWT
+	err = public.Call(&res, "admin_frobnicate", 2)
+	require.ErrorContains(t, err, "unauthorized")
+	require.NoError(t, authed.Call(&res, "admin_frobnicate", 3))
+	require.Equal(t, 6, res)
+
+	// the other methods are denied, even with a JWT
+	err = authed.Call(nil, "health_status")
+	require.ErrorContains(t, err, "does not exist")
+
+	// a batch with a rejected call is rejected
+	batch := []rpc.BatchElem{
+		{Method: "test_frobnicate", Args: []any{1}, Result: new(int)},
+		{Method: "admin_frobnicate", Args: []any{1}, Result: new(int)},
+	}
+	require.NoError(t, public.BatchCall(batch))
+	require.ErrorContains(t, batch[0].Error, "batch contains a rejected call")
+	require.ErrorContains(t, batch[1].Error, "unauthorized")
+
+	require.Equal(t, map[string]int{
+		"admin_*/" + RejectUnauthorized: 2,
+		"*/" + RejectDenied:             1,
+	}, m.rejections)
+}
+
+func TestPolicyRateLimit(t *testing.T) {
+	policy := &Policy{Rules: map[string]MethodRule{
+		"test_frobnicate": {RateLimit: 0.001, Burst: 2},
+	}}
+	endpoint, m := startPolicyServer(t, policy, nil)
+	client, err := rpc.Dial(endpoint)
+	require.NoError(t, err)
+	defer client.Close()
+
+	var res int
+	require.NoError(t, client.Call(&res, "test_frobnicate", 1))
+	require.NoError(t, client.Call(&res, "test_frobnicate", 1))
+	err = client.Call(&res, "test_frobnicate", 1)
+	require.ErrorContains(t, err, "rate limit exceeded")
+	// the other methods have their own rule
+	require.NoError(t, client.Call(nil, "health_status"))
+	require.Equal(t, 1, m.rejections["test_frobnicate/"+RejectRateLimited])
+}
+
+func TestPolicyRateLimitBatch(t *testing.T) {
+	policy := &Policy{Rules: map[string]MethodRule{
+		"test_frobnicate": {RateLimit: 0.001, Burst: 2},
+	}}
+	endpoint, m := startPolicyServer(t, policy, nil)
+	client, err := rpc.Dial(endpoint)
+	require.NoError(t, err)
+	defer client.Close()
+
+	batch := make([]rpc.BatchElem, 3)
+	for i := range batch {
+		batch[i] = rpc.BatchElem{Method: "test_frobnicate", Args: []any{i}, Result: new(int)}
+	}
+	require.NoError(t, client.BatchCall(batch))
+	require.ErrorContains(t, batch[0].Error, "batch contains a rejected call")
+	require.ErrorContains(t, batch[2].Error, "rate limit exceeded")
+	require.Equal(t, 1, m.rejections["test_frobnicate/"+RejectRateLimited])
+
+	// the tokens of the rejected batch are given back
+	var res int
+	require.NoError(t, client.Call(&res, "test_frobnicate", 1))
+	require.NoError(t, client.Call(&res, "test_frobnicate", 1))
+	err = client.Call(&res, "test_frobnicate", 1)
+	require.ErrorContains(t, err, "rate limit exceeded")
+}
+
+func TestPolicyRateLimitPerSubject(t *testing.T) {
+	secret := make([]byte, 32)
+	policy := &Policy{Rules: map[string]MethodRule{
+		"*": {RateLimit: 0.001, Burst: 1},
+	}}
+	endpoint, _ := startPolicyServer(t, policy, secret)
+
+	// limited returns whether a call of the subject is rate limited
+	limited := func(subject string) bool {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Subject:  subject,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		}).SignedString(secret)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"health_status"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return strings.Contains(string(body), "rate limit exceeded")
+	}
+	require.False(t, limited("alice"))
+	require.True(t, limited("alice"))
+	require.False(t, limited("bob"))
+}
+
+func TestPolicyClientIP(t *testing.T) {
+	h := NewPolicyHandler(nil, &Policy{ClientIPHeader: "X-Forwarded-For"}, nil, testlog.Logger(t, log.LevelError), nil).(*policyHandler)
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	req.RemoteAddr = "10.0.0.1:1234"
+	require.Equal(t, "10.0.0.1", h.clientIP(req))
+
+	// the entries set by the client are ignored
+	req.Header.Add("X-Forwarded-For", "1.1.1.1, 2.2.2.2")
+	require.Equal(t, "2.2.2.2", h.clientIP(req))
+	req.Header.Add("X-Forwarded-For", "3.3.3.3")
+	require.Equal(t, "3.3.3.3", h.clientIP(req))
+}
+
+func TestPolicyWebsocket(t *testing.T) {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", new(testAPI)))
+	require.NoError(t, srv.RegisterName("admin", new(testAPI)))
+	dial := func(policy *Policy) (*rpc.Client, error) {
+		h := NewPolicyHandler(srv.WebsocketHandler([]string{"*"}), policy, nil, testlog.Logger(t, log.LevelError), nil)
+		httpSrv := httptest.NewServer(h)
+		t.Cleanup(httpSrv.Close)
+		return rpc.Dial("ws" + strings.TrimPrefix(httpSrv.URL, "http"))
+	}
+
+	// the websocket serves every method, so it is refused if a rule is stricter than the default rule
+	_, err := dial(&Policy{Rules: map[string]MethodRule{"admin_*": {Deny: true}}})
+	require.ErrorContains(t, err, "403")
+	_, err = dial(&Policy{Rules: map[string]MethodRule{"*": {RateLimit: 10}, "admin_*": {RateLimit: 1}}})
+	require.ErrorContains(t, err, "403")
+
+	client, err := dial(&Policy{Rules: map[string]MethodRule{"*": {RateLimit: 1, Burst: 1}, "test_*": {RateLimit: 10}}})
+	require.NoError(t, err)
+	defer client.Close()
+	var res int
+	require.NoError(t, client.Call(&res, "admin_frobnicate", 2))
+	require.Equal(t, 4, res)
+}
+
+func TestPolicyUpgradeHeaderOnPost(t *testing.T) {
+	endpoint, m := startPolicyServer(t, &Policy{Rules: map[string]MethodRule{"admin_*": {Deny: true}}}, nil)
+	// a POST is checked call by call even if it asks for a websocket upgrade
+	client, err := rpc.DialOptions(context.Background(), endpoint,
+		rpc.WithHeader("Upgrade", "websocket"), rpc.WithHeader("Connection", "Upgrade"))
+	require.NoError(t, err)
+	defer client.Close()
+	err = client.Call(nil, "admin_frobnicate", 1)
+	require.ErrorContains(t, err, "does not exist")
+	require.Equal(t, 1, m.rejections["admin_*/"+RejectDenied])
+}
+
+func TestPolicyMaxRequestSize(t *testing.T) {
+	endpoint, m := startPolicyServer(t, &Policy{MaxRequestSize: 100}, nil)
+	client, err := rpc.Dial(endpoint)
+	require.NoError(t, err)
+	defer client.Close()
+
+	var res int
+	require.NoError(t, client.Call(&res, "test_frobnicate", 1))
+	err = client.Call(nil, "test_frobnicate", hexutil.Bytes(make([]byte, 100)))
+	require.ErrorContains(t, err, "413")
+	require.Equal(t, 1, m.rejections[unknownMethod+"/"+RejectTooLarge])
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, os.WriteFile(secretPath, []byte(hexutil.Encode(make([]byte, 32))), 0o600))
+	path := filepath.Join(dir, "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(`{
+		"rules": {
+			"*": {"deny": true},
+			"optimism_outputAtBlock": {"public": true, "rateLimit": 10},
+			"admin_*": {}
+		},
+		"jwtSecretPath": %q
+	}`, secretPath)), 0o600))
+
+	policy, err := LoadPolicy(path)
+	require.NoError(t, err)
+	require.Len(t, policy.jwtSecret, 32)
+
+	key, rule := policy.Rule("optimism_outputAtBlock")
+	require.Equal(t, "optimism_outputAtBlock", key)
+	require.Equal(t, MethodRule{Public: true, RateLimit: 10}, rule)
+	key, _ = policy.Rule("admin_startSequencer")
+	require.Equal(t, "admin_*", key)
+	key, rule = policy.Rule("opp2p_self")
+	require.Equal(t, DefaultRuleKey, key)
+	require.True(t, rule.Deny)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": {"admin*": {}}}`), 0o600))
+	_, err = LoadPolicy(path)
+	require.ErrorContains(t, err, "invalid rule key")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": {}, "unknown": 1}`), 0o600))
+	_, err = LoadPolicy(path)
+	require.Error(t, err)
+}
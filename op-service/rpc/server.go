@@ -17,8 +17,14 @@ import (
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 )
 
+// [Kroma: START]
+var tracer = tracing.Tracer("op-service/rpc")
+
+// [Kroma: END]
+
 var wildcardHosts = []string{"*"}
 
 type Server struct {
@@ -37,6 +43,10 @@ type Server struct {
 	log            log.Logger
 	tls            *ServerTLSConfig
 	middlewares    []Middleware
+	// [Kroma: START]
+	policy     *Policy
+	rpcMetrics opmetrics.RPCMetricer
+	// [Kroma: END]
 }
 
 type ServerTLSConfig struct {
@@ -118,6 +128,19 @@ func WithMiddleware(middleware func(http.Handler) (hdlr http.Handler)) ServerOpt
 	}
 }
 
+// [Kroma: START]
+
+// WithPolicy restricts the calls to the RPC server with the policy, and records the rejections in the metrics.
+// With a policy, the JWT secret is only required by the methods that are not public.
+func WithPolicy(policy *Policy, m opmetrics.RPCMetricer) ServerOption {
+	return func(b *Server) {
+		b.policy = policy
+		b.rpcMetrics = m
+	}
+}
+
+// [Kroma: END]
+
 func NewServer(host string, port int, appVersion string, opts ...ServerOption) *Server {
 	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
 	bs := &Server{
@@ -168,7 +191,19 @@ func (b *Server) Start() error {
 	for _, middleware := range b.middlewares {
 		nodeHdlr = middleware(nodeHdlr)
 	}
+	/* [Kroma: START]
 	nodeHdlr = node.NewHTTPHandlerStack(nodeHdlr, b.corsHosts, b.vHosts, b.jwtSecret)
+	[Kroma: END] */
+	// [Kroma: START]
+	jwtSecret := b.jwtSecret
+	if b.policy != nil {
+		// the policy decides which methods require the JWT
+		nodeHdlr = NewPolicyHandler(nodeHdlr, b.policy, b.jwtSecret, b.log, b.rpcMetrics)
+		jwtSecret = nil
+	}
+	nodeHdlr = tracing.NewHTTPHandler(nodeHdlr, tracer)
+	nodeHdlr = node.NewHTTPHandlerStack(nodeHdlr, b.corsHosts, b.vHosts, jwtSecret)
+	// [Kroma: END]
 
 	mux := http.NewServeMux()
 	mux.Handle(b.rpcPath, nodeHdlr)
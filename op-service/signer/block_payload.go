@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockPayloadArgs represents the arguments to sign a payload that is gossiped over p2p, e.g. an unsafe block.
+// The signer signs keccak256(domain ++ chainID ++ payloadHash) with the key of the sender address.
+type BlockPayloadArgs struct {
+	Domain        [32]byte        `json:"domain"`
+	ChainID       *hexutil.Big    `json:"chainId"`
+	PayloadHash   common.Hash     `json:"payloadHash"`
+	SenderAddress *common.Address `json:"senderAddress"`
+}
+
+// Check checks that the arguments are complete.
+func (args *BlockPayloadArgs) Check() error {
+	if args.ChainID == nil {
+		return errors.New("chainId not specified")
+	}
+	if args.PayloadHash == (common.Hash{}) {
+		return errors.New("payloadHash not specified")
+	}
+	if args.SenderAddress == nil {
+		return errors.New("senderAddress not specified")
+	}
+	return nil
+}
+
+// SignBlockPayload signs a p2p payload with the key of the sender address of the arguments.
+func (s *SignerClient) SignBlockPayload(ctx context.Context, args *BlockPayloadArgs) ([65]byte, error) {
+	var sig [65]byte
+	if err := args.Check(); err != nil {
+		return sig, fmt.Errorf("invalid block payload args: %w", err)
+	}
+
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "opsigner_signBlockPayload", args); err != nil {
+		return sig, fmt.Errorf("opsigner_signBlockPayload failed: %w", err)
+	}
+	if len(result) != len(sig) {
+		return sig, fmt.Errorf("invalid signature length %d", len(result))
+	}
+	copy(sig[:], result)
+	return sig, nil
+}
@@ -11,6 +11,7 @@ package sources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -380,6 +381,39 @@ func (s *EthClient) ReadStorageAt(ctx context.Context, address common.Address, s
 	return common.BytesToHash(value.Bytes()), nil
 }
 
+// [Kroma: START]
+
+// GetTransactionCount returns the nonce of the account at the given block, **without verifying the correctness of the result**.
+func (s *EthClient) GetTransactionCount(ctx context.Context, address common.Address, blockTag string) (uint64, error) {
+	var out hexutil.Uint64
+	err := s.client.CallContext(ctx, &out, "eth_getTransactionCount", address, blockTag)
+	return uint64(out), err
+}
+
+// GetBalance returns the balance of the account at the given block, **without verifying the correctness of the result**.
+func (s *EthClient) GetBalance(ctx context.Context, address common.Address, blockTag string) (*big.Int, error) {
+	var out hexutil.Big
+	err := s.client.CallContext(ctx, &out, "eth_getBalance", address, blockTag)
+	return out.ToInt(), err
+}
+
+// NextBaseFee returns the base fee of the block after the latest block, **without verifying the correctness of the result**.
+func (s *EthClient) NextBaseFee(ctx context.Context) (*big.Int, error) {
+	var out struct {
+		BaseFee []*hexutil.Big `json:"baseFeePerGas"`
+	}
+	if err := s.client.CallContext(ctx, &out, "eth_feeHistory", hexutil.Uint(1), "latest", nil); err != nil {
+		return nil, err
+	}
+	// the fee history ends with the base fee of the block after the last block
+	if len(out.BaseFee) == 0 || out.BaseFee[len(out.BaseFee)-1] == nil {
+		return nil, errors.New("fee history has no base fee")
+	}
+	return out.BaseFee[len(out.BaseFee)-1].ToInt(), nil
+}
+
+// [Kroma: END]
+
 func (s *EthClient) Close() {
 	s.client.Close()
 }
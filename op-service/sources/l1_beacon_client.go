@@ -23,6 +23,9 @@ const (
 	specMethod           = "eth/v1/config/spec"
 	genesisMethod        = "eth/v1/beacon/genesis"
 	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+	// [Kroma: START]
+	blockMethodPrefix = "eth/v2/beacon/blocks/"
+	// [Kroma: END]
 )
 
 type L1BeaconClientConfig struct {
@@ -127,6 +130,24 @@ func (cl *BeaconHTTPClient) BeaconBlobSideCars(ctx context.Context, fetchAllSide
 	return resp, nil
 }
 
+// [Kroma: START]
+
+// BeaconBlockFetcher fetches beacon blocks, it is implemented by BeaconHTTPClient.
+type BeaconBlockFetcher interface {
+	BeaconBlock(ctx context.Context, blockID string) (eth.APIBeaconBlockResponse, error)
+}
+
+// BeaconBlock fetches the beacon block of the given block ID: "head", "finalized", a slot or a block root.
+func (cl *BeaconHTTPClient) BeaconBlock(ctx context.Context, blockID string) (eth.APIBeaconBlockResponse, error) {
+	var resp eth.APIBeaconBlockResponse
+	if err := cl.apiReq(ctx, &resp, blockMethodPrefix+blockID, nil); err != nil {
+		return eth.APIBeaconBlockResponse{}, err
+	}
+	return resp, nil
+}
+
+// [Kroma: END]
+
 type ClientPool[T any] struct {
 	clients []T
 	index   int
@@ -302,3 +323,22 @@ func blobsFromSidecars(blobSidecars []*eth.BlobSidecar, hashes []eth.IndexedBlob
 func (cl *L1BeaconClient) GetVersion(ctx context.Context) (string, error) {
 	return cl.cl.NodeVersion(ctx)
 }
+
+// [Kroma: START]
+
+// SyncParticipation returns the slot of the head beacon block and the share of the sync committee that signed it,
+// a proxy of the attestation participation of the beacon chain.
+func (cl *L1BeaconClient) SyncParticipation(ctx context.Context) (uint64, float64, error) {
+	fetcher, ok := cl.cl.(BeaconBlockFetcher)
+	if !ok {
+		return 0, 0, errors.New("beacon client does not support fetching beacon blocks")
+	}
+	resp, err := fetcher.BeaconBlock(ctx, "head")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch head beacon block: %w", err)
+	}
+	block := resp.Data.Message
+	return uint64(block.Slot), block.Body.SyncAggregate.Participation(), nil
+}
+
+// [Kroma: END]
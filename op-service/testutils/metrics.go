@@ -56,6 +56,12 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
+// [Kroma: START]
+func (n *TestDerivationMetrics) RecordDerivationEvent(stage string, event string) {
+}
+
+// [Kroma: END]
+
 type TestRPCMetrics struct{}
 
 func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
@@ -67,3 +73,8 @@ func (n *TestRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 }
 
 func (n *TestRPCMetrics) RecordRPCClientResponse(method string, err error) {}
+
+// [Kroma: START]
+func (n *TestRPCMetrics) RecordRPCServerRejection(method string, reason string) {}
+
+// [Kroma: END]
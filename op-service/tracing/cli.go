@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnabledFlagName    = "tracing.enabled"
+	EndpointFlagName   = "tracing.endpoint"
+	FileFlagName       = "tracing.file"
+	SampleRateFlagName = "tracing.sample-rate"
+	defaultEndpoint    = "http://127.0.0.1:4318"
+	defaultSampleRate  = 1.0
+)
+
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		Enabled:    false,
+		Endpoint:   defaultEndpoint,
+		SampleRate: defaultSampleRate,
+	}
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return CLIFlagsWithCategory(envPrefix, "")
+}
+
+func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Enable OpenTelemetry tracing",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "OTLP/HTTP endpoint of the collector the traces are exported to",
+			Value:    defaultEndpoint,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_ENDPOINT"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     FileFlagName,
+			Usage:    "File the traces are written to as JSON lines, instead of the collector",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_FILE"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name:     SampleRateFlagName,
+			Usage:    "Fraction of the traces started by this service that are sampled, between 0 and 1. Traces of sampled callers are always sampled.",
+			Value:    defaultSampleRate,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_SAMPLE_RATE"),
+			Category: category,
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled    bool
+	Endpoint   string
+	File       string
+	SampleRate float64
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return errors.New("tracing sample rate must be between 0 and 1")
+	}
+	if c.File != "" {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid tracing endpoint, expected a http(s) url")
+	}
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:    ctx.Bool(EnabledFlagName),
+		Endpoint:   ctx.String(EndpointFlagName),
+		File:       ctx.String(FileFlagName),
+		SampleRate: ctx.Float64(SampleRateFlagName),
+	}
+}
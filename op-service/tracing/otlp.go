@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+// otlpTimeout is the timeout of an export request to the collector.
+const otlpTimeout = 10 * time.Second
+
+// newOTLPExporter returns an exporter of the spans to a collector with OTLP/HTTP,
+// at the /v1/traces path of the endpoint.
+func newOTLPExporter(endpoint string) (*otlptrace.Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint: %w", err)
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(strings.TrimSuffix(u.Path, "/") + "/v1/traces"),
+		otlptracehttp.WithTimeout(otlpTimeout),
+	}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(context.Background(), opts...)
+}
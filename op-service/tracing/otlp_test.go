@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/collector/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := new(coltracepb.ExportTraceServiceRequest)
+		require.NoError(t, proto.Unmarshal(body, req))
+		requests <- req
+	}))
+	defer srv.Close()
+
+	exporter, err := newOTLPExporter(srv.URL + "/collector/")
+	require.NoError(t, err)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "test"))),
+	)
+	tracer := provider.Tracer("test-scope")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	EndSpan(child, errors.New("boom"))
+
+	req := <-requests
+	require.Len(t, req.ResourceSpans, 1)
+	require.Equal(t, "service.name", req.ResourceSpans[0].Resource.Attributes[0].Key)
+	scope := req.ResourceSpans[0].ScopeSpans[0]
+	require.Equal(t, "test-scope", scope.Scope.Name)
+	span := scope.Spans[0]
+	require.Equal(t, "child", span.Name)
+	require.Equal(t, parent.SpanContext().SpanID().String(), hex.EncodeToString(span.ParentSpanId))
+	require.Equal(t, "boom", span.Status.Message)
+
+	parent.End()
+	require.Equal(t, "parent", (<-requests).ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+	require.NoError(t, provider.Shutdown(context.Background()))
+}
+
+func TestOTLPExporterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exporter, err := newOTLPExporter(srv.URL)
+	require.NoError(t, err)
+	provider := sdktrace.NewTracerProvider()
+	_, span := provider.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)})
+	require.ErrorContains(t, err, "400")
+}
@@ -0,0 +1,194 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+)
+
+// BatchMethod is the span name of JSON-RPC batch requests.
+const BatchMethod = "<batch>"
+
+// maxTracedRequestSize is the size of the requests the server spans read the methods of, the geth limit of 5MB.
+const maxTracedRequestSize = 5 * 1024 * 1024
+
+var (
+	enabled    atomic.Bool
+	propagator = propagation.TraceContext{}
+)
+
+// Start sets up the global tracer provider of the service, and returns a function that flushes the spans
+// and stops the tracing. The spans are not recorded if tracing is not enabled.
+func Start(cfg CLIConfig, serviceName string, version string) (func(ctx context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tracing file: %w", err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to create tracing file exporter: %w", err)
+		}
+		exporter, file = exp, f
+	} else {
+		exp, err := newOTLPExporter(cfg.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tracing OTLP exporter: %w", err)
+		}
+		exporter = exp
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRate))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	enabled.Store(true)
+
+	return func(ctx context.Context) error {
+		enabled.Store(false)
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// Enabled returns whether the spans are recorded.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Tracer returns the tracer of an instrumented package. The spans are started with the tracer provider
+// that is global at the time, so package tracers keep working when the tracing is restarted.
+func Tracer(name string) trace.Tracer {
+	return &globalTracer{name: name}
+}
+
+type globalTracer struct {
+	embedded.Tracer
+	name string
+}
+
+func (t *globalTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(t.name).Start(ctx, spanName, opts...)
+}
+
+// EndSpan records the error of the span, if any, and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartRPCClientSpan starts the span of a JSON-RPC call, and returns a context whose requests carry the
+// trace context in their headers, so the spans of the server join the trace.
+func StartRPCClientSpan(ctx context.Context, tracer trace.Tracer, method string, batchSize int) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{attribute.String("rpc.system", "jsonrpc"), attribute.String("rpc.method", method)}
+	if batchSize > 0 {
+		attrs = append(attrs, attribute.Int("rpc.jsonrpc.batch_size", batchSize))
+	}
+	ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	if span.SpanContext().IsValid() {
+		header := make(http.Header)
+		propagator.Inject(ctx, propagation.HeaderCarrier(header))
+		ctx = rpc.NewContextWithHeaders(ctx, header)
+	}
+	return ctx, span
+}
+
+type jsonrpcCall struct {
+	Method string `json:"method"`
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// NewHTTPHandler returns a handler that serves each JSON-RPC request of next in a span,
+// which joins the trace of the caller if the request carries a trace context.
+func NewHTTPHandler(next http.Handler, tracer trace.Tracer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !Enabled() || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// read the methods of the request, and leave the body as is for next
+		head, err := io.ReadAll(io.LimitReader(r.Body, maxTracedRequestSize))
+		if err != nil {
+			http.Error(w, "failed to read request", http.StatusBadRequest)
+			return
+		}
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+
+		name := "<unknown>"
+		var batchSize int
+		if trimmed := bytes.TrimSpace(head); len(trimmed) > 0 && trimmed[0] == '[' {
+			var calls []jsonrpcCall
+			if json.Unmarshal(head, &calls) == nil {
+				name, batchSize = BatchMethod, len(calls)
+			}
+		} else {
+			var call jsonrpcCall
+			if json.Unmarshal(head, &call) == nil && call.Method != "" {
+				name = call.Method
+			}
+		}
+
+		attrs := []attribute.KeyValue{attribute.String("rpc.system", "jsonrpc"), attribute.String("rpc.method", name)}
+		if batchSize > 0 {
+			attrs = append(attrs, attribute.Int("rpc.jsonrpc.batch_size", batchSize))
+		}
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		span.SetAttributes(attribute.Int("http.status_code", rec.status))
+		if rec.status >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, strings.ToLower(http.StatusText(rec.status)))
+		}
+	})
+}
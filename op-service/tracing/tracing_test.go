@@ -0,0 +1,117 @@
+package tracing_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
+)
+
+type testAPI struct{}
+
+func (t *testAPI) Frobnicate(n int) int {
+	return n * 2
+}
+
+// exportedSpan is the part of the spans written by the file exporter the test checks.
+type exportedSpan struct {
+	Name        string
+	SpanKind    trace.SpanKind
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+}
+
+func readSpans(t *testing.T, path string) map[string]exportedSpan {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	spans := make(map[string]exportedSpan)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var span exportedSpan
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans[span.SpanKind.String()+"/"+span.Name] = span
+	}
+	require.NoError(t, scanner.Err())
+	return spans
+}
+
+func TestFileExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	cfg := tracing.DefaultCLIConfig()
+	cfg.Enabled = true
+	cfg.File = path
+	require.NoError(t, cfg.Check())
+	stop, err := tracing.Start(cfg, "test", "v0.0.0")
+	require.NoError(t, err)
+	require.True(t, tracing.Enabled())
+
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", new(testAPI)))
+	httpSrv := httptest.NewServer(tracing.NewHTTPHandler(srv, tracing.Tracer("test-server")))
+	defer httpSrv.Close()
+
+	rpcClient, err := rpc.Dial(httpSrv.URL)
+	require.NoError(t, err)
+	defer rpcClient.Close()
+	c := client.NewBaseRPCClient(rpcClient)
+
+	var res int
+	require.NoError(t, c.CallContext(context.Background(), &res, "test_frobnicate", 2))
+	require.Equal(t, 4, res)
+	batch := []rpc.BatchElem{
+		{Method: "test_frobnicate", Args: []any{1}, Result: new(int)},
+		{Method: "test_frobnicate", Args: []any{2}, Result: new(int)},
+	}
+	require.NoError(t, c.BatchCallContext(context.Background(), batch))
+
+	require.NoError(t, stop(context.Background()))
+	require.False(t, tracing.Enabled())
+
+	spans := readSpans(t, path)
+	for _, name := range []string{"test_frobnicate", tracing.BatchMethod} {
+		clientSpan, ok := spans[trace.SpanKindClient.String()+"/"+name]
+		require.True(t, ok, "missing client span of %s", name)
+		serverSpan, ok := spans[trace.SpanKindServer.String()+"/"+name]
+		require.True(t, ok, "missing server span of %s", name)
+		// the server span joins the trace of the client through the request headers
+		require.Equal(t, clientSpan.SpanContext.TraceID, serverSpan.SpanContext.TraceID)
+		require.Equal(t, clientSpan.SpanContext.SpanID, serverSpan.Parent.SpanID)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	stop, err := tracing.Start(tracing.DefaultCLIConfig(), "test", "v0.0.0")
+	require.NoError(t, err)
+	require.False(t, tracing.Enabled())
+	require.NoError(t, stop(context.Background()))
+}
+
+func TestCheck(t *testing.T) {
+	cfg := tracing.DefaultCLIConfig()
+	cfg.Enabled = true
+	require.NoError(t, cfg.Check())
+	cfg.SampleRate = 1.5
+	require.Error(t, cfg.Check())
+	cfg.SampleRate = 0.5
+	cfg.Endpoint = "127.0.0.1:4318"
+	require.Error(t, cfg.Check())
+	cfg.File = "traces.jsonl"
+	require.NoError(t, cfg.Check())
+}
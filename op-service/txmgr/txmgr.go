@@ -20,9 +20,12 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/tracing"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
@@ -52,6 +55,8 @@ var (
 // ErrTxReceiptNotSucceed is the error returned when tx confirmed but the status is not success.
 var ErrTxReceiptNotSucceed = errors.New("transaction confirmed but the status is not success")
 
+var tracer = tracing.Tracer("op-service/txmgr")
+
 // [Kroma: END]
 
 // TxManager is an interface that allows callers to reliably publish txs,
@@ -224,10 +229,29 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 	defer func() {
 		m.metr.RecordPendingTx(m.pending.Add(-1))
 	}()
+	// [Kroma: START]
+	ctx, span := tracer.Start(ctx, "txmgr.send", trace.WithAttributes(
+		attribute.String("txmgr.name", m.name),
+		attribute.String("tx.from", m.Config.From.Hex()),
+	))
+	if candidate.To != nil {
+		span.SetAttributes(attribute.String("tx.to", candidate.To.Hex()))
+	}
+	// [Kroma: END]
 	receipt, err := m.send(ctx, candidate)
 	if err != nil {
 		m.resetNonce()
 	}
+	// [Kroma: START]
+	if receipt != nil {
+		span.SetAttributes(
+			attribute.String("tx.hash", receipt.TxHash.Hex()),
+			attribute.Int64("tx.block_number", receipt.BlockNumber.Int64()),
+			attribute.Int64("tx.gas_used", int64(receipt.GasUsed)),
+		)
+	}
+	tracing.EndSpan(span, err)
+	// [Kroma: END]
 	return receipt, err
 }
 
@@ -485,6 +509,17 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 
 	l.Info("Publishing transaction")
 
+	// [Kroma: START]
+	ctx, span := tracer.Start(ctx, "txmgr.publish", trace.WithAttributes(
+		attribute.String("txmgr.name", m.name),
+		attribute.Int64("tx.nonce", int64(tx.Nonce())),
+		attribute.Bool("bump", bumpFeesImmediately),
+	))
+	// spanErr is the error the span ends with
+	var spanErr error
+	defer func() { tracing.EndSpan(span, spanErr) }()
+	// [Kroma: END]
+
 	for {
 		// if the tx manager closed, give up without bumping fees or retrying
 		if m.closed.Load() {
@@ -496,11 +531,22 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			if err != nil {
 				l.Error("unable to increase gas", "err", err)
 				m.metr.TxPublished("bump_failed")
+				// [Kroma: START]
+				spanErr = fmt.Errorf("unable to increase gas: %w", err)
+				// [Kroma: END]
 				return tx, false
 			}
 			tx = newTx
 			sendState.bumpCount++
 			l = m.txLogger(tx, true)
+			// [Kroma: START]
+			span.AddEvent("bump", trace.WithAttributes(
+				attribute.String("tx.hash", tx.Hash().Hex()),
+				attribute.String("tx.gas_tip_cap", tx.GasTipCap().String()),
+				attribute.String("tx.gas_fee_cap", tx.GasFeeCap().String()),
+				attribute.Int("bump_count", sendState.bumpCount),
+			))
+			// [Kroma: END]
 		}
 		bumpFeesImmediately = true // bump fees next loop
 
@@ -518,8 +564,14 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 		if err == nil {
 			m.metr.TxPublished("")
 			l.Info("Transaction successfully published")
+			// [Kroma: START]
+			span.SetAttributes(attribute.String("tx.hash", tx.Hash().Hex()))
+			// [Kroma: END]
 			return tx, true
 		}
+		// [Kroma: START]
+		span.AddEvent("send_error", trace.WithAttributes(attribute.String("error", err.Error())))
+		// [Kroma: END]
 
 		switch {
 		case errStringMatch(err, ErrAlreadyReserved):
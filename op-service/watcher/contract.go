@@ -4,15 +4,17 @@ import (
 	"context"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
-
-	"github.com/kroma-network/kroma/kroma-bindings/bindings"
 )
 
+// implementationSlot is the EIP-1967 storage slot of the proxy that holds the implementation address.
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// upgradePollInterval is the interval the implementation of a watched proxy is polled at.
+const upgradePollInterval = time.Minute
+
 type ContractWatcher struct {
 	ctx     context.Context
 	backend *ethclient.Client
@@ -27,8 +29,11 @@ func NewContractWatcher(ctx context.Context, backend *ethclient.Client, l log.Lo
 	}
 }
 
+// WatchUpgraded calls handlerFn, and calls it again every time the implementation of the proxy at the given address changes.
+// The implementation is polled instead of subscribing to the Upgraded event, so no websocket connection is needed,
+// and an upgrade that is removed by an L1 reorg is detected as another change of the implementation.
 func (cw ContractWatcher) WatchUpgraded(address common.Address, handlerFn func() error) error {
-	proxy, err := bindings.NewProxy(address, cw.backend)
+	impl, err := cw.implementation(address)
 	if err != nil {
 		return err
 	}
@@ -38,31 +43,30 @@ func (cw ContractWatcher) WatchUpgraded(address common.Address, handlerFn func()
 		return err
 	}
 
-	opts := &bind.WatchOpts{Context: cw.ctx}
-	upgradedCh := make(chan *bindings.ProxyUpgraded)
-	sub := event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
-		if err != nil {
-			cw.log.Warn("resubscribe contract upgraded event", "err", err)
-		}
-		return proxy.WatchUpgraded(opts, upgradedCh, nil)
-	})
-
 	go func() {
+		ticker := time.NewTicker(upgradePollInterval)
+		defer ticker.Stop()
+
 		for {
 			select {
-			case evt := <-upgradedCh:
-				cw.log.Info("received contract upgraded event", "address", address)
-				err := handlerFn()
+			case <-ticker.C:
+				newImpl, err := cw.implementation(address)
 				if err != nil {
+					cw.log.Warn("failed to get contract implementation", "address", address, "err", err)
+					continue
+				}
+				if newImpl == impl {
+					continue
+				}
+				cw.log.Info("detected contract upgrade", "address", address, "implementation", newImpl)
+				// the implementation is updated only after the handler succeeds, so it is retried at the next poll
+				if err := handlerFn(); err != nil {
 					cw.log.Error("failed to update config", "err", err)
-					<-time.After(time.Second)
-					upgradedCh <- evt
 					continue
 				}
+				impl = newImpl
 				cw.log.Info("config updated")
 			case <-cw.ctx.Done():
-				sub.Unsubscribe()
-				close(upgradedCh)
 				return
 			}
 		}
@@ -70,3 +74,11 @@ func (cw ContractWatcher) WatchUpgraded(address common.Address, handlerFn func()
 
 	return nil
 }
+
+func (cw ContractWatcher) implementation(address common.Address) (common.Address, error) {
+	slot, err := cw.backend.StorageAt(cw.ctx, address, implementationSlot, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(slot), nil
+}